@@ -165,3 +165,12 @@ func (m Metadata) Get(key string) []string {
 
 	return ret
 }
+
+// Keys returns the keys of the metadata, in lower case and in no particular order.
+func (m Metadata) Keys() []string {
+	keys := make([]string, 0, len(m.data))
+	for k := range m.data {
+		keys = append(keys, k)
+	}
+	return keys
+}
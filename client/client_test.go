@@ -89,9 +89,11 @@ func TestMetadata(t *testing.T) {
 	assert.Equal(t, []string{"test-val"}, val)
 
 	assert.Empty(t, md.Get("non-existent-key"))
+	assert.ElementsMatch(t, []string{"test-key", "test-key-2"}, md.Keys())
 }
 
 func TestUninstantiatedMetadata(t *testing.T) {
 	i := Info{}
 	assert.Empty(t, i.Metadata.Get("test"))
+	assert.Empty(t, i.Metadata.Keys())
 }
@@ -10,9 +10,12 @@ import (
 	loggingexporter "go.opentelemetry.io/collector/exporter/loggingexporter"
 	nopexporter "go.opentelemetry.io/collector/exporter/nopexporter"
 	otlpexporter "go.opentelemetry.io/collector/exporter/otlpexporter"
+	otlpfileexporter "go.opentelemetry.io/collector/exporter/otlpfileexporter"
 	otlphttpexporter "go.opentelemetry.io/collector/exporter/otlphttpexporter"
 	"go.opentelemetry.io/collector/extension"
+	adminextension "go.opentelemetry.io/collector/extension/adminextension"
 	ballastextension "go.opentelemetry.io/collector/extension/ballastextension"
+	healthextension "go.opentelemetry.io/collector/extension/healthextension"
 	memorylimiterextension "go.opentelemetry.io/collector/extension/memorylimiterextension"
 	zpagesextension "go.opentelemetry.io/collector/extension/zpagesextension"
 	"go.opentelemetry.io/collector/otelcol"
@@ -21,6 +24,7 @@ import (
 	memorylimiterprocessor "go.opentelemetry.io/collector/processor/memorylimiterprocessor"
 	"go.opentelemetry.io/collector/receiver"
 	nopreceiver "go.opentelemetry.io/collector/receiver/nopreceiver"
+	otlpfilereceiver "go.opentelemetry.io/collector/receiver/otlpfilereceiver"
 	otlpreceiver "go.opentelemetry.io/collector/receiver/otlpreceiver"
 )
 
@@ -29,7 +33,9 @@ func components() (otelcol.Factories, error) {
 	factories := otelcol.Factories{}
 
 	factories.Extensions, err = extension.MakeFactoryMap(
+		adminextension.NewFactory(),
 		ballastextension.NewFactory(),
+		healthextension.NewFactory(),
 		memorylimiterextension.NewFactory(),
 		zpagesextension.NewFactory(),
 	)
@@ -39,6 +45,7 @@ func components() (otelcol.Factories, error) {
 
 	factories.Receivers, err = receiver.MakeFactoryMap(
 		nopreceiver.NewFactory(),
+		otlpfilereceiver.NewFactory(),
 		otlpreceiver.NewFactory(),
 	)
 	if err != nil {
@@ -50,6 +57,7 @@ func components() (otelcol.Factories, error) {
 		loggingexporter.NewFactory(),
 		nopexporter.NewFactory(),
 		otlpexporter.NewFactory(),
+		otlpfileexporter.NewFactory(),
 		otlphttpexporter.NewFactory(),
 	)
 	if err != nil {
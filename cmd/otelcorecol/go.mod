@@ -12,6 +12,7 @@ require (
 	go.opentelemetry.io/collector/confmap/provider/fileprovider v0.100.0
 	go.opentelemetry.io/collector/confmap/provider/httpprovider v0.100.0
 	go.opentelemetry.io/collector/confmap/provider/httpsprovider v0.100.0
+	go.opentelemetry.io/collector/confmap/provider/secretprovider v0.100.0
 	go.opentelemetry.io/collector/confmap/provider/yamlprovider v0.100.0
 	go.opentelemetry.io/collector/connector v0.100.0
 	go.opentelemetry.io/collector/connector/forwardconnector v0.100.0
@@ -20,9 +21,12 @@ require (
 	go.opentelemetry.io/collector/exporter/loggingexporter v0.100.0
 	go.opentelemetry.io/collector/exporter/nopexporter v0.100.0
 	go.opentelemetry.io/collector/exporter/otlpexporter v0.100.0
+	go.opentelemetry.io/collector/exporter/otlpfileexporter v0.100.0
 	go.opentelemetry.io/collector/exporter/otlphttpexporter v0.100.0
 	go.opentelemetry.io/collector/extension v0.100.0
+	go.opentelemetry.io/collector/extension/adminextension v0.100.0
 	go.opentelemetry.io/collector/extension/ballastextension v0.100.0
+	go.opentelemetry.io/collector/extension/healthextension v0.100.0
 	go.opentelemetry.io/collector/extension/memorylimiterextension v0.100.0
 	go.opentelemetry.io/collector/extension/zpagesextension v0.100.0
 	go.opentelemetry.io/collector/otelcol v0.100.0
@@ -31,6 +35,7 @@ require (
 	go.opentelemetry.io/collector/processor/memorylimiterprocessor v0.100.0
 	go.opentelemetry.io/collector/receiver v0.100.0
 	go.opentelemetry.io/collector/receiver/nopreceiver v0.100.0
+	go.opentelemetry.io/collector/receiver/otlpfilereceiver v0.100.0
 	go.opentelemetry.io/collector/receiver/otlpreceiver v0.100.0
 	go.uber.org/goleak v1.3.0
 	golang.org/x/sys v0.20.0
@@ -102,6 +107,7 @@ require (
 	go.opentelemetry.io/contrib/zpages v0.51.0 // indirect
 	go.opentelemetry.io/otel v1.26.0 // indirect
 	go.opentelemetry.io/otel/bridge/opencensus v1.26.0 // indirect
+	go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp v0.2.0-alpha // indirect
 	go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc v1.26.0 // indirect
 	go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp v1.26.0 // indirect
 	go.opentelemetry.io/otel/exporters/otlp/otlptrace v1.26.0 // indirect
@@ -110,8 +116,10 @@ require (
 	go.opentelemetry.io/otel/exporters/prometheus v0.48.0 // indirect
 	go.opentelemetry.io/otel/exporters/stdout/stdoutmetric v1.26.0 // indirect
 	go.opentelemetry.io/otel/exporters/stdout/stdouttrace v1.26.0 // indirect
+	go.opentelemetry.io/otel/log v0.2.0-alpha // indirect
 	go.opentelemetry.io/otel/metric v1.26.0 // indirect
 	go.opentelemetry.io/otel/sdk v1.26.0 // indirect
+	go.opentelemetry.io/otel/sdk/log v0.2.0-alpha // indirect
 	go.opentelemetry.io/otel/sdk/metric v1.26.0 // indirect
 	go.opentelemetry.io/otel/trace v1.26.0 // indirect
 	go.opentelemetry.io/proto/otlp v1.2.0 // indirect
@@ -166,6 +174,8 @@ replace go.opentelemetry.io/collector/confmap/provider/httpprovider => ../../con
 
 replace go.opentelemetry.io/collector/confmap/provider/httpsprovider => ../../confmap/provider/httpsprovider
 
+replace go.opentelemetry.io/collector/confmap/provider/secretprovider => ../../confmap/provider/secretprovider
+
 replace go.opentelemetry.io/collector/confmap/provider/yamlprovider => ../../confmap/provider/yamlprovider
 
 replace go.opentelemetry.io/collector/consumer => ../../consumer
@@ -184,14 +194,20 @@ replace go.opentelemetry.io/collector/exporter/nopexporter => ../../exporter/nop
 
 replace go.opentelemetry.io/collector/exporter/otlpexporter => ../../exporter/otlpexporter
 
+replace go.opentelemetry.io/collector/exporter/otlpfileexporter => ../../exporter/otlpfileexporter
+
 replace go.opentelemetry.io/collector/exporter/otlphttpexporter => ../../exporter/otlphttpexporter
 
 replace go.opentelemetry.io/collector/extension => ../../extension
 
+replace go.opentelemetry.io/collector/extension/adminextension => ../../extension/adminextension
+
 replace go.opentelemetry.io/collector/extension/auth => ../../extension/auth
 
 replace go.opentelemetry.io/collector/extension/ballastextension => ../../extension/ballastextension
 
+replace go.opentelemetry.io/collector/extension/healthextension => ../../extension/healthextension
+
 replace go.opentelemetry.io/collector/extension/memorylimiterextension => ../../extension/memorylimiterextension
 
 replace go.opentelemetry.io/collector/extension/zpagesextension => ../../extension/zpagesextension
@@ -208,6 +224,8 @@ replace go.opentelemetry.io/collector/receiver => ../../receiver
 
 replace go.opentelemetry.io/collector/receiver/nopreceiver => ../../receiver/nopreceiver
 
+replace go.opentelemetry.io/collector/receiver/otlpfilereceiver => ../../receiver/otlpfilereceiver
+
 replace go.opentelemetry.io/collector/receiver/otlpreceiver => ../../receiver/otlpreceiver
 
 replace go.opentelemetry.io/collector/processor/batchprocessor => ../../processor/batchprocessor
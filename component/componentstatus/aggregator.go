@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package componentstatus aggregates the status events reported by the components of the
+// collector into the statuses of their pipelines and of the collector.
+package componentstatus // import "go.opentelemetry.io/collector/component/componentstatus"
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// ExtensionsKey is the key under which the statuses of the extensions, which are not part of
+// any pipeline, are aggregated.
+const ExtensionsKey = "extensions"
+
+// AggregateStatus is a node of the status tree: the collector, a pipeline or a component instance.
+type AggregateStatus struct {
+	// Event is the status event of the component instance, or the status event aggregated from
+	// the children with component.AggregateStatusEvent. It is nil for a collector without any
+	// status reported yet.
+	Event *component.StatusEvent
+
+	// Children are the statuses of the pipelines of the collector, keyed by pipeline ID or
+	// ExtensionsKey, or the statuses of the component instances of a pipeline, keyed by
+	// InstanceKey. It is nil for component instances.
+	Children map[string]*AggregateStatus
+}
+
+// Aggregator aggregates the status events of component instances per pipeline and for the
+// collector. All the methods are safe for concurrent use.
+//
+// The status of a pipeline is aggregated from the statuses of its component instances, and the
+// status of the collector from the statuses of its pipelines, with component.AggregateStatusEvent:
+//  1. If all statuses are the same, it is the aggregated status.
+//  2. Otherwise StatusFatalError wins over StatusPermanentError, then StatusStopping (including
+//     partially stopped), then StatusRecoverableError, and finally StatusStarting.
+type Aggregator struct {
+	mu sync.Mutex
+	// events maps pipeline IDs, and ExtensionsKey, to the events of their component instances.
+	events      map[string]map[string]*component.StatusEvent
+	subscribers map[*subscription]struct{}
+	closed      bool
+}
+
+// Host is implemented by the component.Host of a collector aggregating the status events of its
+// components, so that all the consumers of the statuses share the same Aggregator.
+type Host interface {
+	// StatusAggregator returns the Aggregator of the status events of the components.
+	StatusAggregator() *Aggregator
+}
+
+type subscription struct {
+	ch chan *AggregateStatus
+}
+
+// UnsubscribeFunc stops the subscription, and closes its channel.
+type UnsubscribeFunc func()
+
+// NewAggregator returns a new Aggregator, without any status.
+func NewAggregator() *Aggregator {
+	return &Aggregator{
+		events:      map[string]map[string]*component.StatusEvent{},
+		subscribers: map[*subscription]struct{}{},
+	}
+}
+
+// RecordStatus records the status event of the given component instance, in each of its pipelines,
+// or under ExtensionsKey for extensions. An event with the same status and attributes as the current
+// event of the instance is ignored, so that the timestamp of a status is the time it was first reported.
+func (a *Aggregator) RecordStatus(source *component.InstanceID, event *component.StatusEvent) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := InstanceKey(source)
+	changed := false
+	if len(source.PipelineIDs) == 0 {
+		changed = a.recordLocked(ExtensionsKey, key, event)
+	}
+	for pipelineID := range source.PipelineIDs {
+		changed = a.recordLocked(pipelineID.String(), key, event) || changed
+	}
+	if !changed || len(a.subscribers) == 0 {
+		return
+	}
+
+	status := a.statusLocked()
+	for sub := range a.subscribers {
+		sub.send(status)
+	}
+}
+
+func (a *Aggregator) recordLocked(pipeline string, key string, event *component.StatusEvent) bool {
+	instances, ok := a.events[pipeline]
+	if !ok {
+		instances = map[string]*component.StatusEvent{}
+		a.events[pipeline] = instances
+	}
+	if prev, ok := instances[key]; ok && prev.Status() == event.Status() &&
+		reflect.DeepEqual(prev.Attributes().AsRaw(), event.Attributes().AsRaw()) {
+		return false
+	}
+	instances[key] = event
+	return true
+}
+
+// Status returns a snapshot of the status tree of the collector.
+func (a *Aggregator) Status() *AggregateStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.statusLocked()
+}
+
+func (a *Aggregator) statusLocked() *AggregateStatus {
+	collector := &AggregateStatus{
+		Children: make(map[string]*AggregateStatus, len(a.events)),
+	}
+	pipelineEvents := make(map[string]*component.StatusEvent, len(a.events))
+	for pipeline, instances := range a.events {
+		ps := &AggregateStatus{
+			Event:    component.AggregateStatusEvent(instances),
+			Children: make(map[string]*AggregateStatus, len(instances)),
+		}
+		for key, ev := range instances {
+			ps.Children[key] = &AggregateStatus{Event: ev}
+		}
+		collector.Children[pipeline] = ps
+		pipelineEvents[pipeline] = ps.Event
+	}
+	if len(pipelineEvents) > 0 {
+		collector.Event = component.AggregateStatusEvent(pipelineEvents)
+	}
+	return collector
+}
+
+// Subscribe returns a channel receiving a snapshot of the status tree of the collector every time
+// it changes, starting with the current one. The channel is buffered and only keeps the latest
+// snapshot, so that a slow subscriber does not block the reporting of status events.
+func (a *Aggregator) Subscribe() (<-chan *AggregateStatus, UnsubscribeFunc) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	sub := &subscription{ch: make(chan *AggregateStatus, 1)}
+	if a.closed {
+		close(sub.ch)
+		return sub.ch, func() {}
+	}
+	a.subscribers[sub] = struct{}{}
+	sub.send(a.statusLocked())
+
+	var once sync.Once
+	return sub.ch, func() {
+		once.Do(func() {
+			a.mu.Lock()
+			defer a.mu.Unlock()
+			if _, ok := a.subscribers[sub]; ok {
+				delete(a.subscribers, sub)
+				close(sub.ch)
+			}
+		})
+	}
+}
+
+// Close closes the channels of all the subscriptions.
+func (a *Aggregator) Close() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.closed = true
+	for sub := range a.subscribers {
+		close(sub.ch)
+	}
+	a.subscribers = map[*subscription]struct{}{}
+}
+
+// send replaces the pending snapshot, if any, with the given one. It must be called with the
+// lock of the Aggregator held.
+func (s *subscription) send(status *AggregateStatus) {
+	select {
+	case <-s.ch:
+	default:
+	}
+	s.ch <- status
+}
+
+// InstanceKey returns the key of a component instance in the status tree, made of its kind and
+// ID, e.g. "receiver:otlp".
+func InstanceKey(source *component.InstanceID) string {
+	return strings.ToLower(source.Kind.String()) + ":" + source.ID.String()
+}
+
+// IsUnhealthy returns true if the status event makes a component instance unhealthy: a permanent
+// or fatal error, or a recoverable error reported for at least the given threshold before now.
+func IsUnhealthy(event *component.StatusEvent, now time.Time, recoverableErrorThreshold time.Duration) bool {
+	switch event.Status() {
+	case component.StatusPermanentError, component.StatusFatalError:
+		return true
+	case component.StatusRecoverableError:
+		return now.Sub(event.Timestamp()) >= recoverableErrorThreshold
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package componentstatus
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+var (
+	tracesID  = component.MustNewID("traces")
+	metricsID = component.MustNewID("metrics")
+
+	receiverID = &component.InstanceID{
+		ID:          component.MustNewID("otlp"),
+		Kind:        component.KindReceiver,
+		PipelineIDs: map[component.ID]struct{}{tracesID: {}, metricsID: {}},
+	}
+	exporterID = &component.InstanceID{
+		ID:          component.MustNewIDWithName("otlp", "backend"),
+		Kind:        component.KindExporter,
+		PipelineIDs: map[component.ID]struct{}{tracesID: {}},
+	}
+	extensionID = &component.InstanceID{
+		ID:   component.MustNewID("zpages"),
+		Kind: component.KindExtension,
+	}
+)
+
+func TestAggregatorStatus(t *testing.T) {
+	agg := NewAggregator()
+	st := agg.Status()
+	assert.Nil(t, st.Event)
+	assert.Empty(t, st.Children)
+
+	agg.RecordStatus(extensionID, component.NewStatusEvent(component.StatusOK))
+	agg.RecordStatus(receiverID, component.NewStatusEvent(component.StatusOK))
+	agg.RecordStatus(exporterID, component.NewStatusEvent(component.StatusStarting))
+
+	st = agg.Status()
+	assert.Equal(t, component.StatusStarting, st.Event.Status())
+	require.Len(t, st.Children, 3)
+	assert.Equal(t, component.StatusOK, st.Children[ExtensionsKey].Event.Status())
+	assert.Equal(t, component.StatusOK, st.Children["metrics"].Event.Status())
+	assert.Equal(t, component.StatusStarting, st.Children["traces"].Event.Status())
+	assert.Equal(t, component.StatusOK, st.Children["traces"].Children["receiver:otlp"].Event.Status())
+	assert.Nil(t, st.Children["traces"].Children["receiver:otlp"].Children)
+
+	err := errors.New("connection refused")
+	agg.RecordStatus(exporterID, component.NewRecoverableErrorEvent(err))
+	st = agg.Status()
+	assert.Equal(t, component.StatusRecoverableError, st.Event.Status())
+	assert.Equal(t, err, st.Event.Err())
+	assert.Equal(t, component.StatusRecoverableError, st.Children["traces"].Event.Status())
+	assert.Equal(t, component.StatusOK, st.Children["metrics"].Event.Status())
+
+	agg.RecordStatus(receiverID, component.NewFatalErrorEvent(errors.New("failed")))
+	st = agg.Status()
+	assert.Equal(t, component.StatusFatalError, st.Event.Status())
+	assert.Equal(t, component.StatusFatalError, st.Children["metrics"].Event.Status())
+}
+
+func TestAggregatorKeepsFirstEvent(t *testing.T) {
+	agg := NewAggregator()
+	first := component.NewRecoverableErrorEvent(errors.New("connection refused"))
+	agg.RecordStatus(exporterID, first)
+	agg.RecordStatus(exporterID, component.NewRecoverableErrorEvent(errors.New("connection reset")))
+	assert.Same(t, first, agg.Status().Children["traces"].Children["exporter:otlp/backend"].Event)
+}
+
+func TestAggregatorRecordsAttributes(t *testing.T) {
+	agg := NewAggregator()
+	agg.RecordStatus(exporterID, component.NewStatusEvent(component.StatusOK))
+
+	attrs := pcommon.NewMap()
+	attrs.PutBool("pipeline.paused", true)
+	paused := component.NewStatusEventWithAttributes(component.StatusOK, attrs)
+	agg.RecordStatus(exporterID, paused)
+	st := agg.Status().Children["traces"]
+	assert.Equal(t, component.StatusOK, st.Event.Status())
+	assert.Same(t, paused, st.Children["exporter:otlp/backend"].Event)
+}
+
+func TestAggregatorSubscribe(t *testing.T) {
+	agg := NewAggregator()
+	agg.RecordStatus(receiverID, component.NewStatusEvent(component.StatusStarting))
+
+	ch, unsubscribe := agg.Subscribe()
+	st := <-ch
+	assert.Equal(t, component.StatusStarting, st.Event.Status())
+
+	// Only the latest snapshot is kept.
+	agg.RecordStatus(receiverID, component.NewStatusEvent(component.StatusOK))
+	agg.RecordStatus(receiverID, component.NewRecoverableErrorEvent(errors.New("err")))
+	st = <-ch
+	assert.Equal(t, component.StatusRecoverableError, st.Event.Status())
+
+	// Unchanged statuses are not sent.
+	agg.RecordStatus(receiverID, component.NewRecoverableErrorEvent(errors.New("err")))
+	select {
+	case <-ch:
+		t.Fatal("unexpected status")
+	default:
+	}
+
+	unsubscribe()
+	unsubscribe()
+	_, ok := <-ch
+	assert.False(t, ok)
+	agg.RecordStatus(receiverID, component.NewStatusEvent(component.StatusOK))
+}
+
+func TestAggregatorClose(t *testing.T) {
+	agg := NewAggregator()
+	ch, unsubscribe := agg.Subscribe()
+	assert.Nil(t, (<-ch).Event)
+
+	agg.Close()
+	_, ok := <-ch
+	assert.False(t, ok)
+	unsubscribe()
+
+	ch, unsubscribe = agg.Subscribe()
+	_, ok = <-ch
+	assert.False(t, ok)
+	unsubscribe()
+}
+
+func TestIsUnhealthy(t *testing.T) {
+	now := time.Now()
+	assert.False(t, IsUnhealthy(component.NewStatusEvent(component.StatusOK), now, time.Minute))
+	assert.False(t, IsUnhealthy(component.NewStatusEvent(component.StatusStarting), now, time.Minute))
+	assert.True(t, IsUnhealthy(component.NewPermanentErrorEvent(errors.New("err")), now, time.Minute))
+	assert.True(t, IsUnhealthy(component.NewFatalErrorEvent(errors.New("err")), now, time.Minute))
+
+	ev := component.NewRecoverableErrorEvent(errors.New("err"))
+	assert.False(t, IsUnhealthy(ev, ev.Timestamp().Add(time.Second), time.Minute))
+	assert.True(t, IsUnhealthy(ev, ev.Timestamp().Add(time.Minute), time.Minute))
+	assert.True(t, IsUnhealthy(ev, ev.Timestamp(), 0))
+}
+
+func TestInstanceKey(t *testing.T) {
+	assert.Equal(t, "receiver:otlp", InstanceKey(receiverID))
+	assert.Equal(t, "exporter:otlp/backend", InstanceKey(exporterID))
+	assert.Equal(t, "extension:zpages", InstanceKey(extensionID))
+}
@@ -5,6 +5,8 @@ package component // import "go.opentelemetry.io/collector/component"
 
 import (
 	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 type Status int32
@@ -42,11 +44,13 @@ func (s Status) String() string {
 	return "StatusNone"
 }
 
-// StatusEvent contains a status and timestamp, and can contain an error
+// StatusEvent contains a status and timestamp, and can contain an error and attributes
 type StatusEvent struct {
 	status    Status
 	err       error
 	timestamp time.Time
+	// attributes is nil for the events without attributes.
+	attributes *pcommon.Map
 }
 
 // Status returns the Status (enum) associated with the StatusEvent
@@ -64,6 +68,15 @@ func (ev *StatusEvent) Timestamp() time.Time {
 	return ev.timestamp
 }
 
+// Attributes returns the attributes associated with the StatusEvent, describing the state of
+// the component beyond its status. The returned map must not be modified.
+func (ev *StatusEvent) Attributes() pcommon.Map {
+	if ev.attributes == nil {
+		return pcommon.NewMap()
+	}
+	return *ev.attributes
+}
+
 // NewStatusEvent creates and returns a StatusEvent with the specified status and sets the timestamp
 // time.Now(). To set an error on the event for an error status use one of the dedicated
 // constructors (e.g. NewRecoverableErrorEvent, NewPermanentErrorEvent, NewFatalErrorEvent)
@@ -74,6 +87,14 @@ func NewStatusEvent(status Status) *StatusEvent {
 	}
 }
 
+// NewStatusEventWithAttributes creates and returns a StatusEvent with the specified status and
+// attributes, and a timestamp set to time.Now(). The attributes must not be modified afterwards.
+func NewStatusEventWithAttributes(status Status, attributes pcommon.Map) *StatusEvent {
+	ev := NewStatusEvent(status)
+	ev.attributes = &attributes
+	return ev
+}
+
 // NewRecoverableErrorEvent creates and returns a StatusEvent with StatusRecoverableError, the
 // specified error, and a timestamp set to time.Now().
 func NewRecoverableErrorEvent(err error) *StatusEvent {
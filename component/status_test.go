@@ -9,6 +9,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 func TestNewStatusEvent(t *testing.T) {
@@ -28,10 +30,21 @@ func TestNewStatusEvent(t *testing.T) {
 			require.Equal(t, status, ev.Status())
 			require.Nil(t, ev.Err())
 			require.False(t, ev.Timestamp().IsZero())
+			require.Equal(t, 0, ev.Attributes().Len())
 		})
 	}
 }
 
+func TestNewStatusEventWithAttributes(t *testing.T) {
+	attrs := pcommon.NewMap()
+	attrs.PutBool("pipeline.paused", true)
+	ev := NewStatusEventWithAttributes(StatusOK, attrs)
+	require.Equal(t, StatusOK, ev.Status())
+	require.Nil(t, ev.Err())
+	require.False(t, ev.Timestamp().IsZero())
+	require.Equal(t, map[string]any{"pipeline.paused": true}, ev.Attributes().AsRaw())
+}
+
 func TestStatusEventsWithError(t *testing.T) {
 	statusConstructorMap := map[Status]func(error) *StatusEvent{
 		StatusRecoverableError: NewRecoverableErrorEvent,
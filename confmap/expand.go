@@ -11,6 +11,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 // schemePattern defines the regexp pattern for scheme names.
@@ -18,6 +20,24 @@ import (
 // combination of letters, digits, plus ("+"), period ("."), or hyphen ("-").
 const schemePattern = `[A-Za-z][A-Za-z0-9+.-]+`
 
+const (
+	// defaultSeparator separates an embedded URI of any scheme from the value used when the URI
+	// resolves to an empty value, e.g. `${file:/run/secrets/port||4317}`. The "|" character is not
+	// valid in URLs, and starts no YAML scalar when doubled.
+	defaultSeparator = "||"
+	// requiredSeparator separates an embedded URI of any scheme from the error message reported
+	// when the URI resolves to an empty value, e.g. `${file:/run/secrets/key|?the key must be mounted}`.
+	requiredSeparator = "|?"
+
+	// envScheme is the scheme whose embedded URIs also accept the shell separators below, the
+	// names of the environment variables not containing ":".
+	envScheme = "env"
+	// envDefaultSeparator is the shell form of defaultSeparator, e.g. `${env:PORT:-4317}`.
+	envDefaultSeparator = ":-"
+	// envRequiredSeparator is the shell form of requiredSeparator, e.g. `${env:API_KEY:?the API key must be set}`.
+	envRequiredSeparator = ":?"
+)
+
 var (
 	// Need to match new line as well in the OpaqueValue, so setting the "s" flag. See https://pkg.go.dev/regexp/syntax.
 	uriRegexp = regexp.MustCompile(`(?s:^(?P<Scheme>` + schemePattern + `):(?P<OpaqueValue>.*)$)`)
@@ -139,11 +159,11 @@ func toString(strURI string, input any) (string, error) {
 }
 
 func (mr *Resolver) expandURI(ctx context.Context, uri string) (any, bool, error) {
-	lURI, err := newLocation(uri[2 : len(uri)-1])
+	lURI, fb, err := newLocationWithFallback(uri[2 : len(uri)-1])
 	if err != nil {
 		return nil, false, err
 	}
-	if strings.Contains(lURI.opaqueValue, "$") {
+	if strings.Contains(lURI.opaqueValue, "$") || strings.Contains(fb.value, "$") {
 		return nil, false, fmt.Errorf("the uri %q contains unsupported characters ('$')", lURI.asString())
 	}
 	ret, err := mr.retrieveValue(ctx, lURI)
@@ -152,9 +172,52 @@ func (mr *Resolver) expandURI(ctx context.Context, uri string) (any, bool, error
 	}
 	mr.closers = append(mr.closers, ret.Close)
 	val, err := ret.AsRaw()
+	if err != nil || !isEmptyValue(val) {
+		return val, true, err
+	}
+	val, err = fb.resolve(lURI)
 	return val, true, err
 }
 
+// fallback defines what an embedded URI is replaced with when it resolves to an empty value.
+type fallback struct {
+	// separator is defaultSeparator or requiredSeparator, the env separators being normalized.
+	separator string
+	// value is the default value when separator is defaultSeparator, or the
+	// error message when separator is requiredSeparator.
+	value string
+}
+
+func (fb fallback) resolve(uri location) (any, error) {
+	switch fb.separator {
+	case defaultSeparator:
+		var val any
+		// Parse the default value the same way providers parse retrieved values,
+		// so that `${env:PORT:-4317}` has the same type whether PORT is set or not.
+		if err := yaml.Unmarshal([]byte(fb.value), &val); err != nil || checkRawConfType(val) != nil {
+			return fb.value, nil
+		}
+		return val, nil
+	case requiredSeparator:
+		if fb.value == "" {
+			return nil, fmt.Errorf("required uri %q resolved to an empty value", uri.asString())
+		}
+		return nil, fmt.Errorf("required uri %q resolved to an empty value: %s", uri.asString(), fb.value)
+	}
+	// No fallback, keep the empty value.
+	return nil, nil
+}
+
+// isEmptyValue returns true if the retrieved value is nil or an empty string,
+// which is what providers return for unset or empty environment variables.
+func isEmptyValue(val any) bool {
+	if val == nil {
+		return true
+	}
+	str, ok := val.(string)
+	return ok && str == ""
+}
+
 type location struct {
 	scheme      string
 	opaqueValue string
@@ -164,6 +227,35 @@ func (c location) asString() string {
 	return c.scheme + ":" + c.opaqueValue
 }
 
+// newLocationWithFallback parses an embedded URI that may end with a default value
+// (`scheme:value||default`) or a required marker (`scheme:value|?message`), the env URIs also
+// accepting the shell forms (`env:NAME:-default` and `env:NAME:?message`).
+func newLocationWithFallback(uri string) (location, fallback, error) {
+	lURI, err := newLocation(uri)
+	if err != nil {
+		return location{}, fallback{}, err
+	}
+	separators := map[string]string{defaultSeparator: defaultSeparator, requiredSeparator: requiredSeparator}
+	if lURI.scheme == envScheme {
+		separators[envDefaultSeparator] = defaultSeparator
+		separators[envRequiredSeparator] = requiredSeparator
+	}
+	fb := fallback{}
+	idx, sepLen := -1, 0
+	for sep, normalized := range separators {
+		if i := strings.Index(lURI.opaqueValue, sep); i >= 0 && (idx < 0 || i < idx) {
+			idx, sepLen = i, len(sep)
+			fb.separator = normalized
+		}
+	}
+	if idx < 0 {
+		return lURI, fb, nil
+	}
+	fb.value = lURI.opaqueValue[idx+sepLen:]
+	lURI.opaqueValue = lURI.opaqueValue[:idx]
+	return lURI, fb, nil
+}
+
 func newLocation(uri string) (location, error) {
 	submatches := uriRegexp.FindStringSubmatch(uri)
 	if len(submatches) != 3 {
@@ -330,6 +330,10 @@ func newEnvProvider() ProviderFactory {
 			return NewRetrieved(float64(6.4))
 		case "env:BOOL":
 			return NewRetrieved(true)
+		case "env:EMPTY":
+			return NewRetrieved("")
+		case "env:UNSET":
+			return NewRetrieved(nil)
 		}
 		return nil, errors.New("impossible")
 	})
@@ -378,6 +382,154 @@ func TestResolverExpandReturnError(t *testing.T) {
 	}
 }
 
+func TestResolverExpandFallbackValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		output any
+	}{
+		{
+			name:   "DefaultNotUsed",
+			input:  "${env:HOST:-0.0.0.0}",
+			output: "localhost",
+		},
+		{
+			name:   "DefaultUnset",
+			input:  "${env:UNSET:-0.0.0.0}",
+			output: "0.0.0.0",
+		},
+		{
+			name:   "DefaultEmpty",
+			input:  "${env:EMPTY:-0.0.0.0}",
+			output: "0.0.0.0",
+		},
+		{
+			name:   "DefaultTyped",
+			input:  "${env:UNSET:-4317}",
+			output: 4317,
+		},
+		{
+			name:   "DefaultWithColon",
+			input:  "${env:UNSET:-localhost:4317}",
+			output: "localhost:4317",
+		},
+		{
+			name:   "DefaultEmbedded",
+			input:  "${env:UNSET:-localhost}:${env:EMPTY:-4317}",
+			output: "localhost:4317",
+		},
+		{
+			name:   "DefaultEmptyValue",
+			input:  "${env:UNSET:-}",
+			output: nil,
+		},
+		{
+			name:   "DefaultNested",
+			input:  "${env:UNSET:-${env:HOST}}",
+			output: "localhost",
+		},
+		{
+			name:   "DefaultNestedDefault",
+			input:  "${env:UNSET:-${env:EMPTY:-fallback}}",
+			output: "fallback",
+		},
+		{
+			name:   "OtherProviderKeepsEnvSeparator",
+			input:  "${test:https://example.com/a:-b?c=d:-e}",
+			output: "https://example.com/a:-b?c=d:-e",
+		},
+		{
+			name:   "OtherProviderKeepsEnvRequiredSeparator",
+			input:  "${test:file:/tmp/a:?b.yaml}",
+			output: "file:/tmp/a:?b.yaml",
+		},
+		{
+			name:   "OtherProviderDefaultNotUsed",
+			input:  "${test:https://example.com/a:-b||fallback}",
+			output: "https://example.com/a:-b",
+		},
+		{
+			name:   "OtherProviderDefault",
+			input:  "${empty:/run/secrets/port||4317}",
+			output: 4317,
+		},
+		{
+			name:   "EnvDefault",
+			input:  "${env:UNSET||localhost}",
+			output: "localhost",
+		},
+		{
+			name:   "RequiredSet",
+			input:  "${env:PORT:?PORT must be set}",
+			output: 3044,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newFakeProvider("input", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved(map[string]any{tt.name: tt.input})
+			})
+
+			testProvider := newFakeProvider("test", func(_ context.Context, uri string, _ WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved(uri[5:])
+			})
+			emptyProvider := newFakeProvider("empty", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved("")
+			})
+
+			resolver, err := NewResolver(ResolverSettings{URIs: []string{"input:"}, ProviderFactories: []ProviderFactory{provider, newEnvProvider(), testProvider, emptyProvider}, ConverterFactories: nil})
+			require.NoError(t, err)
+
+			cfgMap, err := resolver.Resolve(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, map[string]any{tt.name: tt.output}, cfgMap.ToStringMap())
+		})
+	}
+}
+
+func TestResolverExpandRequiredValues(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expectedErr string
+	}{
+		{
+			name:        "RequiredUnset",
+			input:       "${env:UNSET:?}",
+			expectedErr: `required uri "env:UNSET" resolved to an empty value`,
+		},
+		{
+			name:        "RequiredEmpty",
+			input:       "https://${env:EMPTY:?the endpoint host must be set}:4318",
+			expectedErr: `required uri "env:EMPTY" resolved to an empty value: the endpoint host must be set`,
+		},
+		{
+			name:        "RequiredOtherProvider",
+			input:       "${empty:/run/secrets/key|?the key must be mounted}",
+			expectedErr: `required uri "empty:/run/secrets/key" resolved to an empty value: the key must be mounted`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newFakeProvider("input", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved(map[string]any{tt.name: tt.input})
+			})
+
+			emptyProvider := newFakeProvider("empty", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved("")
+			})
+
+			resolver, err := NewResolver(ResolverSettings{URIs: []string{"input:"}, ProviderFactories: []ProviderFactory{provider, newEnvProvider(), emptyProvider}, ConverterFactories: nil})
+			require.NoError(t, err)
+
+			_, err = resolver.Resolve(context.Background())
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
 func TestResolverInfiniteExpand(t *testing.T) {
 	const receiverValue = "${test:VALUE}"
 	provider := newFakeProvider("input", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap // import "go.opentelemetry.io/collector/confmap"
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MergeStrategy defines how the Resolver merges the lists of the configurations retrieved
+// from several URIs. Maps are always merged, and other values always replaced.
+type MergeStrategy string
+
+const (
+	// MergeStrategyReplace replaces a list by the list of the last configuration that sets it.
+	// This is the default strategy.
+	MergeStrategyReplace MergeStrategy = "replace"
+	// MergeStrategyAppend appends the elements of a list to the list of the previous configurations.
+	MergeStrategyAppend MergeStrategy = "append"
+	// MergeStrategyPrepend prepends the elements of a list to the list of the previous configurations.
+	MergeStrategyPrepend MergeStrategy = "prepend"
+	// MergeStrategyUniqueAppend appends the elements of a list that are not already part of the
+	// list of the previous configurations.
+	MergeStrategyUniqueAppend MergeStrategy = "unique-append"
+)
+
+// UnmarshalText unmarshals and validates a MergeStrategy.
+func (s *MergeStrategy) UnmarshalText(text []byte) error {
+	strategy := MergeStrategy(text)
+	if err := strategy.validate(); err != nil {
+		return err
+	}
+	*s = strategy
+	return nil
+}
+
+func (s MergeStrategy) validate() error {
+	switch s {
+	case "", MergeStrategyReplace, MergeStrategyAppend, MergeStrategyPrepend, MergeStrategyUniqueAppend:
+		return nil
+	}
+	return fmt.Errorf("unsupported merge strategy %q, expected one of %q, %q, %q or %q",
+		string(s), MergeStrategyReplace, MergeStrategyAppend, MergeStrategyPrepend, MergeStrategyUniqueAppend)
+}
+
+// listMerger merges configurations, merging the lists at the given paths with a strategy.
+type listMerger struct {
+	strategy MergeStrategy
+	// paths are the split paths of the lists merged with strategy, nil for all lists.
+	paths [][]string
+}
+
+func newListMerger(strategy MergeStrategy, paths []string) (listMerger, error) {
+	if err := strategy.validate(); err != nil {
+		return listMerger{}, err
+	}
+	lm := listMerger{strategy: strategy}
+	for _, path := range paths {
+		lm.paths = append(lm.paths, strings.Split(path, KeyDelimiter))
+	}
+	return lm, nil
+}
+
+// merge merges src into dst.
+func (lm listMerger) merge(dst *Conf, src *Conf) error {
+	if lm.strategy == "" || lm.strategy == MergeStrategyReplace {
+		return dst.Merge(src)
+	}
+
+	// Compute the merged lists before merging the configurations, which replaces them.
+	lists := map[string]any{}
+	for _, key := range src.AllKeys() {
+		srcList, ok := src.Get(key).([]any)
+		if !ok || !lm.matches(key) {
+			continue
+		}
+		dstList, ok := dst.Get(key).([]any)
+		if !ok {
+			continue
+		}
+		lists[key] = mergeLists(lm.strategy, dstList, srcList)
+	}
+
+	if err := dst.Merge(src); err != nil {
+		return err
+	}
+	if len(lists) == 0 {
+		return nil
+	}
+	return dst.Merge(NewFromStringMap(lists))
+}
+
+// matches returns true if the strategy applies to the list at the given key.
+func (lm listMerger) matches(key string) bool {
+	if lm.paths == nil {
+		return true
+	}
+	parts := strings.Split(key, KeyDelimiter)
+	for _, path := range lm.paths {
+		if matchPath(path, parts) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPath(path []string, parts []string) bool {
+	if len(path) != len(parts) {
+		return false
+	}
+	for i := range path {
+		if path[i] != "*" && path[i] != parts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func mergeLists(strategy MergeStrategy, dst []any, src []any) []any {
+	merged := make([]any, 0, len(dst)+len(src))
+	switch strategy {
+	case MergeStrategyAppend:
+		merged = append(append(merged, dst...), src...)
+	case MergeStrategyPrepend:
+		merged = append(append(merged, src...), dst...)
+	case MergeStrategyUniqueAppend:
+		merged = append(merged, dst...)
+		for _, elem := range src {
+			if !containsElement(merged, elem) {
+				merged = append(merged, elem)
+			}
+		}
+	}
+	return merged
+}
+
+func containsElement(list []any, elem any) bool {
+	for _, e := range list {
+		if reflect.DeepEqual(e, elem) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confmap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolverMergeStrategy(t *testing.T) {
+	base := map[string]any{
+		"service": map[string]any{
+			"extensions": []any{"health_check", "pprof"},
+			"pipelines": map[string]any{
+				"traces": map[string]any{
+					"receivers":  []any{"otlp"},
+					"processors": []any{"batch"},
+					"exporters":  []any{"otlp"},
+				},
+			},
+		},
+		"receivers": map[string]any{
+			"otlp": map[string]any{"endpoints": []any{"a"}},
+		},
+	}
+	overlay := map[string]any{
+		"service": map[string]any{
+			"extensions": []any{"pprof", "zpages"},
+			"pipelines": map[string]any{
+				"traces": map[string]any{
+					"processors": []any{"memory_limiter"},
+				},
+				"logs": map[string]any{
+					"processors": []any{"batch"},
+				},
+			},
+		},
+		"receivers": map[string]any{
+			"otlp": map[string]any{"endpoints": []any{"b"}},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		strategy           MergeStrategy
+		paths              []string
+		expectedExtensions []any
+		expectedProcessors []any
+		expectedEndpoints  []any
+	}{
+		{
+			name:               "default",
+			expectedExtensions: []any{"pprof", "zpages"},
+			expectedProcessors: []any{"memory_limiter"},
+			expectedEndpoints:  []any{"b"},
+		},
+		{
+			name:               "replace",
+			strategy:           MergeStrategyReplace,
+			expectedExtensions: []any{"pprof", "zpages"},
+			expectedProcessors: []any{"memory_limiter"},
+			expectedEndpoints:  []any{"b"},
+		},
+		{
+			name:               "append",
+			strategy:           MergeStrategyAppend,
+			expectedExtensions: []any{"health_check", "pprof", "pprof", "zpages"},
+			expectedProcessors: []any{"batch", "memory_limiter"},
+			expectedEndpoints:  []any{"a", "b"},
+		},
+		{
+			name:               "prepend",
+			strategy:           MergeStrategyPrepend,
+			expectedExtensions: []any{"pprof", "zpages", "health_check", "pprof"},
+			expectedProcessors: []any{"memory_limiter", "batch"},
+			expectedEndpoints:  []any{"b", "a"},
+		},
+		{
+			name:               "unique-append",
+			strategy:           MergeStrategyUniqueAppend,
+			expectedExtensions: []any{"health_check", "pprof", "zpages"},
+			expectedProcessors: []any{"batch", "memory_limiter"},
+			expectedEndpoints:  []any{"a", "b"},
+		},
+		{
+			name:               "paths",
+			strategy:           MergeStrategyUniqueAppend,
+			paths:              []string{"service::extensions", "service::pipelines::*::processors"},
+			expectedExtensions: []any{"health_check", "pprof", "zpages"},
+			expectedProcessors: []any{"batch", "memory_limiter"},
+			expectedEndpoints:  []any{"b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseProvider := newFakeProvider("base", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved(base)
+			})
+			overlayProvider := newFakeProvider("overlay", func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+				return NewRetrieved(overlay)
+			})
+			resolver, err := NewResolver(ResolverSettings{
+				URIs:              []string{"base:", "overlay:"},
+				ProviderFactories: []ProviderFactory{baseProvider, overlayProvider},
+				MergeStrategy:     tt.strategy,
+				MergePaths:        tt.paths,
+			})
+			require.NoError(t, err)
+
+			conf, err := resolver.Resolve(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedExtensions, conf.Get("service::extensions"))
+			assert.Equal(t, tt.expectedProcessors, conf.Get("service::pipelines::traces::processors"))
+			assert.Equal(t, []any{"otlp"}, conf.Get("service::pipelines::traces::receivers"))
+			// Lists only set by one configuration are kept as is.
+			assert.Equal(t, []any{"batch"}, conf.Get("service::pipelines::logs::processors"))
+			assert.Equal(t, tt.expectedEndpoints, conf.Get("receivers::otlp::endpoints"))
+		})
+	}
+}
+
+func TestResolverMergeStrategyURIs(t *testing.T) {
+	newProvider := func(scheme string, extensions ...any) ProviderFactory {
+		return newFakeProvider(scheme, func(context.Context, string, WatcherFunc) (*Retrieved, error) {
+			return NewRetrieved(map[string]any{"service": map[string]any{"extensions": extensions}})
+		})
+	}
+	resolver, err := NewResolver(ResolverSettings{
+		URIs:              []string{"base:", "overlay:", "override:"},
+		ProviderFactories: []ProviderFactory{newProvider("base", "pprof"), newProvider("overlay", "zpages"), newProvider("override", "health_check")},
+		MergeStrategy:     MergeStrategyAppend,
+		MergeStrategyURIs: 2,
+	})
+	require.NoError(t, err)
+
+	// The list of the last configuration replaces the merged list.
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []any{"health_check"}, conf.Get("service::extensions"))
+
+	resolver, err = NewResolver(ResolverSettings{
+		URIs:              []string{"base:", "overlay:", "override:"},
+		ProviderFactories: []ProviderFactory{newProvider("base", "pprof"), newProvider("overlay", "zpages"), newProvider("override", "health_check")},
+		MergeStrategy:     MergeStrategyAppend,
+		MergeStrategyURIs: 3,
+	})
+	require.NoError(t, err)
+	conf, err = resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []any{"pprof", "zpages", "health_check"}, conf.Get("service::extensions"))
+}
+
+func TestResolverInvalidMergeStrategy(t *testing.T) {
+	_, err := NewResolver(ResolverSettings{
+		URIs:              []string{"mock:"},
+		ProviderFactories: []ProviderFactory{newMockProvider(&mockProvider{})},
+		MergeStrategy:     "invalid",
+	})
+	assert.EqualError(t, err, `invalid map resolver config: unsupported merge strategy "invalid", expected one of "replace", "append", "prepend" or "unique-append"`)
+}
+
+func TestMergeStrategyUnmarshalText(t *testing.T) {
+	var strategy MergeStrategy
+	require.NoError(t, strategy.UnmarshalText([]byte("unique-append")))
+	assert.Equal(t, MergeStrategyUniqueAppend, strategy)
+	assert.Error(t, strategy.UnmarshalText([]byte("merge")))
+	assert.Equal(t, MergeStrategyUniqueAppend, strategy)
+}
+
+func TestMergeListsOfMaps(t *testing.T) {
+	dst := []any{map[string]any{"key": "a"}}
+	src := []any{map[string]any{"key": "a"}, map[string]any{"key": "b"}}
+	assert.Equal(t, []any{map[string]any{"key": "a"}, map[string]any{"key": "b"}}, mergeLists(MergeStrategyUniqueAppend, dst, src))
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Command encryptsecret generates the keys and encrypts the values of the secrets files read by
+// the secret provider.
+//
+// Usage:
+//
+//	encryptsecret -genkey
+//	encryptsecret -name NAME < value
+//
+// The first form prints a new random key, encoded in hex. The second form encrypts the value
+// read from the standard input, without its trailing newline, with the key set with the
+// OTELCOL_SECRETS_KEY or OTELCOL_SECRETS_KEY_FILE environment variable, and prints the entry of
+// the secret to add to the secrets file.
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap/provider/secretprovider"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("encryptsecret", flag.ContinueOnError)
+	genKey := flags.Bool("genkey", false, "Print a new random key, encoded in hex")
+	name := flags.String("name", "", "Name of the secret to encrypt, read from the standard input")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *genKey {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintln(stdout, hex.EncodeToString(key))
+		return err
+	}
+
+	if *name == "" {
+		return errors.New("either the genkey or the name flag must be provided")
+	}
+	key, err := secretprovider.LoadKey()
+	if err != nil {
+		return err
+	}
+	value, err := io.ReadAll(stdin)
+	if err != nil {
+		return err
+	}
+	encrypted, err := secretprovider.Encrypt(key, *name, strings.TrimSuffix(strings.TrimSuffix(string(value), "\n"), "\r"))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(stdout, "%s: %s\n", *name, encrypted)
+	return err
+}
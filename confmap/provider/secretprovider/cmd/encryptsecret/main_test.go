@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/provider/secretprovider"
+)
+
+func TestGenKey(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, run([]string{"-genkey"}, nil, &out))
+	key, err := hex.DecodeString(strings.TrimSpace(out.String()))
+	require.NoError(t, err)
+	assert.Len(t, key, 32)
+}
+
+func TestEncryptSecret(t *testing.T) {
+	var key bytes.Buffer
+	require.NoError(t, run([]string{"-genkey"}, nil, &key))
+	t.Setenv(secretprovider.KeyEnv, strings.TrimSpace(key.String()))
+
+	var out bytes.Buffer
+	require.NoError(t, run([]string{"-name", "api_key"}, strings.NewReader("s3cr3t\n"), &out))
+	assert.Regexp(t, `^api_key: ENC\[AES256_GCM,.*\]\n$`, out.String())
+
+	// The secret is read back by the provider.
+	path := filepath.Join(t.TempDir(), "secrets.yaml")
+	require.NoError(t, os.WriteFile(path, out.Bytes(), 0o600))
+	t.Setenv(secretprovider.FileEnv, path)
+	ret, err := secretprovider.NewFactory().Create(confmaptest.NewNopProviderSettings()).Retrieve(context.Background(), "secret:api_key", nil)
+	require.NoError(t, err)
+	value, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", value)
+}
+
+func TestEncryptSecretErrors(t *testing.T) {
+	assert.EqualError(t, run(nil, nil, &bytes.Buffer{}), "either the genkey or the name flag must be provided")
+
+	t.Setenv(secretprovider.KeyEnv, "invalid")
+	assert.EqualError(t, run([]string{"-name", "api_key"}, strings.NewReader("s3cr3t"), &bytes.Buffer{}), "invalid key, expected 32 bytes encoded in hex or base64")
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package secretprovider // import "go.opentelemetry.io/collector/confmap/provider/secretprovider"
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// keySize is the size of the AES-256 key.
+const keySize = 32
+
+// encryptedPattern matches an encrypted value, in the same format as the values of SOPS files.
+var encryptedPattern = regexp.MustCompile(`^ENC\[AES256_GCM,data:(?P<data>[A-Za-z0-9+/=]*),iv:(?P<iv>[A-Za-z0-9+/=]+),tag:(?P<tag>[A-Za-z0-9+/=]+),type:str\]$`)
+
+// Encrypt encrypts the value of the secret with the given name, using AES-256-GCM with the
+// given 32-byte key. The name is authenticated with the value, so that encrypted values
+// cannot be swapped between secrets. The result can be used as the value of the secret in
+// the secrets file.
+func Encrypt(key []byte, name string, value string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	iv := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(iv); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nil, iv, []byte(value), additionalData(name))
+	data, tag := sealed[:len(sealed)-gcm.Overhead()], sealed[len(sealed)-gcm.Overhead():]
+	return fmt.Sprintf("ENC[AES256_GCM,data:%s,iv:%s,tag:%s,type:str]",
+		base64.StdEncoding.EncodeToString(data),
+		base64.StdEncoding.EncodeToString(iv),
+		base64.StdEncoding.EncodeToString(tag)), nil
+}
+
+func decrypt(key []byte, name string, encrypted string) (string, error) {
+	submatches := encryptedPattern.FindStringSubmatch(strings.TrimSpace(encrypted))
+	if submatches == nil {
+		return "", errors.New("invalid encrypted value, expected ENC[AES256_GCM,data:...,iv:...,tag:...,type:str]")
+	}
+	var parts [3][]byte
+	for i := range parts {
+		var err error
+		if parts[i], err = base64.StdEncoding.DecodeString(submatches[i+1]); err != nil {
+			return "", err
+		}
+	}
+	data, iv, tag := parts[0], parts[1], parts[2]
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(iv) != gcm.NonceSize() {
+		return "", fmt.Errorf("invalid iv size %d", len(iv))
+	}
+	value, err := gcm.Open(nil, iv, append(data, tag...), additionalData(name))
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != keySize {
+		return nil, fmt.Errorf("invalid key size %d, expected %d bytes", len(key), keySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// additionalData returns the data authenticated with the value of the secret, like SOPS does
+// with the path of the value.
+func additionalData(name string) []byte {
+	return []byte(name + ":")
+}
+
+// parseKey decodes a 256-bit key encoded in hex or base64.
+func parseKey(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	if key, err := hex.DecodeString(encoded); err == nil && len(key) == keySize {
+		return key, nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(encoded); err == nil && len(key) == keySize {
+		return key, nil
+	}
+	return nil, fmt.Errorf("invalid key, expected %d bytes encoded in hex or base64", keySize)
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package secretprovider
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestEncryptDecrypt(t *testing.T) {
+	encrypted, err := Encrypt(testKey, "api_key", "s3cr3t")
+	require.NoError(t, err)
+	assert.Regexp(t, encryptedPattern, encrypted)
+
+	value, err := decrypt(testKey, "api_key", encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", value)
+}
+
+func TestDecryptOtherName(t *testing.T) {
+	encrypted, err := Encrypt(testKey, "api_key", "s3cr3t")
+	require.NoError(t, err)
+
+	_, err = decrypt(testKey, "other_key", encrypted)
+	assert.Error(t, err)
+}
+
+func TestDecryptWrongKey(t *testing.T) {
+	encrypted, err := Encrypt(testKey, "api_key", "s3cr3t")
+	require.NoError(t, err)
+
+	_, err = decrypt([]byte("fedcba9876543210fedcba9876543210"), "api_key", encrypted)
+	assert.Error(t, err)
+}
+
+func TestDecryptInvalidValue(t *testing.T) {
+	_, err := decrypt(testKey, "api_key", "s3cr3t")
+	assert.EqualError(t, err, "invalid encrypted value, expected ENC[AES256_GCM,data:...,iv:...,tag:...,type:str]")
+}
+
+func TestEncryptInvalidKey(t *testing.T) {
+	_, err := Encrypt([]byte("short"), "api_key", "s3cr3t")
+	assert.EqualError(t, err, "invalid key size 5, expected 32 bytes")
+}
+
+func TestParseKey(t *testing.T) {
+	key, err := parseKey(hex.EncodeToString(testKey) + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, testKey, key)
+
+	key, err = parseKey(base64.StdEncoding.EncodeToString(testKey))
+	require.NoError(t, err)
+	assert.Equal(t, testKey, key)
+
+	_, err = parseKey("invalid")
+	assert.EqualError(t, err, "invalid key, expected 32 bytes encoded in hex or base64")
+}
@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package secretprovider // import "go.opentelemetry.io/collector/confmap/provider/secretprovider"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	schemeName = "secret"
+
+	// FileEnv is the environment variable holding the path of the encrypted secrets file.
+	FileEnv = "OTELCOL_SECRETS_FILE"
+	// KeyEnv is the environment variable holding the key used to decrypt the secrets.
+	KeyEnv = "OTELCOL_SECRETS_KEY"
+	// KeyFileEnv is the environment variable holding the path of a file containing the key
+	// used to decrypt the secrets. It is used when KeyEnv is not set.
+	KeyFileEnv = "OTELCOL_SECRETS_KEY_FILE"
+
+	defaultPollInterval = 10 * time.Second
+)
+
+type provider struct {
+	logger *zap.Logger
+	// pollInterval is the interval at which watched secrets are checked for changes.
+	pollInterval time.Duration
+}
+
+// NewFactory returns a factory for a confmap.Provider that reads secrets from an encrypted file.
+//
+// This Provider supports "secret" scheme, and can be called with a selector:
+// `secret:NAME_OF_THE_SECRET`
+//
+// The secrets file, set with the OTELCOL_SECRETS_FILE environment variable, is a YAML map from
+// secret names to values encrypted with Encrypt, e.g. with the encryptsecret command of this
+// module. The 256-bit key, encoded in hex or base64, is read from the OTELCOL_SECRETS_KEY
+// environment variable, or from the file set with the OTELCOL_SECRETS_KEY_FILE environment
+// variable.
+//
+// When watched, the secrets file and the key are checked periodically, and a change is reported
+// when the value of the secret changes, so that the secret can be rotated without a restart.
+func NewFactory() confmap.ProviderFactory {
+	return confmap.NewProviderFactory(newProvider)
+}
+
+func newProvider(ps confmap.ProviderSettings) confmap.Provider {
+	logger := ps.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &provider{
+		logger:       logger,
+		pollInterval: defaultPollInterval,
+	}
+}
+
+func (sp *provider) Retrieve(_ context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	if !strings.HasPrefix(uri, schemeName+":") {
+		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
+	}
+	name := uri[len(schemeName)+1:]
+	if name == "" {
+		return nil, fmt.Errorf("%q uri is missing the name of the secret", uri)
+	}
+
+	value, err := loadSecret(name)
+	if err != nil {
+		return nil, err
+	}
+	if watcher == nil {
+		return confmap.NewRetrieved(value)
+	}
+
+	w := &secretWatcher{
+		name:     name,
+		value:    value,
+		logger:   sp.logger,
+		watcher:  watcher,
+		interval: sp.pollInterval,
+		done:     make(chan struct{}),
+	}
+	w.start()
+	return confmap.NewRetrieved(value, confmap.WithRetrievedClose(w.close))
+}
+
+func (*provider) Scheme() string {
+	return schemeName
+}
+
+func (*provider) Shutdown(context.Context) error {
+	return nil
+}
+
+// loadSecret reads and decrypts the secret with the given name.
+func loadSecret(name string) (string, error) {
+	path := os.Getenv(FileEnv)
+	if path == "" {
+		return "", fmt.Errorf("the secrets file must be set with the %s environment variable", FileEnv)
+	}
+	key, err := LoadKey()
+	if err != nil {
+		return "", err
+	}
+
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", fmt.Errorf("unable to read the secrets file %v: %w", path, err)
+	}
+	var secrets map[string]any
+	if err = yaml.Unmarshal(content, &secrets); err != nil {
+		return "", fmt.Errorf("unable to parse the secrets file %v: %w", path, err)
+	}
+	encrypted, ok := secrets[name]
+	if !ok {
+		return "", fmt.Errorf("secret %q is not defined in %v", name, path)
+	}
+	str, ok := encrypted.(string)
+	if !ok {
+		return "", fmt.Errorf("secret %q in %v must be an encrypted string, got %T", name, path, encrypted)
+	}
+	value, err := decrypt(key, name, str)
+	if err != nil {
+		return "", fmt.Errorf("unable to decrypt secret %q in %v: %w", name, path, err)
+	}
+	return value, nil
+}
+
+// LoadKey reads the key from the KeyEnv environment variable, or from the file set with the
+// KeyFileEnv environment variable, as the provider does.
+func LoadKey() ([]byte, error) {
+	if key, ok := os.LookupEnv(KeyEnv); ok {
+		return parseKey(key)
+	}
+	path := os.Getenv(KeyFileEnv)
+	if path == "" {
+		return nil, fmt.Errorf("the decryption key must be set with the %s or %s environment variable", KeyEnv, KeyFileEnv)
+	}
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the key file %v: %w", path, err)
+	}
+	return parseKey(string(content))
+}
+
+// secretWatcher periodically reloads a secret, and notifies the watcher once its value changed.
+type secretWatcher struct {
+	name     string
+	value    string
+	logger   *zap.Logger
+	watcher  confmap.WatcherFunc
+	interval time.Duration
+
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+func (w *secretWatcher) start() {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.done:
+				return
+			case <-ticker.C:
+				value, err := loadSecret(w.name)
+				if err != nil {
+					// The secrets file may be in the middle of being rotated, keep the current value.
+					w.logger.Warn("Failed to reload secret", zap.String("name", w.name), zap.Error(err))
+					continue
+				}
+				if value != w.value {
+					// The configuration is re-fetched after a change, which starts a new watcher.
+					w.watcher(&confmap.ChangeEvent{})
+					return
+				}
+			}
+		}
+	}()
+}
+
+func (w *secretWatcher) close(context.Context) error {
+	w.closeOnce.Do(func() {
+		close(w.done)
+	})
+	w.wg.Wait()
+	return nil
+}
@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package secretprovider
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+const secretSchemePrefix = schemeName + ":"
+
+func createProvider() confmap.Provider {
+	return NewFactory().Create(confmap.ProviderSettings{Logger: zap.NewNop()})
+}
+
+// writeSecrets writes the given secrets, encrypted with testKey, to a file set as the secrets file.
+func writeSecrets(t *testing.T, path string, secrets map[string]string) {
+	content := ""
+	for name, value := range secrets {
+		encrypted, err := Encrypt(testKey, name, value)
+		require.NoError(t, err)
+		content += name + ": " + encrypted + "\n"
+	}
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func setupSecrets(t *testing.T, secrets map[string]string) string {
+	path := filepath.Join(t.TempDir(), "secrets.yaml")
+	writeSecrets(t, path, secrets)
+	t.Setenv(FileEnv, path)
+	t.Setenv(KeyEnv, hex.EncodeToString(testKey))
+	return path
+}
+
+func TestValidateProviderScheme(t *testing.T) {
+	assert.NoError(t, confmaptest.ValidateProviderScheme(createProvider()))
+}
+
+func TestUnsupportedScheme(t *testing.T) {
+	sp := createProvider()
+	_, err := sp.Retrieve(context.Background(), "https://", nil)
+	assert.Error(t, err)
+	assert.NoError(t, sp.Shutdown(context.Background()))
+}
+
+func TestEmptyName(t *testing.T) {
+	sp := createProvider()
+	_, err := sp.Retrieve(context.Background(), secretSchemePrefix, nil)
+	assert.EqualError(t, err, `"secret:" uri is missing the name of the secret`)
+	assert.NoError(t, sp.Shutdown(context.Background()))
+}
+
+func TestSecret(t *testing.T) {
+	setupSecrets(t, map[string]string{"api_key": "s3cr3t", "port": "0123"})
+
+	sp := createProvider()
+	ret, err := sp.Retrieve(context.Background(), secretSchemePrefix+"api_key", nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", raw)
+
+	// Secrets are always strings.
+	ret, err = sp.Retrieve(context.Background(), secretSchemePrefix+"port", nil)
+	require.NoError(t, err)
+	raw, err = ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "0123", raw)
+	assert.NoError(t, sp.Shutdown(context.Background()))
+}
+
+func TestSecretKeyFile(t *testing.T) {
+	setupSecrets(t, map[string]string{"api_key": "s3cr3t"})
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(hex.EncodeToString(testKey)+"\n"), 0600))
+	require.NoError(t, os.Unsetenv(KeyEnv))
+	t.Setenv(KeyFileEnv, keyFile)
+
+	sp := createProvider()
+	ret, err := sp.Retrieve(context.Background(), secretSchemePrefix+"api_key", nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", raw)
+	assert.NoError(t, sp.Shutdown(context.Background()))
+}
+
+func TestSecretErrors(t *testing.T) {
+	path := setupSecrets(t, map[string]string{"api_key": "s3cr3t"})
+
+	sp := createProvider()
+	_, err := sp.Retrieve(context.Background(), secretSchemePrefix+"unknown", nil)
+	assert.EqualError(t, err, `secret "unknown" is not defined in `+path)
+
+	t.Setenv(KeyEnv, hex.EncodeToString([]byte("fedcba9876543210fedcba9876543210")))
+	_, err = sp.Retrieve(context.Background(), secretSchemePrefix+"api_key", nil)
+	assert.EqualError(t, err, `unable to decrypt secret "api_key" in `+path+`: cipher: message authentication failed`)
+
+	require.NoError(t, os.Unsetenv(KeyEnv))
+	_, err = sp.Retrieve(context.Background(), secretSchemePrefix+"api_key", nil)
+	assert.EqualError(t, err, "the decryption key must be set with the OTELCOL_SECRETS_KEY or OTELCOL_SECRETS_KEY_FILE environment variable")
+
+	t.Setenv(FileEnv, "")
+	_, err = sp.Retrieve(context.Background(), secretSchemePrefix+"api_key", nil)
+	assert.EqualError(t, err, "the secrets file must be set with the OTELCOL_SECRETS_FILE environment variable")
+	assert.NoError(t, sp.Shutdown(context.Background()))
+}
+
+func TestSecretRotation(t *testing.T) {
+	path := setupSecrets(t, map[string]string{"api_key": "s3cr3t", "other": "value"})
+
+	sp := createProvider()
+	sp.(*provider).pollInterval = 10 * time.Millisecond
+	changed := make(chan *confmap.ChangeEvent, 1)
+	ret, err := sp.Retrieve(context.Background(), secretSchemePrefix+"api_key", func(event *confmap.ChangeEvent) {
+		changed <- event
+	})
+	require.NoError(t, err)
+
+	// Re-encrypting the same value or changing another secret is not a change.
+	writeSecrets(t, path, map[string]string{"api_key": "s3cr3t", "other": "new value"})
+	select {
+	case <-changed:
+		t.Fatal("unexpected change event")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	writeSecrets(t, path, map[string]string{"api_key": "r0tat3d"})
+	select {
+	case event := <-changed:
+		assert.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a change event")
+	}
+
+	assert.NoError(t, ret.Close(context.Background()))
+	assert.NoError(t, sp.Shutdown(context.Background()))
+}
+
+func TestSecretWatchClose(t *testing.T) {
+	setupSecrets(t, map[string]string{"api_key": "s3cr3t"})
+
+	sp := createProvider()
+	ret, err := sp.Retrieve(context.Background(), secretSchemePrefix+"api_key", func(*confmap.ChangeEvent) {
+		t.Error("unexpected change event")
+	})
+	require.NoError(t, err)
+	assert.NoError(t, ret.Close(context.Background()))
+	// Closing twice is a no-op.
+	assert.NoError(t, ret.Close(context.Background()))
+	assert.NoError(t, sp.Shutdown(context.Background()))
+}
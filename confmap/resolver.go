@@ -23,6 +23,9 @@ type Resolver struct {
 	uris       []location
 	providers  map[string]Provider
 	converters []Converter
+	merger     listMerger
+	// mergerURIs is the number of URIs merged with merger, see ResolverSettings.MergeStrategyURIs.
+	mergerURIs int
 
 	closers []CloseFunc
 	watcher chan error
@@ -60,12 +63,27 @@ type ResolverSettings struct {
 	// ConverterSettings contains settings that will be passed to Converter
 	// factories when instantiating Converters.
 	ConverterSettings ConverterSettings
+
+	// MergeStrategy defines how lists are merged when merging the configurations retrieved
+	// from URIs. If not set, lists are replaced (see MergeStrategyReplace).
+	MergeStrategy MergeStrategy
+
+	// MergePaths restricts MergeStrategy to the lists at the given paths, e.g. "service::extensions".
+	// A path segment "*" matches any key, e.g. "service::pipelines::*::processors".
+	// If empty, MergeStrategy applies to all lists.
+	MergePaths []string
+
+	// MergeStrategyURIs restricts MergeStrategy to the configurations of the first URIs, the lists
+	// of the configurations of the following URIs, e.g. command line overrides, replacing the
+	// merged lists. If 0, MergeStrategy applies to the configurations of all URIs.
+	MergeStrategyURIs int
 }
 
 // NewResolver returns a new Resolver that resolves configuration from multiple URIs.
 //
 // To resolve a configuration the following steps will happen:
-//  1. Retrieves individual configurations from all given "URIs", and merge them in the retrieve order.
+//  1. Retrieves individual configurations from all given "URIs", and merge them in the retrieve order,
+//     merging lists according to the "MergeStrategy".
 //  2. Once the Conf is merged, apply the converters in the given order.
 //
 // After the configuration was resolved the `Resolver` can be used as a single point to watch for updates in
@@ -122,6 +140,11 @@ func NewResolver(set ResolverSettings) (*Resolver, error) {
 		}
 	}
 
+	merger, err := newListMerger(set.MergeStrategy, set.MergePaths)
+	if err != nil {
+		return nil, fmt.Errorf("invalid map resolver config: %w", err)
+	}
+
 	// Safe copy, ensures the slices and maps cannot be changed from the caller.
 	uris := make([]location, len(set.URIs))
 	for i, uri := range set.URIs {
@@ -146,6 +169,8 @@ func NewResolver(set ResolverSettings) (*Resolver, error) {
 		uris:       uris,
 		providers:  providers,
 		converters: converters,
+		merger:     merger,
+		mergerURIs: set.MergeStrategyURIs,
 		watcher:    make(chan error, 1),
 	}, nil
 }
@@ -160,7 +185,7 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 
 	// Retrieves individual configurations from all URIs in the given order, and merge them in retMap.
 	retMap := New()
-	for _, uri := range mr.uris {
+	for i, uri := range mr.uris {
 		ret, err := mr.retrieveValue(ctx, uri)
 		if err != nil {
 			return nil, fmt.Errorf("cannot retrieve the configuration: %w", err)
@@ -170,7 +195,11 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 		if err != nil {
 			return nil, err
 		}
-		if err = retMap.Merge(retCfgMap); err != nil {
+		merger := mr.merger
+		if mr.mergerURIs > 0 && i >= mr.mergerURIs {
+			merger = listMerger{strategy: MergeStrategyReplace}
+		}
+		if err = merger.merge(retMap, retCfgMap); err != nil {
 			return nil, err
 		}
 	}
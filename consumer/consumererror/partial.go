@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+// partial is an error returned when a part of the data was rejected, while
+// the rest of the data was successfully consumed.
+type partial struct {
+	err      error
+	rejected int
+}
+
+// NewPartial wraps an error to indicate that it is a partial error, i.e. an error
+// reporting that the given number of items (spans, metric data points, log records
+// or profiles) were rejected while the other items were successfully consumed.
+// Partial errors must not be retried: the rejected items would be rejected again.
+func NewPartial(err error, rejected int) error {
+	return partial{err: err, rejected: rejected}
+}
+
+func (p partial) Error() string {
+	return "Partial error: " + p.err.Error()
+}
+
+// Unwrap returns the wrapped error for functions Is and As in standard package errors.
+func (p partial) Unwrap() error {
+	return p.err
+}
+
+// IsPartial checks if an error was wrapped with the NewPartial function, which
+// is used to indicate that only a part of the data was rejected. An error joining
+// several errors, e.g. the errors of the consumers of a fanout, is partial only if
+// all of them are: otherwise, some consumer rejected the whole data. Receivers
+// return partial errors to their clients as a partial success.
+func IsPartial(err error) bool {
+	_, ok := rejectedCount(err)
+	return ok
+}
+
+// RejectedCount returns the number of rejected items of a partial error, see
+// IsPartial, or 0 if the error is not a partial error. For an error joining
+// several partial errors, it is the largest number of rejected items.
+func RejectedCount(err error) int {
+	rejected, _ := rejectedCount(err)
+	return rejected
+}
+
+// rejectedCount returns the number of rejected items of a partial error, and
+// whether the error is a partial error.
+func rejectedCount(err error) (int, bool) {
+	switch e := err.(type) {
+	case partial:
+		return e.rejected, true
+	case interface{ Unwrap() []error }:
+		errs := e.Unwrap()
+		if len(errs) == 0 {
+			return 0, false
+		}
+		maxRejected := 0
+		for _, err := range errs {
+			rejected, ok := rejectedCount(err)
+			if !ok {
+				return 0, false
+			}
+			maxRejected = max(maxRejected, rejected)
+		}
+		return maxRejected, true
+	case interface{ Unwrap() error }:
+		return rejectedCount(e.Unwrap())
+	}
+	return 0, false
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPartial(t *testing.T) {
+	var err error
+	assert.False(t, IsPartial(err))
+	assert.Equal(t, 0, RejectedCount(err))
+
+	err = errors.New("testError")
+	assert.False(t, IsPartial(err))
+	assert.Equal(t, 0, RejectedCount(err))
+
+	err = NewPartial(err, 3)
+	assert.True(t, IsPartial(err))
+	assert.Equal(t, 3, RejectedCount(err))
+	assert.EqualError(t, err, "Partial error: testError")
+
+	err = fmt.Errorf("%w", err)
+	assert.True(t, IsPartial(err))
+	assert.Equal(t, 3, RejectedCount(err))
+
+	err = NewPermanent(err)
+	assert.True(t, IsPartial(err))
+	assert.Equal(t, 3, RejectedCount(err))
+}
+
+func TestIsPartialJoined(t *testing.T) {
+	err := errors.Join(NewPartial(errors.New("first"), 3), fmt.Errorf("second: %w", NewPartial(errors.New("second"), 5)))
+	assert.True(t, IsPartial(err))
+	assert.Equal(t, 5, RejectedCount(err))
+
+	// Some consumer rejected the whole data.
+	err = fmt.Errorf("fanout: %w", errors.Join(NewPartial(errors.New("first"), 3), errors.New("second")))
+	assert.False(t, IsPartial(err))
+	assert.Equal(t, 0, RejectedCount(err))
+
+	err = errors.Join(NewPartial(errors.New("first"), 3), NewPermanent(errors.New("second")))
+	assert.False(t, IsPartial(err))
+}
+
+func TestPartial_Unwrap(t *testing.T) {
+	var err error = testErrorType{"testError"}
+	partialErr := NewPartial(err, 1)
+	require.True(t, IsPartial(partialErr))
+
+	target := testErrorType{}
+	require.True(t, errors.As(partialErr, &target))
+	require.Equal(t, err, target)
+}
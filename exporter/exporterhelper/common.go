@@ -16,6 +16,7 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
 	"go.opentelemetry.io/collector/exporter/exporterqueue"
+	"go.opentelemetry.io/collector/internal/logctx"
 )
 
 // requestSender is an abstraction of a sender for a request independent of the type of the data (traces, metrics, logs).
@@ -294,7 +295,7 @@ func (be *baseExporter) send(ctx context.Context, req Request) error {
 	err := be.queueSender.send(ctx, req)
 	if err != nil {
 		be.set.Logger.Error("Exporting failed. Rejecting data."+be.exportFailureMessage,
-			zap.Error(err), zap.Int("rejected_items", req.ItemsCount()))
+			logctx.Field(ctx), zap.Error(err), zap.Int("rejected_items", req.ItemsCount()))
 	}
 	return err
 }
@@ -322,6 +323,17 @@ func (be *baseExporter) Start(ctx context.Context, host component.Host) error {
 	return be.queueSender.Start(ctx, host)
 }
 
+// Drain waits until the requests in the sending queue are sent, including their retries, or the
+// context is done. It returns the number of items left in a memory queue when the context is done,
+// which may be lost on shutdown. The service drains the exporters on shutdown before shutting them down.
+func (be *baseExporter) Drain(ctx context.Context) int {
+	if qs, ok := be.queueSender.(*queueSender); ok {
+		return qs.drain(ctx)
+	}
+	// Without a queue, the data is sent synchronously: the upstream components wait for it to be sent.
+	return 0
+}
+
 func (be *baseExporter) Shutdown(ctx context.Context) error {
 	return multierr.Combine(
 		// First shutdown the retry sender, so the queue sender can flush the queue without retries.
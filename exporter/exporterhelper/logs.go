@@ -15,6 +15,7 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterqueue"
 	"go.opentelemetry.io/collector/exporter/internal/queue"
+	"go.opentelemetry.io/collector/internal/logctx"
 	"go.opentelemetry.io/collector/pdata/plog"
 )
 
@@ -127,6 +128,7 @@ func NewLogsRequestExporter(
 		req, cErr := converter(ctx, ld)
 		if cErr != nil {
 			set.Logger.Error("Failed to convert logs. Dropping data.",
+				logctx.Field(ctx),
 				zap.Int("dropped_log_records", ld.LogRecordCount()),
 				zap.Error(err))
 			return consumererror.NewPermanent(cErr)
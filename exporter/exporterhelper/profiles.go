@@ -15,6 +15,7 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterqueue"
 	"go.opentelemetry.io/collector/exporter/internal/queue"
+	"go.opentelemetry.io/collector/internal/logctx"
 	"go.opentelemetry.io/collector/pdata/pprofile"
 )
 
@@ -135,6 +136,7 @@ func NewProfilesRequestExporter(
 		req, cErr := converter(ctx, ld)
 		if cErr != nil {
 			set.Logger.Error("Failed to convert profiles. Dropping data.",
+				logctx.Field(ctx),
 				zap.Int("dropped_profiles", ld.ProfileCount()),
 				zap.Error(err))
 			return consumererror.NewPermanent(cErr)
@@ -6,6 +6,7 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -18,11 +19,15 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterqueue"
 	"go.opentelemetry.io/collector/exporter/internal/queue"
+	"go.opentelemetry.io/collector/internal/logctx"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
 const defaultQueueSize = 1000
 
+// drainPollInterval is the interval at which a draining queue sender checks whether its queue is empty.
+const drainPollInterval = 10 * time.Millisecond
+
 var (
 	scopeName = "go.opentelemetry.io/collector/exporterhelper"
 )
@@ -81,6 +86,13 @@ type queueSender struct {
 	meter          otelmetric.Meter
 	consumers      *queue.Consumers[Request]
 
+	// persistent is true if the queue persists the requests, so that they are not lost on shutdown.
+	persistent bool
+	// inFlight is the number of requests taken from the queue and not sent yet.
+	inFlight atomic.Int64
+	// pendingItems is the number of items offered to a memory queue and not sent yet.
+	pendingItems atomic.Int64
+
 	metricCapacity otelmetric.Int64ObservableGauge
 	metricSize     otelmetric.Int64ObservableGauge
 }
@@ -94,12 +106,21 @@ func newQueueSender(q exporterqueue.Queue[Request], set exporter.CreateSettings,
 		traceAttribute: attribute.String(obsmetrics.ExporterKey, set.ID.String()),
 		logger:         set.TelemetrySettings.Logger,
 		meter:          set.TelemetrySettings.MeterProvider.Meter(scopeName),
+		persistent:     queue.IsPersistent[Request](q),
 	}
 	consumeFunc := func(ctx context.Context, req Request) error {
+		qs.inFlight.Add(1)
+		items := req.ItemsCount()
+		defer func() {
+			qs.inFlight.Add(-1)
+			if !qs.persistent {
+				qs.pendingItems.Add(-int64(items))
+			}
+		}()
 		err := qs.nextSender.send(ctx, req)
 		if err != nil {
 			set.Logger.Error("Exporting failed. Dropping data."+exportFailureMessage,
-				zap.Error(err), zap.Int("dropped_items", req.ItemsCount()))
+				logctx.Field(ctx), zap.Error(err), zap.Int("dropped_items", req.ItemsCount()))
 		}
 		return err
 	}
@@ -148,6 +169,21 @@ func (qs *queueSender) Shutdown(ctx context.Context) error {
 	return qs.consumers.Shutdown(ctx)
 }
 
+// drain waits until the queue is empty and the requests taken from it are sent, or the context is done.
+// It returns the number of items left in a memory queue, which may be lost on shutdown.
+func (qs *queueSender) drain(ctx context.Context) int {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for qs.queue.Size() > 0 || qs.inFlight.Load() > 0 {
+		select {
+		case <-ctx.Done():
+			return int(qs.pendingItems.Load())
+		case <-ticker.C:
+		}
+	}
+	return 0
+}
+
 // send implements the requestSender interface. It puts the request in the queue.
 func (qs *queueSender) send(ctx context.Context, req Request) error {
 	// Prevent cancellation and deadline to propagate to the context stored in the queue.
@@ -155,7 +191,14 @@ func (qs *queueSender) send(ctx context.Context, req Request) error {
 	c := noCancellationContext{Context: ctx}
 
 	span := trace.SpanFromContext(c)
+	// The items are counted before being offered, as they can be consumed before Offer returns.
+	if !qs.persistent {
+		qs.pendingItems.Add(int64(req.ItemsCount()))
+	}
 	if err := qs.queue.Offer(c, req); err != nil {
+		if !qs.persistent {
+			qs.pendingItems.Add(-int64(req.ItemsCount()))
+		}
 		span.AddEvent("Failed to enqueue item.", trace.WithAttributes(qs.traceAttribute))
 		return err
 	}
@@ -21,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterqueue"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/exporter/internal/queue"
+	"go.opentelemetry.io/collector/internal/logctx"
 )
 
 func TestQueuedRetry_StopWhileWaiting(t *testing.T) {
@@ -101,12 +102,17 @@ func TestQueuedRetry_RejectOnFull(t *testing.T) {
 	t.Cleanup(func() {
 		assert.NoError(t, be.Shutdown(context.Background()))
 	})
-	require.Error(t, be.send(context.Background(), newMockRequest(2, nil)))
+	ctx := context.WithValue(context.Background(), testCtxKey{}, "value")
+	require.Error(t, be.send(ctx, newMockRequest(2, nil)))
 	assert.Len(t, observed.All(), 1)
 	assert.Equal(t, "Exporting failed. Rejecting data.", observed.All()[0].Message)
 	assert.Equal(t, "sending queue is full", observed.All()[0].ContextMap()["error"])
+	// The context is passed to the logger, to correlate the log record with the span of the export.
+	assert.Contains(t, observed.All()[0].Context, logctx.Field(ctx))
 }
 
+type testCtxKey struct{}
+
 func TestQueuedRetryHappyPath(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -312,6 +318,62 @@ func TestQueueRetryWithDisabledQueue(t *testing.T) {
 
 }
 
+func TestQueueSenderDrain(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = 10 * time.Millisecond
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newObservabilityConsumerSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithRetry(rCfg), WithQueue(qCfg))
+	require.NoError(t, err)
+	ocs := be.obsrepSender.(*observabilityConsumerSender)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	// The request fails once, and is sent by the retry sender while draining.
+	mockR := newMockRequest(2, errors.New("transient error"))
+	ocs.run(func() {
+		require.NoError(t, be.send(context.Background(), mockR))
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.Zero(t, be.Drain(ctx))
+	mockR.checkNumRequests(t, 2)
+	ocs.checkSendItemsCount(t, 2)
+	require.Zero(t, be.queueSender.(*queueSender).queue.Size())
+}
+
+func TestQueueSenderDrainTimeout(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	// No consumers, the requests are never taken from the queue.
+	qCfg.NumConsumers = 0
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender,
+		withMarshaler(mockRequestMarshaler), withUnmarshaler(mockRequestUnmarshaler(&mockRequest{})),
+		WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	require.NoError(t, be.send(context.Background(), newMockRequest(2, nil)))
+	require.NoError(t, be.send(context.Background(), newMockRequest(3, nil)))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, 5, be.Drain(ctx))
+}
+
+func TestQueueSenderDrainDisabledQueue(t *testing.T) {
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newNoopObsrepSender)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Zero(t, be.Drain(ctx))
+}
+
 func TestQueueFailedRequestDropped(t *testing.T) {
 	set := exportertest.NewNopCreateSettings()
 	logger, observed := observer.New(zap.ErrorLevel)
@@ -18,6 +18,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/internal/experr"
+	"go.opentelemetry.io/collector/internal/logctx"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
@@ -91,8 +92,9 @@ func (rs *retrySender) send(ctx context.Context, req Request) error {
 			return nil
 		}
 
-		// Immediately drop data on permanent errors.
-		if consumererror.IsPermanent(err) {
+		// Immediately drop data on permanent errors, and on partial errors since the
+		// rejected items would be rejected again.
+		if consumererror.IsPermanent(err) || consumererror.IsPartial(err) {
 			return fmt.Errorf("not retryable error: %w", err)
 		}
 
@@ -117,6 +119,7 @@ func (rs *retrySender) send(ctx context.Context, req Request) error {
 				attribute.String("error", err.Error())))
 		rs.logger.Info(
 			"Exporting failed. Will retry the request after interval.",
+			logctx.Field(ctx),
 			zap.Error(err),
 			zap.String("interval", backoffDelayStr),
 		)
@@ -59,6 +59,45 @@ func TestQueuedRetry_DropOnPermanentError(t *testing.T) {
 	ocs.checkDroppedItemsCount(t, 2)
 }
 
+func TestRetry_NoRetryOnPartialError(t *testing.T) {
+	rCfg := configretry.NewDefaultBackOffConfig()
+	mockR := newMockRequest(2, consumererror.NewPartial(errors.New("bad data"), 1))
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newObservabilityConsumerSender, WithRetry(rCfg))
+	require.NoError(t, err)
+	ocs := be.obsrepSender.(*observabilityConsumerSender)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	ocs.run(func() {
+		err = be.send(context.Background(), mockR)
+		require.Error(t, err)
+		assert.True(t, consumererror.IsPartial(err))
+	})
+	ocs.awaitAsyncProcessing()
+	mockR.checkNumRequests(t, 1)
+}
+
+func TestRetry_RetryOnJoinedPartialAndRetryableError(t *testing.T) {
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = 0
+	mockR := newMockRequest(2, errors.Join(consumererror.NewPartial(errors.New("bad data"), 1), errors.New("transient error")))
+	be, err := newBaseExporter(defaultSettings, defaultDataType, newObservabilityConsumerSender, WithRetry(rCfg))
+	require.NoError(t, err)
+	ocs := be.obsrepSender.(*observabilityConsumerSender)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	ocs.run(func() {
+		require.NoError(t, be.send(context.Background(), mockR))
+	})
+	ocs.awaitAsyncProcessing()
+	mockR.checkNumRequests(t, 2)
+}
+
 func TestQueuedRetry_DropOnNoRetry(t *testing.T) {
 	qCfg := NewDefaultQueueSettings()
 	rCfg := configretry.NewDefaultBackOffConfig()
@@ -15,6 +15,7 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterqueue"
 	"go.opentelemetry.io/collector/exporter/internal/queue"
+	"go.opentelemetry.io/collector/internal/logctx"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
@@ -127,6 +128,7 @@ func NewTracesRequestExporter(
 		req, cErr := converter(ctx, td)
 		if cErr != nil {
 			set.Logger.Error("Failed to convert traces. Dropping data.",
+				logctx.Field(ctx),
 				zap.Int("dropped_spans", td.SpanCount()),
 				zap.Error(err))
 			return consumererror.NewPermanent(cErr)
@@ -178,6 +178,14 @@ func TestPersistentQueue_Shutdown(t *testing.T) {
 
 }
 
+func TestIsPersistent(t *testing.T) {
+	assert.True(t, IsPersistent[tracesRequest](createTestPersistentQueueWithClient(newFakeBoundedStorageClient(1000))))
+	assert.False(t, IsPersistent(NewBoundedMemoryQueue[tracesRequest](MemoryQueueSettings[tracesRequest]{
+		Sizer:    &RequestSizer[tracesRequest]{},
+		Capacity: 10,
+	})))
+}
+
 func TestPersistentQueue_ConsumersProducers(t *testing.T) {
 	cases := []struct {
 		numMessagesProduced int
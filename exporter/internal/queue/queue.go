@@ -57,3 +57,9 @@ type RequestSizer[T any] struct{}
 func (rs *RequestSizer[T]) Sizeof(T) int64 {
 	return 1
 }
+
+// IsPersistent returns whether the queue persists its items in a storage, so that they are not lost on shutdown.
+func IsPersistent[T any](q Queue[T]) bool {
+	_, ok := q.(*persistentQueue[T])
+	return ok
+}
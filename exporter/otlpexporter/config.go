@@ -9,6 +9,7 @@ import (
 	"net"
 	"strconv"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
@@ -23,6 +24,23 @@ type Config struct {
 	RetryConfig                    configretry.BackOffConfig    `mapstructure:"retry_on_failure"`
 
 	configgrpc.ClientConfig `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Streaming configures the export of the traces, metrics and logs on bidirectional streams.
+	Streaming StreamingConfig `mapstructure:"streaming"`
+}
+
+// StreamingConfig defines the configuration of the OTLP streams. The exporter falls back to
+// unary requests if the server does not support the streams.
+type StreamingConfig struct {
+	// Enabled sends the data on streams when set to true.
+	Enabled bool `mapstructure:"enabled"`
+
+	// MaxDictionarySize is the maximum number of entries of each dictionary of a stream.
+	MaxDictionarySize int `mapstructure:"max_dictionary_size"`
+
+	// MaxLifetime is the duration after which a stream is replaced by a new one with empty
+	// dictionaries. If zero, the streams are kept until they fail.
+	MaxLifetime time.Duration `mapstructure:"max_lifetime"`
 }
 
 func (c *Config) Validate() error {
@@ -40,6 +58,13 @@ func (c *Config) Validate() error {
 		return fmt.Errorf(`invalid port "%s"`, port)
 	}
 
+	if c.Streaming.MaxDictionarySize < 0 {
+		return errors.New("streaming max_dictionary_size must not be negative")
+	}
+	if c.Streaming.MaxLifetime < 0 {
+		return errors.New("streaming max_lifetime must not be negative")
+	}
+
 	return nil
 }
 
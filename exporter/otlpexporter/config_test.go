@@ -76,6 +76,11 @@ func TestUnmarshalConfig(t *testing.T) {
 				BalancerName:    "round_robin",
 				Auth:            &configauth.Authentication{AuthenticatorID: component.MustNewID("nop")},
 			},
+			Streaming: StreamingConfig{
+				Enabled:           true,
+				MaxDictionarySize: 1024,
+				MaxLifetime:       5 * time.Minute,
+			},
 		}, cfg)
 }
 
@@ -119,6 +124,14 @@ func TestUnmarshalInvalidConfig(t *testing.T) {
 			name:     "invalid_port",
 			errorMsg: `invalid port "port"`,
 		},
+		{
+			name:     "invalid_streaming_dictionary_size",
+			errorMsg: `streaming max_dictionary_size must not be negative`,
+		},
+		{
+			name:     "invalid_streaming_lifetime",
+			errorMsg: `streaming max_lifetime must not be negative`,
+		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			cfg := factory.CreateDefaultConfig()
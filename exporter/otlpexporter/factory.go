@@ -5,6 +5,7 @@ package otlpexporter // import "go.opentelemetry.io/collector/exporter/otlpexpor
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configcompression"
@@ -17,6 +18,11 @@ import (
 	"go.opentelemetry.io/collector/exporter/otlpexporter/internal/metadata"
 )
 
+const (
+	defaultMaxDictionarySize = 16384
+	defaultMaxLifetime       = 10 * time.Minute
+)
+
 // NewFactory creates a factory for OTLP exporter.
 func NewFactory() exporter.Factory {
 	return exporter.NewFactory(
@@ -41,6 +47,10 @@ func createDefaultConfig() component.Config {
 			// We almost read 0 bytes, so no need to tune ReadBufferSize.
 			WriteBufferSize: 512 * 1024,
 		},
+		Streaming: StreamingConfig{
+			MaxDictionarySize: defaultMaxDictionarySize,
+			MaxLifetime:       defaultMaxLifetime,
+		},
 	}
 }
 
@@ -34,6 +34,7 @@ func TestCreateDefaultConfig(t *testing.T) {
 	assert.Equal(t, ocfg.QueueConfig, exporterhelper.NewDefaultQueueSettings())
 	assert.Equal(t, ocfg.TimeoutSettings, exporterhelper.NewDefaultTimeoutSettings())
 	assert.Equal(t, ocfg.Compression, configcompression.TypeGzip)
+	assert.Equal(t, ocfg.Streaming, StreamingConfig{MaxDictionarySize: 16384, MaxLifetime: 10 * time.Minute})
 }
 
 func TestCreateMetricsExporter(t *testing.T) {
@@ -5,8 +5,10 @@ package otlpexporter // import "go.opentelemetry.io/collector/exporter/otlpexpor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"runtime"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -20,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/internal/otlpstream"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -43,6 +46,10 @@ type baseExporter struct {
 	metadata        metadata.MD
 	callOptions     []grpc.CallOption
 
+	// OTLP streams client, nil if the streams are disabled.
+	streamClient *otlpstream.Client
+	fallbackOnce sync.Once
+
 	settings component.TelemetrySettings
 
 	// Default user-agent header.
@@ -76,11 +83,22 @@ func (e *baseExporter) start(ctx context.Context, host component.Host) (err erro
 	e.callOptions = []grpc.CallOption{
 		grpc.WaitForReady(e.config.ClientConfig.WaitForReady),
 	}
+	if e.config.Streaming.Enabled {
+		e.streamClient = otlpstream.NewClient(e.clientConn, otlpstream.ClientSettings{
+			MaxDictionarySize: e.config.Streaming.MaxDictionarySize,
+			MaxLifetime:       e.config.Streaming.MaxLifetime,
+			Metadata:          e.metadata,
+			CallOptions:       e.callOptions,
+		})
+	}
 
 	return
 }
 
 func (e *baseExporter) shutdown(context.Context) error {
+	if e.streamClient != nil {
+		e.streamClient.Close()
+	}
 	if e.clientConn != nil {
 		return e.clientConn.Close()
 	}
@@ -89,7 +107,7 @@ func (e *baseExporter) shutdown(context.Context) error {
 
 func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 	req := ptraceotlp.NewExportRequestFromTraces(td)
-	resp, respErr := e.traceExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+	resp, respErr := e.exportTraces(ctx, req)
 	if err := processError(respErr); err != nil {
 		return err
 	}
@@ -105,7 +123,7 @@ func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 
 func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
 	req := pmetricotlp.NewExportRequestFromMetrics(md)
-	resp, respErr := e.metricExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+	resp, respErr := e.exportMetrics(ctx, req)
 	if err := processError(respErr); err != nil {
 		return err
 	}
@@ -121,7 +139,7 @@ func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) erro
 
 func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
 	req := plogotlp.NewExportRequestFromLogs(ld)
-	resp, respErr := e.logExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+	resp, respErr := e.exportLogs(ctx, req)
 	if err := processError(respErr); err != nil {
 		return err
 	}
@@ -148,6 +166,49 @@ func (e *baseExporter) pushProfiles(ctx context.Context, pd pprofile.Profiles) e
 	return nil
 }
 
+// exportTraces exports the traces on a stream if the streams are enabled and supported by the
+// server, and with a unary request otherwise.
+func (e *baseExporter) exportTraces(ctx context.Context, req ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	if e.streamClient != nil {
+		resp, err := e.streamClient.ExportTraces(ctx, req)
+		if !errors.Is(err, otlpstream.ErrUnsupported) {
+			return resp, err
+		}
+		e.logStreamingFallback()
+	}
+	return e.traceExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+}
+
+func (e *baseExporter) exportMetrics(ctx context.Context, req pmetricotlp.ExportRequest) (pmetricotlp.ExportResponse, error) {
+	if e.streamClient != nil {
+		resp, err := e.streamClient.ExportMetrics(ctx, req)
+		if !errors.Is(err, otlpstream.ErrUnsupported) {
+			return resp, err
+		}
+		e.logStreamingFallback()
+	}
+	return e.metricExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+}
+
+func (e *baseExporter) exportLogs(ctx context.Context, req plogotlp.ExportRequest) (plogotlp.ExportResponse, error) {
+	if e.streamClient != nil {
+		resp, err := e.streamClient.ExportLogs(ctx, req)
+		if !errors.Is(err, otlpstream.ErrUnsupported) {
+			return resp, err
+		}
+		e.logStreamingFallback()
+	}
+	return e.logExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
+}
+
+func (e *baseExporter) logStreamingFallback() {
+	e.fallbackOnce.Do(func() {
+		e.settings.Logger.Info("The server does not support OTLP streams, falling back to unary requests",
+			zap.String("endpoint", e.config.ClientConfig.Endpoint),
+		)
+	})
+}
+
 func (e *baseExporter) enhanceContext(ctx context.Context) context.Context {
 	if e.metadata.Len() > 0 {
 		return metadata.NewOutgoingContext(ctx, e.metadata)
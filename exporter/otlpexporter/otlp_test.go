@@ -29,8 +29,10 @@ import (
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/otlpstream"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -795,3 +797,91 @@ func TestSendLogData(t *testing.T) {
 	assert.Len(t, observed.FilterLevelExact(zap.WarnLevel).All(), 1)
 	assert.Contains(t, observed.FilterLevelExact(zap.WarnLevel).All()[0].Message, "Partial success")
 }
+
+func TestSendLogDataStreaming(t *testing.T) {
+	// Start an OTLP-compatible receiver supporting the streams.
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err, "Failed to find an available address to run the gRPC server: %v", err)
+	rcv := &mockLogsReceiver{
+		mockReceiver: mockReceiver{
+			srv:          grpc.NewServer(),
+			requestCount: &atomic.Int32{},
+			totalItems:   &atomic.Int32{},
+		},
+		exportResponse: plogotlp.NewExportResponse,
+	}
+	plogotlp.RegisterGRPCServer(rcv.srv, rcv)
+	otlpstream.NewServer(otlpstream.ServerSettings{MaxDictionarySize: 100, Logs: rcv}).Register(rcv.srv)
+	go func() {
+		_ = rcv.srv.Serve(ln)
+	}()
+	defer rcv.srv.GracefulStop()
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.QueueConfig.Enabled = false
+	cfg.ClientConfig = configgrpc.ClientConfig{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.ClientConfig{
+			Insecure: true,
+		},
+		Headers: map[string]configopaque.String{
+			"header": "header-value",
+		},
+	}
+	cfg.Streaming.Enabled = true
+	exp, err := factory.CreateLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	assert.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, exp.ConsumeLogs(context.Background(), testdata.GenerateLogs(2)))
+	}
+	assert.EqualValues(t, 3, rcv.requestCount.Load())
+	assert.EqualValues(t, 6, rcv.totalItems.Load())
+	assert.Equal(t, testdata.GenerateLogs(2).LogRecordCount(), rcv.getLastRequest().LogRecordCount())
+	// The headers are sent with the stream.
+	assert.Equal(t, []string{"header-value"}, rcv.getMetadata().Get("header"))
+
+	rcv.setExportError(status.New(codes.InvalidArgument, "Invalid argument").Err())
+	err = exp.ConsumeLogs(context.Background(), testdata.GenerateLogs(2))
+	assert.True(t, consumererror.IsPermanent(err))
+}
+
+func TestSendLogDataStreamingFallback(t *testing.T) {
+	// Start an OTLP-compatible receiver without the streams.
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err, "Failed to find an available address to run the gRPC server: %v", err)
+	rcv := otlpLogsReceiverOnGRPCServer(ln)
+	defer rcv.srv.GracefulStop()
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.QueueConfig.Enabled = false
+	cfg.ClientConfig = configgrpc.ClientConfig{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.ClientConfig{
+			Insecure: true,
+		},
+	}
+	cfg.Streaming.Enabled = true
+	set := exportertest.NewNopCreateSettings()
+	logger, observed := observer.New(zap.InfoLevel)
+	set.TelemetrySettings.Logger = zap.New(logger)
+	exp, err := factory.CreateLogsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	assert.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, exp.ConsumeLogs(context.Background(), testdata.GenerateLogs(2)))
+	}
+	assert.EqualValues(t, 2, rcv.requestCount.Load())
+	assert.EqualValues(t, 4, rcv.totalItems.Load())
+	assert.Equal(t, 1, observed.FilterMessageSnippet("falling back to unary requests").Len())
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfileexporter // import "go.opentelemetry.io/collector/exporter/otlpfileexporter"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/otlpfile"
+)
+
+// Config defines configuration for the file exporter.
+type Config struct {
+	// Path is the path of the file the data is appended to.
+	Path string `mapstructure:"path"`
+
+	// Format is the format of the data: "json" writes an OTLP/JSON export request per line, "proto"
+	// writes OTLP/protobuf export requests, each preceded by its signal and size. Default is "json".
+	Format string `mapstructure:"format"`
+
+	// Compression is the compression of the file, "gzip" or none if empty.
+	Compression string `mapstructure:"compression"`
+
+	// Rotation configures the rotation of the file, see RotationConfig.
+	Rotation RotationConfig `mapstructure:"rotation"`
+}
+
+// RotationConfig configures the rotation of the file: the file is renamed with the time of the
+// rotation inserted before its extension, and a new file is created.
+type RotationConfig struct {
+	// MaxMegabytes is the size in megabytes after which the file is rotated. If zero, the file is
+	// not rotated on its size.
+	MaxMegabytes int `mapstructure:"max_megabytes"`
+
+	// Interval is the duration after which the file is rotated. If zero, the file is not rotated
+	// on its age.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// MaxBackups is the maximum number of rotated files kept, the oldest ones being deleted. If
+	// zero, all the rotated files are kept.
+	MaxBackups int `mapstructure:"max_backups"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("path must be specified")
+	}
+	if err := otlpfile.Format(cfg.Format).Validate(); err != nil {
+		return err
+	}
+	if err := otlpfile.Compression(cfg.Compression).Validate(); err != nil {
+		return err
+	}
+	if cfg.Rotation.MaxMegabytes < 0 {
+		return errors.New("rotation max_megabytes must not be negative")
+	}
+	if cfg.Rotation.Interval < 0 {
+		return errors.New("rotation interval must not be negative")
+	}
+	if cfg.Rotation.MaxBackups < 0 {
+		return errors.New("rotation max_backups must not be negative")
+	}
+	return nil
+}
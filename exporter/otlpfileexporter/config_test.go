@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfileexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(confmap.New(), cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig()
+	require.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.Equal(t, &Config{
+		Path:        "/var/log/otelcol/telemetry.pb.gz",
+		Format:      "proto",
+		Compression: "gzip",
+		Rotation: RotationConfig{
+			MaxMegabytes: 100,
+			Interval:     24 * time.Hour,
+			MaxBackups:   7,
+		},
+	}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         *Config
+		expectedErr string
+	}{
+		{
+			name:        "no path",
+			cfg:         &Config{Format: "json"},
+			expectedErr: "path must be specified",
+		},
+		{
+			name:        "invalid format",
+			cfg:         &Config{Path: "data.json", Format: "xml"},
+			expectedErr: `unsupported format "xml"`,
+		},
+		{
+			name:        "invalid compression",
+			cfg:         &Config{Path: "data.json", Format: "json", Compression: "lz4"},
+			expectedErr: `unsupported compression "lz4"`,
+		},
+		{
+			name:        "negative max_megabytes",
+			cfg:         &Config{Path: "data.json", Format: "json", Rotation: RotationConfig{MaxMegabytes: -1}},
+			expectedErr: "rotation max_megabytes must not be negative",
+		},
+		{
+			name:        "negative interval",
+			cfg:         &Config{Path: "data.json", Format: "json", Rotation: RotationConfig{Interval: -time.Second}},
+			expectedErr: "rotation interval must not be negative",
+		},
+		{
+			name:        "negative max_backups",
+			cfg:         &Config{Path: "data.json", Format: "json", Rotation: RotationConfig{MaxBackups: -1}},
+			expectedErr: "rotation max_backups must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, component.ValidateConfig(tt.cfg), tt.expectedErr)
+		})
+	}
+}
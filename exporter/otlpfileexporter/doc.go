@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package otlpfileexporter writes data to files of OTLP/JSON lines or OTLP/protobuf records.
+package otlpfileexporter // import "go.opentelemetry.io/collector/exporter/otlpfileexporter"
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfileexporter // import "go.opentelemetry.io/collector/exporter/otlpfileexporter"
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/otlpfileexporter/internal/metadata"
+	"go.opentelemetry.io/collector/internal/otlpfile"
+	"go.opentelemetry.io/collector/internal/sharedcomponent"
+)
+
+// NewFactory creates a factory for the file exporter.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		exporter.WithTraces(createTracesExporter, metadata.TracesStability),
+		exporter.WithMetrics(createMetricsExporter, metadata.MetricsStability),
+		exporter.WithLogs(createLogsExporter, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Format: string(otlpfile.FormatJSON),
+	}
+}
+
+func createTracesExporter(ctx context.Context, set exporter.CreateSettings, cfg component.Config) (exporter.Traces, error) {
+	fe, err := getOrCreateFileExporter(cfg.(*Config), set)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewTracesExporter(ctx, set, cfg,
+		fe.Unwrap().consumeTraces,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithStart(fe.Start),
+		exporterhelper.WithShutdown(fe.Shutdown),
+	)
+}
+
+func createMetricsExporter(ctx context.Context, set exporter.CreateSettings, cfg component.Config) (exporter.Metrics, error) {
+	fe, err := getOrCreateFileExporter(cfg.(*Config), set)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewMetricsExporter(ctx, set, cfg,
+		fe.Unwrap().consumeMetrics,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithStart(fe.Start),
+		exporterhelper.WithShutdown(fe.Shutdown),
+	)
+}
+
+func createLogsExporter(ctx context.Context, set exporter.CreateSettings, cfg component.Config) (exporter.Logs, error) {
+	fe, err := getOrCreateFileExporter(cfg.(*Config), set)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewLogsExporter(ctx, set, cfg,
+		fe.Unwrap().consumeLogs,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithStart(fe.Start),
+		exporterhelper.WithShutdown(fe.Shutdown),
+	)
+}
+
+// getOrCreateFileExporter returns the exporter shared by the signals and the exporters writing to
+// the path of the configuration, so that a file is only opened once. The exporters writing to the
+// same path must have the same settings.
+func getOrCreateFileExporter(cfg *Config, set exporter.CreateSettings) (*sharedcomponent.Component[*fileExporter], error) {
+	path, err := filepath.Abs(cfg.Path)
+	if err != nil {
+		path = filepath.Clean(cfg.Path)
+	}
+	fe, _ := exporters.LoadOrStore(
+		path,
+		func() (*fileExporter, error) {
+			return newFileExporter(cfg, set.Logger), nil
+		},
+		&set.TelemetrySettings,
+	)
+	if *fe.Unwrap().cfg != *cfg {
+		return nil, fmt.Errorf("path %q is already written by an exporter with different settings", cfg.Path)
+	}
+	return fe, nil
+}
+
+// This is the map of already created file exporters for particular paths.
+var exporters = sharedcomponent.NewMap[string, *fileExporter]()
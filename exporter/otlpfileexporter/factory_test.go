@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfileexporter
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestCreateMetricsExporter(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	me, err := factory.CreateMetricsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	assert.NoError(t, err)
+	assert.NotNil(t, me)
+}
+
+func TestCreateTracesExporter(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	te, err := factory.CreateTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	assert.NoError(t, err)
+	assert.NotNil(t, te)
+}
+
+func TestCreateLogsExporter(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	le, err := factory.CreateLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	assert.NoError(t, err)
+	assert.NotNil(t, le)
+}
+
+func TestCreateExportersSamePath(t *testing.T) {
+	factory := NewFactory()
+	path := filepath.Join(t.TempDir(), "data.json")
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Path = path
+	other := factory.CreateDefaultConfig().(*Config)
+	other.Path = path
+
+	// The exporters writing to the same path share the file.
+	te, err := factory.CreateTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	le, err := factory.CreateLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), other)
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, le.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The exporters writing to the same path with different settings are rejected.
+	other.Format = "proto"
+	_, err = factory.CreateMetricsExporter(context.Background(), exportertest.NewNopCreateSettings(), other)
+	assert.EqualError(t, err, fmt.Sprintf("path %q is already written by an exporter with different settings", path))
+
+	require.NoError(t, te.Shutdown(context.Background()))
+	require.NoError(t, le.Shutdown(context.Background()))
+}
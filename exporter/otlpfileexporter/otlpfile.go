@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfileexporter // import "go.opentelemetry.io/collector/exporter/otlpfileexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/otlpfile"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// rotationTimeFormat is the format of the time inserted in the names of the rotated files, whose
+// lexical order is the order of their rotation.
+const rotationTimeFormat = "20060102T150405.000000000"
+
+// fileExporter appends the records of all the signals to a file, rotating it on its size or age.
+type fileExporter struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	mu      sync.Mutex
+	file    *os.File
+	writer  otlpfile.WriteFlushCloser
+	encoder *otlpfile.Encoder
+	// size is the number of bytes written to the file, once compressed.
+	size   int64
+	opened time.Time
+}
+
+func newFileExporter(cfg *Config, logger *zap.Logger) *fileExporter {
+	return &fileExporter{cfg: cfg, logger: logger}
+}
+
+func (e *fileExporter) Start(context.Context, component.Host) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.open()
+}
+
+func (e *fileExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.close()
+}
+
+func (e *fileExporter) consumeTraces(_ context.Context, td ptrace.Traces) error {
+	return e.write(otlpfile.TracesRecord(td))
+}
+
+func (e *fileExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	return e.write(otlpfile.MetricsRecord(md))
+}
+
+func (e *fileExporter) consumeLogs(_ context.Context, ld plog.Logs) error {
+	return e.write(otlpfile.LogsRecord(ld))
+}
+
+// write appends the record to the file, flushing it so that the file can be read at any time.
+func (e *fileExporter) write(rec otlpfile.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.file == nil {
+		return errors.New("file exporter is not started")
+	}
+	if e.mustRotate() {
+		if err := e.rotate(); err != nil {
+			return err
+		}
+	}
+	if err := e.encoder.Encode(rec); err != nil {
+		return err
+	}
+	return e.writer.Flush()
+}
+
+func (e *fileExporter) open() error {
+	file, err := os.OpenFile(e.cfg.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return errors.Join(err, file.Close())
+	}
+	// The compressed data appended to an existing file is read as another compressed stream.
+	writer, err := otlpfile.NewWriter(&countingWriter{w: file, n: &e.size}, otlpfile.Compression(e.cfg.Compression))
+	if err != nil {
+		return errors.Join(err, file.Close())
+	}
+	e.file = file
+	e.writer = writer
+	e.encoder = otlpfile.NewEncoder(writer, otlpfile.Format(e.cfg.Format))
+	e.size = info.Size()
+	e.opened = time.Now()
+	return nil
+}
+
+func (e *fileExporter) close() error {
+	if e.file == nil {
+		return nil
+	}
+	err := errors.Join(e.writer.Close(), e.file.Close())
+	e.file = nil
+	return err
+}
+
+// mustRotate returns whether the file reached its maximum size or age. An empty file is not rotated.
+func (e *fileExporter) mustRotate() bool {
+	if e.size == 0 {
+		return false
+	}
+	if e.cfg.Rotation.MaxMegabytes > 0 && e.size >= int64(e.cfg.Rotation.MaxMegabytes)<<20 {
+		return true
+	}
+	return e.cfg.Rotation.Interval > 0 && time.Since(e.opened) >= e.cfg.Rotation.Interval
+}
+
+// rotate renames the file, creates a new one, and deletes the oldest rotated files beyond the
+// maximum number of backups.
+func (e *fileExporter) rotate() error {
+	if err := e.close(); err != nil {
+		return err
+	}
+	prefix, ext := splitPath(e.cfg.Path)
+	if err := os.Rename(e.cfg.Path, prefix+"-"+time.Now().UTC().Format(rotationTimeFormat)+ext); err != nil {
+		return fmt.Errorf("failed to rotate the file: %w", errors.Join(err, e.open()))
+	}
+	if err := e.open(); err != nil {
+		return err
+	}
+	if e.cfg.Rotation.MaxBackups > 0 {
+		e.deleteBackups(prefix, ext)
+	}
+	return nil
+}
+
+func (e *fileExporter) deleteBackups(prefix, ext string) {
+	matches, err := filepath.Glob(prefix + "-*" + ext)
+	if err != nil {
+		e.logger.Warn("Failed to list the rotated files", zap.Error(err))
+		return
+	}
+	// Only the files named with a rotation time are backups, e.g. not "traces-debug.json".
+	var backups []string
+	for _, match := range matches {
+		rotated := strings.TrimSuffix(strings.TrimPrefix(match, prefix+"-"), ext)
+		if _, err = time.Parse(rotationTimeFormat, rotated); err == nil {
+			backups = append(backups, match)
+		}
+	}
+	sort.Strings(backups)
+	for len(backups) > e.cfg.Rotation.MaxBackups {
+		if err = os.Remove(backups[0]); err != nil {
+			e.logger.Warn("Failed to delete the rotated file", zap.String("path", backups[0]), zap.Error(err))
+		}
+		backups = backups[1:]
+	}
+}
+
+// splitPath splits the path before the extensions of the file name, "traces.jsonl.gz" being split
+// into "traces" and ".jsonl.gz".
+func splitPath(path string) (string, string) {
+	dir, name := filepath.Split(path)
+	if i := strings.Index(name, "."); i > 0 {
+		return dir + name[:i], name[i:]
+	}
+	return path, ""
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n *int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	*cw.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfileexporter
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/otlpfile"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestFileExporter(t *testing.T) {
+	for _, format := range []otlpfile.Format{otlpfile.FormatJSON, otlpfile.FormatProto} {
+		for _, compression := range []otlpfile.Compression{otlpfile.CompressionNone, otlpfile.CompressionGzip} {
+			t.Run(string(format)+"_"+string(compression), func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig().(*Config)
+				cfg.Path = filepath.Join(t.TempDir(), "data")
+				cfg.Format = string(format)
+				cfg.Compression = string(compression)
+				set := exportertest.NewNopCreateSettings()
+				ctx := context.Background()
+
+				te, err := factory.CreateTracesExporter(ctx, set, cfg)
+				require.NoError(t, err)
+				me, err := factory.CreateMetricsExporter(ctx, set, cfg)
+				require.NoError(t, err)
+				le, err := factory.CreateLogsExporter(ctx, set, cfg)
+				require.NoError(t, err)
+				require.NoError(t, te.Start(ctx, componenttest.NewNopHost()))
+				require.NoError(t, me.Start(ctx, componenttest.NewNopHost()))
+				require.NoError(t, le.Start(ctx, componenttest.NewNopHost()))
+
+				// The signals of the configuration are written to the same file.
+				require.NoError(t, te.ConsumeTraces(ctx, testdata.GenerateTraces(2)))
+				require.NoError(t, me.ConsumeMetrics(ctx, testdata.GenerateMetrics(3)))
+				require.NoError(t, le.ConsumeLogs(ctx, testdata.GenerateLogs(4)))
+				require.NoError(t, te.Shutdown(ctx))
+				require.NoError(t, me.Shutdown(ctx))
+				require.NoError(t, le.Shutdown(ctx))
+
+				assert.Equal(t, []otlpfile.Record{
+					otlpfile.TracesRecord(testdata.GenerateTraces(2)),
+					otlpfile.MetricsRecord(testdata.GenerateMetrics(3)),
+					otlpfile.LogsRecord(testdata.GenerateLogs(4)),
+				}, readRecords(t, cfg.Path, cfg))
+			})
+		}
+	}
+}
+
+func TestFileExporterAppend(t *testing.T) {
+	cfg := &Config{Path: filepath.Join(t.TempDir(), "data.json.gz"), Format: "json", Compression: "gzip"}
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		fe := newFileExporter(cfg, exportertest.NewNopCreateSettings().Logger)
+		require.NoError(t, fe.Start(ctx, componenttest.NewNopHost()))
+		require.NoError(t, fe.consumeLogs(ctx, testdata.GenerateLogs(1)))
+		require.NoError(t, fe.Shutdown(ctx))
+	}
+	assert.Len(t, readRecords(t, cfg.Path, cfg), 2)
+}
+
+func TestFileExporterRotation(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &Config{
+		Path:     filepath.Join(dir, "data.json"),
+		Format:   "json",
+		Rotation: RotationConfig{MaxMegabytes: 1, MaxBackups: 2},
+	}
+	// The files not named with a rotation time are not backups.
+	others := []string{filepath.Join(dir, "data-0.json"), filepath.Join(dir, "data-debug.json")}
+	for _, path := range others {
+		require.NoError(t, os.WriteFile(path, nil, 0o600))
+	}
+	fe := newFileExporter(cfg, exportertest.NewNopCreateSettings().Logger)
+	ctx := context.Background()
+	require.NoError(t, fe.Start(ctx, componenttest.NewNopHost()))
+
+	// Each batch is written to a new file once the file exceeds a megabyte.
+	ld := testdata.GenerateLogs(5000)
+	for i := 0; i < 4; i++ {
+		require.NoError(t, fe.consumeLogs(ctx, ld))
+	}
+	require.NoError(t, fe.Shutdown(ctx))
+
+	backups, err := filepath.Glob(filepath.Join(dir, "data-2*.json"))
+	require.NoError(t, err)
+	assert.Len(t, backups, 2)
+	for _, path := range append(backups, cfg.Path) {
+		assert.Len(t, readRecords(t, path, cfg), 1)
+	}
+	for _, path := range others {
+		assert.FileExists(t, path)
+	}
+}
+
+func TestFileExporterRotationInterval(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &Config{
+		Path:     filepath.Join(dir, "data.pb"),
+		Format:   "proto",
+		Rotation: RotationConfig{Interval: time.Nanosecond},
+	}
+	fe := newFileExporter(cfg, exportertest.NewNopCreateSettings().Logger)
+	ctx := context.Background()
+	require.NoError(t, fe.Start(ctx, componenttest.NewNopHost()))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, fe.consumeTraces(ctx, testdata.GenerateTraces(1)))
+	}
+	require.NoError(t, fe.Shutdown(ctx))
+
+	backups, err := filepath.Glob(filepath.Join(dir, "data-*.pb"))
+	require.NoError(t, err)
+	assert.Len(t, backups, 2)
+}
+
+func TestFileExporterNotStarted(t *testing.T) {
+	fe := newFileExporter(&Config{Path: filepath.Join(t.TempDir(), "data.json")}, exportertest.NewNopCreateSettings().Logger)
+	assert.EqualError(t, fe.consumeLogs(context.Background(), testdata.GenerateLogs(1)), "file exporter is not started")
+	assert.NoError(t, fe.Shutdown(context.Background()))
+}
+
+func TestFileExporterStartError(t *testing.T) {
+	fe := newFileExporter(&Config{Path: filepath.Join(t.TempDir(), "missing", "data.json")}, exportertest.NewNopCreateSettings().Logger)
+	assert.Error(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+}
+
+func TestSplitPath(t *testing.T) {
+	tests := []struct {
+		path   string
+		prefix string
+		ext    string
+	}{
+		{path: "data", prefix: "data"},
+		{path: "dir/traces.jsonl.gz", prefix: "dir/traces", ext: ".jsonl.gz"},
+		{path: "dir.d/.hidden", prefix: "dir.d/.hidden"},
+	}
+	for _, tt := range tests {
+		prefix, ext := splitPath(tt.path)
+		assert.Equal(t, tt.prefix, prefix)
+		assert.Equal(t, tt.ext, ext)
+	}
+}
+
+func readRecords(t *testing.T, path string, cfg *Config) []otlpfile.Record {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	r, err := otlpfile.NewReader(f, otlpfile.Compression(cfg.Compression))
+	require.NoError(t, err)
+	dec := otlpfile.NewDecoder(r, otlpfile.Format(cfg.Format))
+	var records []otlpfile.Record
+	for {
+		rec, err := dec.Decode()
+		if err == io.EOF {
+			return records
+		}
+		require.NoError(t, err)
+		records = append(records, rec)
+	}
+}
@@ -7,9 +7,12 @@ import (
 	"encoding"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
@@ -43,6 +46,30 @@ func (e *EncodingType) UnmarshalText(text []byte) error {
 	return nil
 }
 
+// SignalConfig overrides the settings of the exporter for the requests of a signal. The settings
+// left empty are inherited from the exporter.
+type SignalConfig struct {
+	// The encoding of the requests of the signal.
+	Encoding EncodingType `mapstructure:"encoding"`
+
+	// The compression of the requests of the signal, "none" disabling the compression.
+	Compression configcompression.Type `mapstructure:"compression"`
+
+	// Headers added to the requests of the signal, replacing the headers of the exporter with the same name.
+	Headers map[string]configopaque.String `mapstructure:"headers"`
+
+	// The time limit of the requests of the signal.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// RateLimitConfig configures the throttling of the requests from the rate limit headers of the
+// responses.
+type RateLimitConfig struct {
+	// Enabled delays the requests so that the budget advertised by the RateLimit-Remaining and
+	// RateLimit-Reset response headers lasts until the rate limit resets. Default is false.
+	Enabled bool `mapstructure:"enabled"`
+}
+
 // Config defines configuration for OTLP/HTTP exporter.
 type Config struct {
 	confighttp.ClientConfig `mapstructure:",squash"`     // squash ensures fields are correctly decoded in embedded struct.
@@ -63,6 +90,21 @@ type Config struct {
 
 	// The encoding to export telemetry (default: "proto")
 	Encoding EncodingType `mapstructure:"encoding"`
+
+	// RateLimit configures the throttling of the requests, see RateLimitConfig.
+	RateLimit RateLimitConfig `mapstructure:"rate_limit"`
+
+	// Traces overrides the settings of the exporter for the traces, see SignalConfig.
+	Traces SignalConfig `mapstructure:"traces"`
+
+	// Metrics overrides the settings of the exporter for the metrics, see SignalConfig.
+	Metrics SignalConfig `mapstructure:"metrics"`
+
+	// Logs overrides the settings of the exporter for the logs, see SignalConfig.
+	Logs SignalConfig `mapstructure:"logs"`
+
+	// Profiles overrides the settings of the exporter for the profiles, see SignalConfig.
+	Profiles SignalConfig `mapstructure:"profiles"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -72,5 +114,38 @@ func (cfg *Config) Validate() error {
 	if cfg.Endpoint == "" && cfg.TracesEndpoint == "" && cfg.MetricsEndpoint == "" && cfg.LogsEndpoint == "" && cfg.ProfilesEndpoint == "" {
 		return errors.New("at least one endpoint must be specified")
 	}
+	for _, sc := range []struct {
+		signal string
+		cfg    SignalConfig
+	}{{"traces", cfg.Traces}, {"metrics", cfg.Metrics}, {"logs", cfg.Logs}, {"profiles", cfg.Profiles}} {
+		if sc.cfg.Timeout < 0 {
+			return fmt.Errorf("%s timeout must not be negative", sc.signal)
+		}
+	}
 	return nil
 }
+
+// withSignalConfig returns a copy of the configuration with the settings overridden by the signal
+// configuration.
+func (cfg *Config) withSignalConfig(sc SignalConfig) *Config {
+	signalCfg := *cfg
+	if sc.Encoding != "" {
+		signalCfg.Encoding = sc.Encoding
+	}
+	if sc.Compression != "" {
+		signalCfg.Compression = sc.Compression
+	}
+	if sc.Timeout != 0 {
+		signalCfg.Timeout = sc.Timeout
+	}
+	if len(sc.Headers) > 0 {
+		signalCfg.Headers = make(map[string]configopaque.String, len(cfg.Headers)+len(sc.Headers))
+		for name, value := range cfg.Headers {
+			signalCfg.Headers[name] = value
+		}
+		for name, value := range sc.Headers {
+			signalCfg.Headers[name] = value
+		}
+	}
+	return &signalCfg
+}
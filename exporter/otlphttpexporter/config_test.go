@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configretry"
@@ -51,7 +52,8 @@ func TestUnmarshalConfig(t *testing.T) {
 				NumConsumers: 2,
 				QueueSize:    10,
 			},
-			Encoding: EncodingProto,
+			Encoding:  EncodingProto,
+			RateLimit: RateLimitConfig{Enabled: true},
 			ClientConfig: confighttp.ClientConfig{
 				Headers: map[string]configopaque.String{
 					"can you have a . here?": "F0000000-0000-0000-0000-000000000000",
@@ -75,6 +77,32 @@ func TestUnmarshalConfig(t *testing.T) {
 		}, cfg)
 }
 
+func TestUnmarshalConfigSignals(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "signals.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	require.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.Equal(t, SignalConfig{}, cfg.Traces)
+	assert.Equal(t, SignalConfig{Encoding: EncodingJSON, Headers: map[string]configopaque.String{"tenant": "acme-logs"}}, cfg.Logs)
+	assert.Equal(t, SignalConfig{Compression: configcompression.TypeZstd, Timeout: 5 * time.Second}, cfg.Metrics)
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	// The settings not overridden are inherited from the exporter.
+	logsCfg := cfg.withSignalConfig(cfg.Logs)
+	assert.Equal(t, EncodingJSON, logsCfg.Encoding)
+	assert.Equal(t, configcompression.TypeGzip, logsCfg.Compression)
+	assert.Equal(t, map[string]configopaque.String{"tenant": "acme-logs", "region": "eu"}, logsCfg.Headers)
+	assert.Equal(t, map[string]configopaque.String{"tenant": "acme", "region": "eu"}, cfg.Headers)
+	metricsCfg := cfg.withSignalConfig(cfg.Metrics)
+	assert.Equal(t, EncodingProto, metricsCfg.Encoding)
+	assert.Equal(t, configcompression.TypeZstd, metricsCfg.Compression)
+	assert.Equal(t, 5*time.Second, metricsCfg.Timeout)
+	assert.Equal(t, cfg, cfg.withSignalConfig(cfg.Traces))
+
+	cfg.Metrics.Timeout = -time.Second
+	assert.EqualError(t, component.ValidateConfig(cfg), "metrics timeout must not be negative")
+}
+
 func TestUnmarshalConfigInvalidEncoding(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "bad_invalid_encoding.yaml"))
 	require.NoError(t, err)
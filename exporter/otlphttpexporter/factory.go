@@ -73,11 +73,11 @@ func createTracesExporter(
 	set exporter.CreateSettings,
 	cfg component.Config,
 ) (exporter.Traces, error) {
-	oce, err := newExporter(cfg, set)
+	oCfg := cfg.(*Config)
+	oce, err := newExporter(oCfg.withSignalConfig(oCfg.Traces), set)
 	if err != nil {
 		return nil, err
 	}
-	oCfg := cfg.(*Config)
 
 	oce.tracesURL, err = composeSignalURL(oCfg, oCfg.TracesEndpoint, "traces")
 	if err != nil {
@@ -87,6 +87,7 @@ func createTracesExporter(
 	return exporterhelper.NewTracesExporter(ctx, set, cfg,
 		oce.pushTraces,
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
@@ -99,11 +100,11 @@ func createMetricsExporter(
 	set exporter.CreateSettings,
 	cfg component.Config,
 ) (exporter.Metrics, error) {
-	oce, err := newExporter(cfg, set)
+	oCfg := cfg.(*Config)
+	oce, err := newExporter(oCfg.withSignalConfig(oCfg.Metrics), set)
 	if err != nil {
 		return nil, err
 	}
-	oCfg := cfg.(*Config)
 
 	oce.metricsURL, err = composeSignalURL(oCfg, oCfg.MetricsEndpoint, "metrics")
 	if err != nil {
@@ -113,6 +114,7 @@ func createMetricsExporter(
 	return exporterhelper.NewMetricsExporter(ctx, set, cfg,
 		oce.pushMetrics,
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
@@ -125,11 +127,11 @@ func createLogsExporter(
 	set exporter.CreateSettings,
 	cfg component.Config,
 ) (exporter.Logs, error) {
-	oce, err := newExporter(cfg, set)
+	oCfg := cfg.(*Config)
+	oce, err := newExporter(oCfg.withSignalConfig(oCfg.Logs), set)
 	if err != nil {
 		return nil, err
 	}
-	oCfg := cfg.(*Config)
 
 	oce.logsURL, err = composeSignalURL(oCfg, oCfg.LogsEndpoint, "logs")
 	if err != nil {
@@ -139,6 +141,7 @@ func createLogsExporter(
 	return exporterhelper.NewLogsExporter(ctx, set, cfg,
 		oce.pushLogs,
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
@@ -151,11 +154,11 @@ func createProfilesExporter(
 	set exporter.CreateSettings,
 	cfg component.Config,
 ) (exporter.Profiles, error) {
-	oce, err := newExporter(cfg, set)
+	oCfg := cfg.(*Config)
+	oce, err := newExporter(oCfg.withSignalConfig(oCfg.Profiles), set)
 	if err != nil {
 		return nil, err
 	}
-	oCfg := cfg.(*Config)
 
 	oce.profilesURL, err = composeSignalURL(oCfg, oCfg.ProfilesEndpoint, "profiles")
 	if err != nil {
@@ -165,6 +168,7 @@ func createProfilesExporter(
 	return exporterhelper.NewProfilesExporter(ctx, set, cfg,
 		oce.pushProfiles,
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithShutdown(oce.shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
@@ -37,6 +37,7 @@ func TestCreateDefaultConfig(t *testing.T) {
 	assert.Equal(t, ocfg.QueueConfig.Enabled, true, "default sending queue is enabled")
 	assert.Equal(t, ocfg.Encoding, EncodingProto)
 	assert.Equal(t, ocfg.Compression, configcompression.TypeGzip)
+	assert.False(t, ocfg.RateLimit.Enabled, "default rate limit throttling is disabled")
 }
 
 func TestCreateMetricsExporter(t *testing.T) {
@@ -0,0 +1,44 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func Meter(settings component.TelemetrySettings) metric.Meter {
+	return settings.MeterProvider.Meter("go.opentelemetry.io/collector/exporter/otlphttpexporter")
+}
+
+func Tracer(settings component.TelemetrySettings) trace.Tracer {
+	return settings.TracerProvider.Tracer("go.opentelemetry.io/collector/exporter/otlphttpexporter")
+}
+
+// TelemetryBuilder provides an interface for components to report telemetry
+// as defined in metadata and user config.
+type TelemetryBuilder struct {
+	ExporterOtlphttpRateLimitRemaining metric.Int64UpDownCounter
+}
+
+// telemetryBuilderOption applies changes to default builder.
+type telemetryBuilderOption func(*TelemetryBuilder)
+
+// NewTelemetryBuilder provides a struct with methods to update all internal telemetry
+// for a component
+func NewTelemetryBuilder(settings component.TelemetrySettings, options ...telemetryBuilderOption) (*TelemetryBuilder, error) {
+	builder := TelemetryBuilder{}
+	var err, errs error
+	meter := Meter(settings)
+	builder.ExporterOtlphttpRateLimitRemaining, err = meter.Int64UpDownCounter(
+		"exporter_otlphttp_rate_limit_remaining",
+		metric.WithDescription("Number of requests the server allows before its rate limit resets, as reported by the last RateLimit-Remaining response header."),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
+	return &builder, errs
+}
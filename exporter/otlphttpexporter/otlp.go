@@ -15,6 +15,8 @@ import (
 	"strconv"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 	"google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/protobuf/proto"
@@ -23,7 +25,10 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter/internal/metadata"
 	"go.opentelemetry.io/collector/internal/httphelper"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/internal/sharedcomponent"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -46,6 +51,9 @@ type baseExporter struct {
 	settings    component.TelemetrySettings
 	// Default user-agent header.
 	userAgent string
+	// rateLimiter paces the requests of all the signals if the rate limit headers are enabled, nil
+	// otherwise.
+	rateLimiter *sharedcomponent.Component[*rateLimiter]
 }
 
 const (
@@ -70,18 +78,39 @@ func newExporter(cfg component.Config, set exporter.CreateSettings) (*baseExport
 	userAgent := fmt.Sprintf("%s/%s (%s/%s)",
 		set.BuildInfo.Description, set.BuildInfo.Version, runtime.GOOS, runtime.GOARCH)
 
+	var limiter *sharedcomponent.Component[*rateLimiter]
+	if oCfg.RateLimit.Enabled {
+		var err error
+		limiter, err = rateLimiters.LoadOrStore(set.ID, func() (*rateLimiter, error) {
+			telemetryBuilder, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+			if err != nil {
+				return nil, err
+			}
+			return newRateLimiter(telemetryBuilder, metric.WithAttributes(attribute.String(obsmetrics.ExporterKey, set.ID.String()))), nil
+		}, &set.TelemetrySettings)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// client construction is deferred to start
 	return &baseExporter{
-		config:    oCfg,
-		logger:    set.Logger,
-		userAgent: userAgent,
-		settings:  set.TelemetrySettings,
+		config:      oCfg,
+		logger:      set.Logger,
+		userAgent:   userAgent,
+		settings:    set.TelemetrySettings,
+		rateLimiter: limiter,
 	}, nil
 }
 
 // start actually creates the HTTP client. The client construction is deferred till this point as this
 // is the only place we get hold of Extensions which are required to construct auth round tripper.
 func (e *baseExporter) start(ctx context.Context, host component.Host) error {
+	if e.rateLimiter != nil {
+		if err := e.rateLimiter.Start(ctx, host); err != nil {
+			return err
+		}
+	}
 	client, err := e.config.ClientConfig.ToClient(ctx, host, e.settings)
 	if err != nil {
 		return err
@@ -90,6 +119,14 @@ func (e *baseExporter) start(ctx context.Context, host component.Host) error {
 	return nil
 }
 
+// shutdown releases the rate limiter shared with the exporters of the other signals.
+func (e *baseExporter) shutdown(ctx context.Context) error {
+	if e.rateLimiter != nil {
+		return e.rateLimiter.Shutdown(ctx)
+	}
+	return nil
+}
+
 func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 	tr := ptraceotlp.NewExportRequestFromTraces(td)
 
@@ -180,11 +217,24 @@ func (e *baseExporter) export(ctx context.Context, url string, request []byte, p
 
 	req.Header.Set("User-Agent", e.userAgent)
 
+	if e.rateLimiter != nil {
+		if err = e.rateLimiter.Unwrap().wait(ctx); err != nil {
+			return err
+		}
+	}
+
 	resp, err := e.client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to make an HTTP request: %w", err)
 	}
 
+	// The reset delay is the retry delay of the throttled requests without a Retry-After header.
+	var rateLimitReset time.Duration
+	var hasRateLimit bool
+	if e.rateLimiter != nil {
+		rateLimitReset, hasRateLimit = e.rateLimiter.Unwrap().update(ctx, resp.Header)
+	}
+
 	defer func() {
 		// Discard any remaining response body when we are done reading.
 		io.CopyN(io.Discard, resp.Body, maxHTTPResponseReadBytes) // nolint:errcheck
@@ -214,18 +264,20 @@ func (e *baseExporter) export(ctx context.Context, url string, request []byte, p
 	if isRetryableStatusCode(resp.StatusCode) {
 		// A retry duration of 0 seconds will trigger the default backoff policy
 		// of our caller (retry handler).
-		retryAfter := 0
+		retryAfter := time.Duration(0)
 
 		// Check if the server is overwhelmed.
 		// See spec https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/protocol/otlp.md#otlphttp-throttling
 		isThrottleError := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
 		if val := resp.Header.Get(headerRetryAfter); isThrottleError && val != "" {
 			if seconds, err2 := strconv.Atoi(val); err2 == nil {
-				retryAfter = seconds
+				retryAfter = time.Duration(seconds) * time.Second
 			}
+		} else if isThrottleError && hasRateLimit {
+			retryAfter = rateLimitReset
 		}
 
-		return exporterhelper.NewThrottleRetry(formattedErr, time.Duration(retryAfter)*time.Second)
+		return exporterhelper.NewThrottleRetry(formattedErr, retryAfter)
 	}
 
 	return consumererror.NewPermanent(formattedErr)
@@ -24,6 +24,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/consumer/consumererror"
@@ -174,6 +175,17 @@ func TestErrorResponses(t *testing.T) {
 					time.Duration(30)*time.Second)
 			},
 		},
+		{
+			name:           "429-RateLimit-Reset",
+			responseStatus: http.StatusTooManyRequests,
+			responseBody:   status.New(codes.ResourceExhausted, "Quota exceeded"),
+			headers:        map[string]string{"RateLimit-Remaining": "0", "RateLimit-Reset": "7"},
+			err: func(srv *httptest.Server) error {
+				return exporterhelper.NewThrottleRetry(
+					status.New(codes.ResourceExhausted, errMsgPrefix(srv)+"429, Message=Quota exceeded, Details=[]").Err(),
+					time.Duration(7)*time.Second)
+			},
+		},
 		{
 			name:           "504",
 			responseStatus: http.StatusGatewayTimeout,
@@ -215,6 +227,7 @@ func TestErrorResponses(t *testing.T) {
 			cfg := &Config{
 				Encoding:       EncodingProto,
 				TracesEndpoint: fmt.Sprintf("%s/v1/traces", srv.URL),
+				RateLimit:      RateLimitConfig{Enabled: true},
 				// Create without QueueSettings and RetryConfig so that ConsumeTraces
 				// returns the errors that we want to check immediately.
 			}
@@ -992,6 +1005,45 @@ func TestEncoding(t *testing.T) {
 	})
 }
 
+func TestSignalOverrides(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/logs", func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, jsonContentType, request.Header.Get("Content-Type"))
+		assert.Equal(t, "gzip", request.Header.Get("Content-Encoding"))
+		assert.Equal(t, "acme-logs", request.Header.Get("tenant"))
+		assert.Equal(t, "eu", request.Header.Get("region"))
+		writer.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/v1/metrics", func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, protobufContentType, request.Header.Get("Content-Type"))
+		assert.Equal(t, "zstd", request.Header.Get("Content-Encoding"))
+		assert.Equal(t, "acme", request.Header.Get("tenant"))
+		writer.WriteHeader(http.StatusOK)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = srv.URL
+	cfg.QueueConfig.Enabled = false
+	cfg.RetryConfig.Enabled = false
+	cfg.Headers = map[string]configopaque.String{"tenant": "acme", "region": "eu"}
+	cfg.Logs = SignalConfig{Encoding: EncodingJSON, Headers: map[string]configopaque.String{"tenant": "acme-logs"}}
+	cfg.Metrics = SignalConfig{Compression: configcompression.TypeZstd}
+
+	logsExp, err := createLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, logsExp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, logsExp.Shutdown(context.Background())) })
+	require.NoError(t, logsExp.ConsumeLogs(context.Background(), plog.NewLogs()))
+
+	metricsExp, err := createMetricsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, metricsExp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, metricsExp.Shutdown(context.Background())) })
+	require.NoError(t, metricsExp.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+}
+
 func createBackend(endpoint string, handler func(writer http.ResponseWriter, request *http.Request)) *httptest.Server {
 	mux := http.NewServeMux()
 	mux.HandleFunc(endpoint, handler)
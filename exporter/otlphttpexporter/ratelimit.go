@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlphttpexporter // import "go.opentelemetry.io/collector/exporter/otlphttpexporter"
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter/internal/metadata"
+	"go.opentelemetry.io/collector/internal/sharedcomponent"
+)
+
+const (
+	headerRateLimitRemaining = "RateLimit-Remaining"
+	headerRateLimitReset     = "RateLimit-Reset"
+)
+
+// rateLimiter delays the requests so that the budget of requests advertised by the server, with
+// the RateLimit-Remaining and RateLimit-Reset response headers, lasts until its rate limit resets.
+// The requests are sent without delay until a response carries these headers.
+type rateLimiter struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	telemetryBuilder *metadata.TelemetryBuilder
+	attrs            metric.MeasurementOption
+
+	mu sync.Mutex
+	// next is the earliest time at which the next request may be sent.
+	next time.Time
+	// interval is the delay between two requests.
+	interval time.Duration
+	// remaining is the last budget reported by the server.
+	remaining int64
+}
+
+func newRateLimiter(telemetryBuilder *metadata.TelemetryBuilder, attrs metric.MeasurementOption) *rateLimiter {
+	return &rateLimiter{telemetryBuilder: telemetryBuilder, attrs: attrs}
+}
+
+// rateLimiters has the rate limiters shared by the signals of each exporter, the budget advertised
+// by the server being spent by all of them.
+var rateLimiters = sharedcomponent.NewMap[component.ID, *rateLimiter]()
+
+// wait blocks until the next request may be sent, or the context is done.
+func (rl *rateLimiter) wait(ctx context.Context) error {
+	for {
+		rl.mu.Lock()
+		now := time.Now()
+		if !now.Before(rl.next) {
+			rl.next = now.Add(rl.interval)
+			rl.mu.Unlock()
+			return nil
+		}
+		delay := rl.next.Sub(now)
+		rl.mu.Unlock()
+
+		// The next time may be changed by a response while waiting, it is checked again.
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// update paces the next requests from the rate limit headers of the response. It returns the
+// delay until the rate limit resets, and false if the response does not carry the headers.
+func (rl *rateLimiter) update(ctx context.Context, header http.Header) (time.Duration, bool) {
+	remaining, err := strconv.ParseInt(header.Get(headerRateLimitRemaining), 10, 64)
+	if err != nil || remaining < 0 {
+		return 0, false
+	}
+	seconds, err := strconv.ParseInt(header.Get(headerRateLimitReset), 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	reset := time.Duration(seconds) * time.Second
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if remaining == 0 {
+		// The budget is exhausted: no request is sent until the rate limit resets, the budget of the
+		// next period being unknown until a response carries it.
+		rl.next = time.Now().Add(reset)
+		rl.interval = 0
+	} else {
+		rl.interval = reset / time.Duration(remaining)
+	}
+	rl.telemetryBuilder.ExporterOtlphttpRateLimitRemaining.Add(ctx, remaining-rl.remaining, rl.attrs)
+	rl.remaining = remaining
+	return reset, true
+}
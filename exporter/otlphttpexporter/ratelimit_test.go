@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlphttpexporter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter/internal/metadata"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTestRateLimiter(t *testing.T) *rateLimiter {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	return newRateLimiter(telemetryBuilder, metric.WithAttributes())
+}
+
+func rateLimitHeader(remaining, reset string) http.Header {
+	header := http.Header{}
+	header.Set(headerRateLimitRemaining, remaining)
+	header.Set(headerRateLimitReset, reset)
+	return header
+}
+
+func TestRateLimiterUpdate(t *testing.T) {
+	rl := newTestRateLimiter(t)
+	for _, header := range []http.Header{
+		{},
+		rateLimitHeader("10", ""),
+		rateLimitHeader("", "1"),
+		rateLimitHeader("-1", "1"),
+		rateLimitHeader("10", "soon"),
+	} {
+		_, ok := rl.update(context.Background(), header)
+		assert.False(t, ok)
+	}
+	assert.Zero(t, rl.interval)
+
+	reset, ok := rl.update(context.Background(), rateLimitHeader("10", "2"))
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Second, reset)
+	assert.Equal(t, 200*time.Millisecond, rl.interval)
+	assert.Equal(t, int64(10), rl.remaining)
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	rl := newTestRateLimiter(t)
+	// The requests are not delayed until the server reports its budget.
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, rl.wait(context.Background()))
+	}
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+
+	// The budget is spread over the period until the rate limit resets.
+	rl.update(context.Background(), rateLimitHeader("20", "1"))
+	start = time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, rl.wait(context.Background()))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	// No request is sent once the budget is exhausted, until the rate limit resets.
+	rl.update(context.Background(), rateLimitHeader("0", "60"))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, rl.wait(ctx), context.DeadlineExceeded)
+}
+
+func TestExportRateLimit(t *testing.T) {
+	var remaining atomic.Int64
+	remaining.Store(3)
+	srv := createBackend("/v1/traces", func(writer http.ResponseWriter, _ *http.Request) {
+		writer.Header().Set(headerRateLimitRemaining, fmt.Sprint(remaining.Add(-1)))
+		writer.Header().Set(headerRateLimitReset, "0")
+		writer.WriteHeader(http.StatusOK)
+	})
+	defer srv.Close()
+
+	id := component.MustNewID("otlphttp")
+	tt, err := componenttest.SetupTelemetry(id)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	set := exportertest.NewNopCreateSettings()
+	set.ID = id
+	set.TelemetrySettings = tt.TelemetrySettings()
+	cfg := &Config{
+		Encoding:        EncodingProto,
+		TracesEndpoint:  srv.URL + "/v1/traces",
+		MetricsEndpoint: srv.URL + "/v1/traces",
+		RateLimit:       RateLimitConfig{Enabled: true},
+	}
+	traces, err := createTracesExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	metrics, err := createMetricsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, traces.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, metrics.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, traces.Shutdown(context.Background()))
+		require.NoError(t, metrics.Shutdown(context.Background()))
+	})
+
+	// The signals share the budget reported by the server, reported once.
+	require.NoError(t, traces.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	require.NoError(t, tt.CheckExporterMetricGauge("exporter_otlphttp_rate_limit_remaining", 2))
+	require.NoError(t, metrics.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+	require.NoError(t, tt.CheckExporterMetricGauge("exporter_otlphttp_rate_limit_remaining", 1))
+}
@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adminextension // import "go.opentelemetry.io/collector/extension/adminextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"sync"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	pipelinesPath        = "/pipelines"
+	pausePipelinePath    = "/pipelines/pause"
+	resumePipelinePath   = "/pipelines/resume"
+	bypassProcessorPath  = "/processors/bypass"
+	restoreProcessorPath = "/processors/restore"
+
+	jsonContentType = "application/json"
+)
+
+// pipelineController is implemented by the host to pause the pipelines and bypass the
+// processors of the running collector.
+type pipelineController interface {
+	// SetPipelinePaused pauses or resumes a pipeline.
+	SetPipelinePaused(pipelineID component.ID, paused bool) error
+	// SetProcessorBypassed bypasses or restores a processor of a pipeline.
+	SetProcessorBypassed(pipelineID component.ID, processorID component.ID, bypassed bool) error
+	// PausedPipelines returns the IDs of the paused pipelines.
+	PausedPipelines() []component.ID
+	// BypassedProcessors returns the IDs of the bypassed processors of each pipeline.
+	BypassedProcessors() map[component.ID][]component.ID
+}
+
+// controlRequest is the JSON body of the requests changing the runtime controls. Requiring a JSON
+// body makes them requests that web pages of other origins cannot send without a CORS preflight.
+type controlRequest struct {
+	Pipeline  string `json:"pipeline"`
+	Processor string `json:"processor"`
+}
+
+// controlState is the state of the runtime controls, as reported by the HTTP endpoints.
+type controlState struct {
+	PausedPipelines    []string            `json:"paused_pipelines"`
+	BypassedProcessors map[string][]string `json:"bypassed_processors"`
+}
+
+type adminExtension struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+
+	server *http.Server
+	wg     sync.WaitGroup
+
+	// shutdownCtx is done when the extension shuts down, to stop the data taps.
+	shutdownCtx    context.Context
+	shutdownCancel context.CancelFunc
+}
+
+func newAdminExtension(config *Config, telemetry component.TelemetrySettings) *adminExtension {
+	ae := &adminExtension{
+		config:    config,
+		telemetry: telemetry,
+	}
+	ae.shutdownCtx, ae.shutdownCancel = context.WithCancel(context.Background())
+	return ae
+}
+
+func (ae *adminExtension) Start(ctx context.Context, host component.Host) error {
+	controller, ok := host.(pipelineController)
+	if !ok {
+		ae.telemetry.Logger.Warn("Host's pipeline controls not available")
+	}
+	tapper, ok := host.(nodeTapper)
+	if !ok {
+		ae.telemetry.Logger.Warn("Host's data taps not available")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(pipelinesPath, ae.handler(controller, http.MethodGet, func(pipelineController, *controlRequest) error {
+		return nil
+	}))
+	mux.HandleFunc(pausePipelinePath, ae.handler(controller, http.MethodPost, setPipelinePaused(true)))
+	mux.HandleFunc(resumePipelinePath, ae.handler(controller, http.MethodPost, setPipelinePaused(false)))
+	mux.HandleFunc(bypassProcessorPath, ae.handler(controller, http.MethodPost, setProcessorBypassed(true)))
+	mux.HandleFunc(restoreProcessorPath, ae.handler(controller, http.MethodPost, setProcessorBypassed(false)))
+	mux.HandleFunc(tapPath, ae.tapHandler(tapper))
+
+	// Start the listener here so we can have earlier failure if port is
+	// already in use.
+	ln, err := ae.config.ToListener(ctx)
+	if err != nil {
+		return err
+	}
+	ae.server, err = ae.config.ToServer(ctx, host, ae.telemetry, mux)
+	if err != nil {
+		return multierr.Append(err, ln.Close())
+	}
+
+	ae.telemetry.Logger.Info("Starting admin extension", zap.String("endpoint", ae.config.Endpoint))
+	ae.wg.Add(1)
+	go func() {
+		defer ae.wg.Done()
+		if errHTTP := ae.server.Serve(ln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			ae.telemetry.ReportStatus(component.NewFatalErrorEvent(errHTTP))
+		}
+	}()
+	return nil
+}
+
+func (ae *adminExtension) Shutdown(context.Context) error {
+	ae.shutdownCancel()
+	if ae.server == nil {
+		return nil
+	}
+	err := ae.server.Close()
+	ae.wg.Wait()
+	return err
+}
+
+// handler returns an HTTP handler applying the given action with the controller, and replying
+// with the resulting state of the runtime controls. The POST requests must have a JSON body.
+func (ae *adminExtension) handler(controller pipelineController, method string, action func(pipelineController, *controlRequest) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			http.Error(w, fmt.Sprintf("method %s not allowed", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+		req := &controlRequest{}
+		if r.Method == http.MethodPost {
+			if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != jsonContentType {
+				http.Error(w, fmt.Sprintf("content type must be %s", jsonContentType), http.StatusUnsupportedMediaType)
+				return
+			}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+				return
+			}
+		}
+		if controller == nil {
+			http.Error(w, "pipeline controls are not supported by the host", http.StatusNotImplemented)
+			return
+		}
+		if err := action(controller, req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.Method != http.MethodGet {
+			ae.telemetry.Logger.Info("Updated pipeline controls", zap.String("path", r.URL.Path),
+				zap.String("pipeline", req.Pipeline), zap.String("processor", req.Processor))
+		}
+		w.Header().Set("Content-Type", jsonContentType)
+		_ = json.NewEncoder(w).Encode(newControlState(controller))
+	}
+}
+
+func setPipelinePaused(paused bool) func(pipelineController, *controlRequest) error {
+	return func(controller pipelineController, req *controlRequest) error {
+		pipelineID, err := parseID("pipeline", req.Pipeline)
+		if err != nil {
+			return err
+		}
+		return controller.SetPipelinePaused(pipelineID, paused)
+	}
+}
+
+func setProcessorBypassed(bypassed bool) func(pipelineController, *controlRequest) error {
+	return func(controller pipelineController, req *controlRequest) error {
+		pipelineID, err := parseID("pipeline", req.Pipeline)
+		if err != nil {
+			return err
+		}
+		processorID, err := parseID("processor", req.Processor)
+		if err != nil {
+			return err
+		}
+		return controller.SetProcessorBypassed(pipelineID, processorID, bypassed)
+	}
+}
+
+// parseID returns the component.ID in the given field of the request body.
+func parseID(field string, value string) (component.ID, error) {
+	var id component.ID
+	if value == "" {
+		return id, fmt.Errorf("missing %q field", field)
+	}
+	if err := id.UnmarshalText([]byte(value)); err != nil {
+		return id, fmt.Errorf("invalid %q field: %w", field, err)
+	}
+	return id, nil
+}
+
+func newControlState(controller pipelineController) *controlState {
+	state := &controlState{
+		PausedPipelines:    []string{},
+		BypassedProcessors: map[string][]string{},
+	}
+	for _, pipelineID := range controller.PausedPipelines() {
+		state.PausedPipelines = append(state.PausedPipelines, pipelineID.String())
+	}
+	for pipelineID, processorIDs := range controller.BypassedProcessors() {
+		for _, processorID := range processorIDs {
+			state.BypassedProcessors[pipelineID.String()] = append(state.BypassedProcessors[pipelineID.String()], processorID.String())
+		}
+	}
+	return state
+}
@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adminextension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension/auth"
+	"go.opentelemetry.io/collector/internal/testutil"
+)
+
+// controllerHost is a component.Host implementing the runtime pipeline controls in memory.
+type controllerHost struct {
+	component.Host
+	paused   map[component.ID]bool
+	bypassed map[component.ID][]component.ID
+}
+
+func newControllerHost() *controllerHost {
+	return &controllerHost{
+		Host:     componenttest.NewNopHost(),
+		paused:   map[component.ID]bool{},
+		bypassed: map[component.ID][]component.ID{},
+	}
+}
+
+func (h *controllerHost) SetPipelinePaused(pipelineID component.ID, paused bool) error {
+	if pipelineID != component.MustNewID("traces") {
+		return fmt.Errorf("pipeline %q not found", pipelineID)
+	}
+	if paused {
+		h.paused[pipelineID] = true
+	} else {
+		delete(h.paused, pipelineID)
+	}
+	return nil
+}
+
+func (h *controllerHost) SetProcessorBypassed(pipelineID component.ID, processorID component.ID, bypassed bool) error {
+	if pipelineID != component.MustNewID("traces") {
+		return fmt.Errorf("pipeline %q not found", pipelineID)
+	}
+	if bypassed {
+		h.bypassed[pipelineID] = []component.ID{processorID}
+	} else {
+		delete(h.bypassed, pipelineID)
+	}
+	return nil
+}
+
+func (h *controllerHost) PausedPipelines() []component.ID {
+	var ret []component.ID
+	for id := range h.paused {
+		ret = append(ret, id)
+	}
+	return ret
+}
+
+func (h *controllerHost) BypassedProcessors() map[component.ID][]component.ID {
+	return h.bypassed
+}
+
+func startTestExtension(t *testing.T, host component.Host) string {
+	return startTestExtensionWithConfig(t, host, confighttp.ServerConfig{})
+}
+
+func startTestExtensionWithConfig(t *testing.T, host component.Host, serverConfig confighttp.ServerConfig) string {
+	serverConfig.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg := &Config{
+		ServerConfig: serverConfig,
+		Tap: TapConfig{
+			MaxRateLimit:       defaultMaxRateLimit,
+			RedactedAttributes: []string{"user.email"},
+		},
+	}
+	ext := newAdminExtension(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, ext.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, ext.Shutdown(context.Background())) })
+	return "http://" + cfg.Endpoint
+}
+
+func TestAdminExtension(t *testing.T) {
+	url := startTestExtension(t, newControllerHost())
+
+	state := assertState(t, http.MethodGet, url+"/pipelines", "", http.StatusOK)
+	assert.Equal(t, &controlState{PausedPipelines: []string{}, BypassedProcessors: map[string][]string{}}, state)
+
+	state = assertState(t, http.MethodPost, url+"/pipelines/pause", `{"pipeline":"traces"}`, http.StatusOK)
+	assert.Equal(t, []string{"traces"}, state.PausedPipelines)
+
+	state = assertState(t, http.MethodPost, url+"/processors/bypass", `{"pipeline":"traces","processor":"batch/1"}`, http.StatusOK)
+	assert.Equal(t, map[string][]string{"traces": {"batch/1"}}, state.BypassedProcessors)
+
+	state = assertState(t, http.MethodPost, url+"/pipelines/resume", `{"pipeline":"traces"}`, http.StatusOK)
+	assert.Empty(t, state.PausedPipelines)
+
+	state = assertState(t, http.MethodPost, url+"/processors/restore", `{"pipeline":"traces","processor":"batch/1"}`, http.StatusOK)
+	assert.Empty(t, state.BypassedProcessors)
+}
+
+func TestAdminExtensionErrors(t *testing.T) {
+	url := startTestExtension(t, newControllerHost())
+
+	assertState(t, http.MethodGet, url+"/pipelines/pause", "", http.StatusMethodNotAllowed)
+	assertState(t, http.MethodPost, url+"/pipelines", `{}`, http.StatusMethodNotAllowed)
+	assertState(t, http.MethodPost, url+"/pipelines/pause", `{}`, http.StatusBadRequest)
+	assertState(t, http.MethodPost, url+"/pipelines/pause", `{"pipeline":`, http.StatusBadRequest)
+	assertState(t, http.MethodPost, url+"/pipelines/pause", `{"pipeline":"traces/"}`, http.StatusBadRequest)
+	assertState(t, http.MethodPost, url+"/pipelines/pause", `{"pipeline":"metrics"}`, http.StatusBadRequest)
+	assertState(t, http.MethodPost, url+"/processors/bypass", `{"pipeline":"traces"}`, http.StatusBadRequest)
+}
+
+func TestAdminExtensionCrossSiteRequest(t *testing.T) {
+	host := newControllerHost()
+	url := startTestExtension(t, host)
+
+	// The requests that web pages can send to other origins without a CORS preflight are refused.
+	for _, contentType := range []string{"", "text/plain", "application/x-www-form-urlencoded"} {
+		req, err := http.NewRequest(http.MethodPost, url+"/pipelines/pause?pipeline=traces", strings.NewReader(`{"pipeline":"traces"}`))
+		require.NoError(t, err)
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	}
+	assert.Empty(t, host.PausedPipelines())
+}
+
+// authHost is a controllerHost with a server authenticator extension.
+type authHost struct {
+	*controllerHost
+	extensions map[component.ID]component.Component
+}
+
+func (h *authHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestAdminExtensionAuth(t *testing.T) {
+	authID := component.MustNewID("token")
+	host := &authHost{
+		controllerHost: newControllerHost(),
+		extensions: map[component.ID]component.Component{
+			authID: auth.NewServer(auth.WithServerAuthenticate(func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+				if len(headers["Authorization"]) == 0 || headers["Authorization"][0] != "Bearer token" {
+					return ctx, errors.New("unauthenticated")
+				}
+				return ctx, nil
+			})),
+		},
+	}
+	url := startTestExtensionWithConfig(t, host, confighttp.ServerConfig{Auth: &configauth.Authentication{AuthenticatorID: authID}})
+
+	assertState(t, http.MethodPost, url+"/pipelines/pause", `{"pipeline":"traces"}`, http.StatusUnauthorized)
+	assert.Empty(t, host.PausedPipelines())
+
+	req, err := http.NewRequest(http.MethodPost, url+"/pipelines/pause", strings.NewReader(`{"pipeline":"traces"}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", jsonContentType)
+	req.Header.Set("Authorization", "Bearer token")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, host.PausedPipelines(), 1)
+}
+
+func TestAdminExtensionUnsupportedHost(t *testing.T) {
+	url := startTestExtension(t, componenttest.NewNopHost())
+	assertState(t, http.MethodGet, url+"/pipelines", "", http.StatusNotImplemented)
+}
+
+func assertState(t *testing.T, method string, url string, body string, expectedStatus int) *controlState {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	if body != "" {
+		req.Header.Set("Content-Type", jsonContentType)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, expectedStatus, resp.StatusCode)
+	if expectedStatus != http.StatusOK {
+		return nil
+	}
+	state := &controlState{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(state))
+	return state
+}
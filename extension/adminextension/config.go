@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adminextension // import "go.opentelemetry.io/collector/extension/adminextension"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config has the configuration for the admin extension.
+type Config struct {
+	confighttp.ServerConfig `mapstructure:",squash"`
+
+	// Tap has the settings of the live data taps.
+	Tap TapConfig `mapstructure:"tap"`
+}
+
+// TapConfig has the settings of the live data taps.
+type TapConfig struct {
+	// MaxRateLimit is the maximum number of messages per second streamed by a tap.
+	MaxRateLimit float64 `mapstructure:"max_rate_limit"`
+
+	// RedactedAttributes are the keys of the attributes whose values are always redacted,
+	// in addition to the ones requested.
+	RedactedAttributes []string `mapstructure:"redacted_attributes"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("\"endpoint\" is required")
+	}
+	if cfg.Tap.MaxRateLimit <= 0 {
+		return errors.New("\"tap::max_rate_limit\" must be positive")
+	}
+	return nil
+}
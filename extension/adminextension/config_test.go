@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adminextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(confmap.New(), cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.Equal(t,
+		&Config{
+			ServerConfig: confighttp.ServerConfig{
+				Endpoint: "localhost:56134",
+			},
+			Tap: TapConfig{
+				MaxRateLimit:       5,
+				RedactedAttributes: []string{"http.request.header.authorization"},
+			},
+		}, cfg)
+}
+
+func TestValidateConfig(t *testing.T) {
+	assert.NoError(t, createDefaultConfig().(*Config).Validate())
+	assert.EqualError(t, (&Config{}).Validate(), "\"endpoint\" is required")
+	assert.EqualError(t, (&Config{ServerConfig: confighttp.ServerConfig{Endpoint: defaultEndpoint}}).Validate(), "\"tap::max_rate_limit\" must be positive")
+}
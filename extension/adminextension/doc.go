@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package adminextension implements an extension that exposes an HTTP API to
+// pause the pipelines and bypass the processors of a running collector,
+// without reloading its configuration.
+package adminextension // import "go.opentelemetry.io/collector/extension/adminextension"
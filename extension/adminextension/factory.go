@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adminextension // import "go.opentelemetry.io/collector/extension/adminextension"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/adminextension/internal/metadata"
+)
+
+const (
+	defaultEndpoint     = "localhost:13134"
+	defaultMaxRateLimit = 10
+)
+
+// NewFactory creates a factory for the admin extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, createExtension, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ServerConfig: confighttp.ServerConfig{
+			Endpoint: defaultEndpoint,
+		},
+		Tap: TapConfig{
+			MaxRateLimit: defaultMaxRateLimit,
+		},
+	}
+}
+
+// createExtension creates the extension based on this config.
+func createExtension(_ context.Context, set extension.CreateSettings, cfg component.Config) (extension.Extension, error) {
+	return newAdminExtension(cfg.(*Config), set.TelemetrySettings), nil
+}
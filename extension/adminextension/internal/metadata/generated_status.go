@@ -0,0 +1,15 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	Type = component.MustNewType("admin")
+)
+
+const (
+	ExtensionStability = component.StabilityLevelDevelopment
+)
@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adminextension // import "go.opentelemetry.io/collector/extension/adminextension"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	tapPath = "/tap"
+
+	pipelineParam      = "pipeline"
+	kindParam          = "kind"
+	componentParam     = "component"
+	samplingRatioParam = "sampling_ratio"
+	rateLimitParam     = "rate_limit"
+	redactParam        = "redact"
+)
+
+// nodeTapper is implemented by the host to tap the data flowing out of the nodes of the pipelines.
+type nodeTapper interface {
+	// ValidateTapNode returns the error TapNode returns for the same node and settings, without
+	// tapping the node.
+	ValidateTapNode(pipelineID component.ID, kind component.Kind, componentID component.ID,
+		samplingRatio float64, rateLimit float64) error
+	// TapNode streams a sample of the data flowing out of a receiver, processor or connector of a
+	// pipeline to emit, as OTLP JSON encoded export requests, until the context is done or emit fails.
+	TapNode(ctx context.Context, pipelineID component.ID, kind component.Kind, componentID component.ID,
+		samplingRatio float64, rateLimit float64, redactedAttributes []string, emit func([]byte) error) error
+}
+
+// tapRequest is a request to tap a node, parsed from the query parameters.
+type tapRequest struct {
+	pipelineID         component.ID
+	kind               component.Kind
+	componentID        component.ID
+	samplingRatio      float64
+	rateLimit          float64
+	redactedAttributes []string
+}
+
+// tapHandler returns an HTTP handler streaming the data tapped from a node, as newline delimited
+// OTLP JSON, or as websocket text messages if the connection is upgraded.
+func (ae *adminExtension) tapHandler(tapper nodeTapper) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, fmt.Sprintf("method %s not allowed", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+		if tapper == nil {
+			http.Error(w, "data taps are not supported by the host", http.StatusNotImplemented)
+			return
+		}
+		req, err := ae.parseTapRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// Check that the node can be tapped before streaming, so that the invalid requests are
+		// rejected with a status code.
+		if err = tapper.ValidateTapNode(req.pipelineID, req.kind, req.componentID, req.samplingRatio, req.rateLimit); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ae.telemetry.Logger.Info("Tapping data", zap.String("query", r.URL.RawQuery))
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			websocket.Server{Handshake: ae.tapHandshake, Handler: ae.streamTapWebsocket(tapper, req)}.ServeHTTP(w, r)
+			return
+		}
+		ae.streamTapHTTP(w, r, tapper, req)
+	}
+}
+
+func (ae *adminExtension) streamTapHTTP(w http.ResponseWriter, r *http.Request, tapper nodeTapper, req *tapRequest) {
+	ctx, cancel := ae.tapContext(r.Context())
+	defer cancel()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	rc := http.NewResponseController(w)
+	_ = rc.Flush()
+	err := req.tap(ctx, tapper, func(msg []byte) error {
+		if _, err := w.Write(append(msg, '\n')); err != nil {
+			return err
+		}
+		return rc.Flush()
+	})
+	if err != nil {
+		ae.telemetry.Logger.Debug("Tap stopped", zap.Error(err))
+	}
+}
+
+func (ae *adminExtension) streamTapWebsocket(tapper nodeTapper, req *tapRequest) websocket.Handler {
+	return func(ws *websocket.Conn) {
+		ctx, cancel := ae.tapContext(ws.Request().Context())
+		defer cancel()
+		// The client does not send messages, reading only detects that the connection is closed.
+		go func() {
+			_, _ = io.Copy(io.Discard, ws)
+			cancel()
+		}()
+		err := req.tap(ctx, tapper, func(msg []byte) error {
+			return websocket.Message.Send(ws, string(msg))
+		})
+		if err != nil {
+			ae.telemetry.Logger.Debug("Tap stopped", zap.Error(err))
+		}
+	}
+}
+
+// tapHandshake rejects the websocket connections opened by web pages of other origins than the
+// extension itself or the CORS allowed origins, as browsers do not apply the same-origin policy to
+// websockets.
+func (ae *adminExtension) tapHandshake(_ *websocket.Config, r *http.Request) error {
+	if origin := r.Header.Get("Origin"); origin != "" && !ae.allowOrigin(origin, r.Host) {
+		return fmt.Errorf("origin %q not allowed", origin)
+	}
+	return nil
+}
+
+// allowOrigin returns whether the origin is the host itself or one of the CORS allowed origins,
+// which may contain a wildcard.
+func (ae *adminExtension) allowOrigin(origin, host string) bool {
+	if u, err := url.Parse(origin); err == nil && u.Host == host {
+		return true
+	}
+	if ae.config.CORS == nil {
+		return false
+	}
+	origin = strings.ToLower(origin)
+	for _, allowed := range ae.config.CORS.AllowedOrigins {
+		allowed = strings.ToLower(allowed)
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+		if prefix, suffix, ok := strings.Cut(allowed, "*"); ok &&
+			len(origin) >= len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// tapContext returns a context done when the given context is done or the extension shuts down.
+func (ae *adminExtension) tapContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	stop := context.AfterFunc(ae.shutdownCtx, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
+
+func (req *tapRequest) tap(ctx context.Context, tapper nodeTapper, emit func([]byte) error) error {
+	return tapper.TapNode(ctx, req.pipelineID, req.kind, req.componentID, req.samplingRatio, req.rateLimit, req.redactedAttributes, emit)
+}
+
+func (ae *adminExtension) parseTapRequest(r *http.Request) (*tapRequest, error) {
+	req := &tapRequest{
+		samplingRatio: 1,
+		rateLimit:     ae.config.Tap.MaxRateLimit,
+	}
+	var err error
+	if req.pipelineID, err = idParam(r, pipelineParam); err != nil {
+		return nil, err
+	}
+	if req.componentID, err = idParam(r, componentParam); err != nil {
+		return nil, err
+	}
+
+	query := r.URL.Query()
+	switch kind := query.Get(kindParam); kind {
+	case "receiver":
+		req.kind = component.KindReceiver
+	case "processor":
+		req.kind = component.KindProcessor
+	case "connector":
+		req.kind = component.KindConnector
+	default:
+		return nil, fmt.Errorf("invalid %q query parameter %q, must be receiver, processor or connector", kindParam, kind)
+	}
+
+	if value := query.Get(samplingRatioParam); value != "" {
+		if req.samplingRatio, err = strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("invalid %q query parameter: %w", samplingRatioParam, err)
+		}
+	}
+	if value := query.Get(rateLimitParam); value != "" {
+		if req.rateLimit, err = strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("invalid %q query parameter: %w", rateLimitParam, err)
+		}
+		if req.rateLimit > ae.config.Tap.MaxRateLimit {
+			return nil, fmt.Errorf("%q query parameter must not exceed %v", rateLimitParam, ae.config.Tap.MaxRateLimit)
+		}
+	}
+
+	req.redactedAttributes = append(req.redactedAttributes, ae.config.Tap.RedactedAttributes...)
+	if value := query.Get(redactParam); value != "" {
+		req.redactedAttributes = append(req.redactedAttributes, strings.Split(value, ",")...)
+	}
+	return req, nil
+}
+
+// idParam returns the component.ID in the given query parameter of the request.
+func idParam(r *http.Request, name string) (component.ID, error) {
+	var id component.ID
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return id, fmt.Errorf("missing %q query parameter", name)
+	}
+	if err := id.UnmarshalText([]byte(value)); err != nil {
+		return id, fmt.Errorf("invalid %q query parameter: %w", name, err)
+	}
+	return id, nil
+}
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adminextension
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// tapperHost is a component.Host tapping a single processor, emitting a message describing the request.
+type tapperHost struct {
+	component.Host
+	messages int
+}
+
+func (h *tapperHost) ValidateTapNode(pipelineID component.ID, kind component.Kind, componentID component.ID, _ float64, _ float64) error {
+	if pipelineID != component.MustNewID("traces") || kind != component.KindProcessor || componentID != component.MustNewID("batch") {
+		return errors.New("node not found")
+	}
+	return nil
+}
+
+func (h *tapperHost) TapNode(ctx context.Context, pipelineID component.ID, kind component.Kind, componentID component.ID,
+	samplingRatio float64, rateLimit float64, redactedAttributes []string, emit func([]byte) error) error {
+	if pipelineID != component.MustNewID("traces") || kind != component.KindProcessor || componentID != component.MustNewID("batch") {
+		return errors.New("node not found")
+	}
+	for i := 0; i < h.messages; i++ {
+		if ctx.Err() != nil {
+			return nil
+		}
+		msg := fmt.Sprintf(`{"message":%d,"sampling_ratio":%v,"rate_limit":%v,"redacted":%q}`, i, samplingRatio, rateLimit, strings.Join(redactedAttributes, ","))
+		if err := emit([]byte(msg)); err != nil {
+			return err
+		}
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func TestTapHTTP(t *testing.T) {
+	url := startTestExtension(t, &tapperHost{Host: componenttest.NewNopHost(), messages: 2})
+
+	resp, err := http.Get(url + "/tap?pipeline=traces&kind=processor&component=batch&sampling_ratio=0.5&rate_limit=2&redact=user.id")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+	scanner := bufio.NewScanner(resp.Body)
+	require.True(t, scanner.Scan())
+	assert.Equal(t, `{"message":0,"sampling_ratio":0.5,"rate_limit":2,"redacted":"user.email,user.id"}`, scanner.Text())
+	require.True(t, scanner.Scan())
+	assert.Equal(t, `{"message":1,"sampling_ratio":0.5,"rate_limit":2,"redacted":"user.email,user.id"}`, scanner.Text())
+}
+
+func TestTapWebsocket(t *testing.T) {
+	url := startTestExtension(t, &tapperHost{Host: componenttest.NewNopHost(), messages: 2})
+
+	wsURL := "ws" + strings.TrimPrefix(url, "http") + "/tap?pipeline=traces&kind=processor&component=batch"
+	ws, err := websocket.Dial(wsURL, "", url)
+	require.NoError(t, err)
+	defer ws.Close()
+
+	var msg string
+	require.NoError(t, websocket.Message.Receive(ws, &msg))
+	assert.Equal(t, `{"message":0,"sampling_ratio":1,"rate_limit":10,"redacted":"user.email"}`, msg)
+	require.NoError(t, websocket.Message.Receive(ws, &msg))
+	assert.Equal(t, `{"message":1,"sampling_ratio":1,"rate_limit":10,"redacted":"user.email"}`, msg)
+}
+
+func TestTapWebsocketOrigin(t *testing.T) {
+	url := startTestExtensionWithConfig(t, &tapperHost{Host: componenttest.NewNopHost(), messages: 1}, confighttp.ServerConfig{
+		CORS: &confighttp.CORSConfig{AllowedOrigins: []string{"https://*.example.com"}},
+	})
+	wsURL := "ws" + strings.TrimPrefix(url, "http") + "/tap?pipeline=traces&kind=processor&component=batch"
+
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{origin: url, allowed: true},
+		{origin: "https://admin.example.com", allowed: true},
+		{origin: "https://evil.test", allowed: false},
+		{origin: "https://example.com.evil.test", allowed: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			ws, err := websocket.Dial(wsURL, "", tt.origin)
+			if !tt.allowed {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer ws.Close()
+			var msg string
+			require.NoError(t, websocket.Message.Receive(ws, &msg))
+		})
+	}
+}
+
+func TestTapErrors(t *testing.T) {
+	url := startTestExtension(t, &tapperHost{Host: componenttest.NewNopHost()})
+
+	tests := []struct {
+		method string
+		query  string
+		status int
+	}{
+		{method: http.MethodPost, query: "pipeline=traces&kind=processor&component=batch", status: http.StatusMethodNotAllowed},
+		{method: http.MethodGet, query: "kind=processor&component=batch", status: http.StatusBadRequest},
+		{method: http.MethodGet, query: "pipeline=traces&kind=processor", status: http.StatusBadRequest},
+		{method: http.MethodGet, query: "pipeline=traces&kind=exporter&component=batch", status: http.StatusBadRequest},
+		{method: http.MethodGet, query: "pipeline=traces&kind=processor&component=batch&sampling_ratio=x", status: http.StatusBadRequest},
+		{method: http.MethodGet, query: "pipeline=traces&kind=processor&component=batch&rate_limit=11", status: http.StatusBadRequest},
+		{method: http.MethodGet, query: "pipeline=logs&kind=processor&component=batch", status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, url+"/tap?"+tt.query, nil)
+			require.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
+
+func TestTapUnsupportedHost(t *testing.T) {
+	url := startTestExtension(t, componenttest.NewNopHost())
+	resp, err := http.Get(url + "/tap?pipeline=traces&kind=processor&component=batch")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const grpcKey = "grpc"
+
+// Config has the configuration for the health extension.
+type Config struct {
+	// HTTP configures the server exposing the liveness and readiness endpoints.
+	// It is disabled when nil.
+	HTTP *HTTPConfig `mapstructure:"http"`
+
+	// GRPC configures the server exposing the grpc.health.v1.Health service.
+	// It is disabled when nil.
+	GRPC *configgrpc.ServerConfig `mapstructure:"grpc"`
+
+	// RecoverableErrorThreshold is how long a component may report StatusRecoverableError
+	// before it is considered unhealthy. Zero makes recoverable errors immediately unhealthy.
+	RecoverableErrorThreshold time.Duration `mapstructure:"recoverable_error_threshold"`
+}
+
+// HTTPConfig has the configuration of the HTTP server of the health extension.
+type HTTPConfig struct {
+	confighttp.ServerConfig `mapstructure:",squash"`
+
+	// LivenessPath is the path of the liveness endpoint, which fails once a component is unhealthy.
+	LivenessPath string `mapstructure:"liveness_path"`
+
+	// ReadinessPath is the path of the readiness endpoint, which fails until all the pipelines
+	// are started, and once a component is unhealthy.
+	ReadinessPath string `mapstructure:"readiness_path"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+var _ confmap.Unmarshaler = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.HTTP == nil && cfg.GRPC == nil {
+		return errors.New("at least one of \"http\" or \"grpc\" must be configured")
+	}
+	if cfg.RecoverableErrorThreshold < 0 {
+		return errors.New("\"recoverable_error_threshold\" must not be negative")
+	}
+	if cfg.HTTP != nil {
+		if cfg.HTTP.Endpoint == "" {
+			return errors.New("\"http::endpoint\" is required")
+		}
+		if !strings.HasPrefix(cfg.HTTP.LivenessPath, "/") || !strings.HasPrefix(cfg.HTTP.ReadinessPath, "/") {
+			return errors.New("\"http::liveness_path\" and \"http::readiness_path\" must start with \"/\"")
+		}
+		if cfg.HTTP.LivenessPath == cfg.HTTP.ReadinessPath {
+			return errors.New("\"http::liveness_path\" and \"http::readiness_path\" must be different")
+		}
+	}
+	if cfg.GRPC != nil && cfg.GRPC.NetAddr.Endpoint == "" {
+		return errors.New("\"grpc::endpoint\" is required")
+	}
+	return nil
+}
+
+// Unmarshal a confmap.Conf into the config struct.
+func (cfg *Config) Unmarshal(conf *confmap.Conf) error {
+	// The gRPC server is disabled by default, use its default settings once it is enabled.
+	if conf.IsSet(grpcKey) && cfg.GRPC == nil {
+		cfg.GRPC = createDefaultGRPCConfig()
+	}
+	return conf.Unmarshal(cfg)
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(confmap.New(), cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	assert.Equal(t,
+		&Config{
+			HTTP: &HTTPConfig{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: "localhost:56133",
+				},
+				LivenessPath:  "/health/live",
+				ReadinessPath: "/health/ready",
+			},
+			GRPC:                      createDefaultGRPCConfig(),
+			RecoverableErrorThreshold: 5 * time.Minute,
+		}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		mutate      func(cfg *Config)
+		expectedErr string
+	}{
+		{
+			name: "no server",
+			mutate: func(cfg *Config) {
+				cfg.HTTP = nil
+			},
+			expectedErr: `at least one of "http" or "grpc" must be configured`,
+		},
+		{
+			name: "negative threshold",
+			mutate: func(cfg *Config) {
+				cfg.RecoverableErrorThreshold = -time.Second
+			},
+			expectedErr: `"recoverable_error_threshold" must not be negative`,
+		},
+		{
+			name: "no http endpoint",
+			mutate: func(cfg *Config) {
+				cfg.HTTP.Endpoint = ""
+			},
+			expectedErr: `"http::endpoint" is required`,
+		},
+		{
+			name: "relative path",
+			mutate: func(cfg *Config) {
+				cfg.HTTP.LivenessPath = "livez"
+			},
+			expectedErr: `"http::liveness_path" and "http::readiness_path" must start with "/"`,
+		},
+		{
+			name: "same paths",
+			mutate: func(cfg *Config) {
+				cfg.HTTP.ReadinessPath = cfg.HTTP.LivenessPath
+			},
+			expectedErr: `"http::liveness_path" and "http::readiness_path" must be different`,
+		},
+		{
+			name: "no grpc endpoint",
+			mutate: func(cfg *Config) {
+				cfg.GRPC = createDefaultGRPCConfig()
+				cfg.GRPC.NetAddr.Endpoint = ""
+			},
+			expectedErr: `"grpc::endpoint" is required`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.mutate(cfg)
+			assert.EqualError(t, component.ValidateConfig(cfg), tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package healthextension implements an extension that reports the health of
+// the collector, derived from the status events of its components, over HTTP
+// and the gRPC health checking protocol.
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
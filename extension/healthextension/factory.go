@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/healthextension/internal/metadata"
+)
+
+const (
+	defaultHTTPEndpoint              = "localhost:13135"
+	defaultGRPCEndpoint              = "localhost:13136"
+	defaultLivenessPath              = "/livez"
+	defaultReadinessPath             = "/readyz"
+	defaultRecoverableErrorThreshold = time.Minute
+)
+
+// NewFactory creates a factory for the health extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, createExtension, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		HTTP: &HTTPConfig{
+			ServerConfig: confighttp.ServerConfig{
+				Endpoint: defaultHTTPEndpoint,
+			},
+			LivenessPath:  defaultLivenessPath,
+			ReadinessPath: defaultReadinessPath,
+		},
+		RecoverableErrorThreshold: defaultRecoverableErrorThreshold,
+	}
+}
+
+func createDefaultGRPCConfig() *configgrpc.ServerConfig {
+	return &configgrpc.ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint:  defaultGRPCEndpoint,
+			Transport: confignet.TransportTypeTCP,
+		},
+	}
+}
+
+// createExtension creates the extension based on this config.
+func createExtension(_ context.Context, set extension.CreateSettings, cfg component.Config) (extension.Extension, error) {
+	return newHealthExtension(cfg.(*Config), set.TelemetrySettings), nil
+}
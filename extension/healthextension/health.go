@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+)
+
+// collectorHealth is the health of the collector, as reported by the HTTP endpoints.
+type collectorHealth struct {
+	Healthy   bool                       `json:"healthy"`
+	Ready     bool                       `json:"ready"`
+	Status    string                     `json:"status"`
+	Pipelines map[string]*pipelineHealth `json:"pipelines,omitempty"`
+}
+
+// pipelineHealth is the health of a pipeline, or of the extensions.
+type pipelineHealth struct {
+	Healthy    bool                        `json:"healthy"`
+	Status     string                      `json:"status"`
+	Components map[string]*componentHealth `json:"components"`
+}
+
+// componentHealth is the health of a component instance.
+type componentHealth struct {
+	Healthy   bool      `json:"healthy"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// healthTracker derives the health of the collector from the status tree of its components.
+type healthTracker struct {
+	recoverableErrorThreshold time.Duration
+	now                       func() time.Time
+
+	mu sync.Mutex
+	// aggregator is the Aggregator of the host, or an Aggregator owned by the tracker if the host
+	// does not implement componentstatus.Host.
+	aggregator *componentstatus.Aggregator
+	owned      bool
+	ready      bool
+}
+
+func newHealthTracker(recoverableErrorThreshold time.Duration) *healthTracker {
+	return &healthTracker{
+		recoverableErrorThreshold: recoverableErrorThreshold,
+		now:                       time.Now,
+		aggregator:                componentstatus.NewAggregator(),
+		owned:                     true,
+	}
+}
+
+// useHost makes the tracker use the Aggregator of the host, if any, instead of its own, so that
+// the health is derived from the same statuses as the other consumers of the host.
+func (ht *healthTracker) useHost(host component.Host) {
+	sh, ok := host.(componentstatus.Host)
+	if !ok {
+		return
+	}
+	ht.mu.Lock()
+	defer ht.mu.Unlock()
+	if ht.owned {
+		ht.aggregator.Close()
+	}
+	ht.aggregator = sh.StatusAggregator()
+	ht.owned = false
+}
+
+// record records the status event of the given component instance, unless the events are
+// recorded by the Aggregator of the host.
+func (ht *healthTracker) record(source *component.InstanceID, event *component.StatusEvent) {
+	ht.mu.Lock()
+	defer ht.mu.Unlock()
+	if ht.owned {
+		ht.aggregator.RecordStatus(source, event)
+	}
+}
+
+// close closes the Aggregator owned by the tracker, if any.
+func (ht *healthTracker) close() {
+	ht.mu.Lock()
+	defer ht.mu.Unlock()
+	if ht.owned {
+		ht.aggregator.Close()
+	}
+}
+
+func (ht *healthTracker) status() *componentstatus.AggregateStatus {
+	ht.mu.Lock()
+	defer ht.mu.Unlock()
+	return ht.aggregator.Status()
+}
+
+// setReady records whether all the pipelines are started.
+func (ht *healthTracker) setReady(ready bool) {
+	ht.mu.Lock()
+	defer ht.mu.Unlock()
+	ht.ready = ready
+}
+
+func (ht *healthTracker) isReady() bool {
+	ht.mu.Lock()
+	defer ht.mu.Unlock()
+	return ht.ready
+}
+
+// health returns the current health of the collector.
+func (ht *healthTracker) health() *collectorHealth {
+	now := ht.now()
+	st := ht.status()
+	ch := &collectorHealth{
+		Healthy:   true,
+		Status:    component.StatusStarting.String(),
+		Pipelines: make(map[string]*pipelineHealth, len(st.Children)),
+	}
+	if st.Event != nil {
+		ch.Status = st.Event.Status().String()
+	}
+	for pipeline, ps := range st.Children {
+		ph := &pipelineHealth{
+			Healthy:    true,
+			Status:     ps.Event.Status().String(),
+			Components: make(map[string]*componentHealth, len(ps.Children)),
+		}
+		for key, cs := range ps.Children {
+			chh := &componentHealth{
+				Healthy:   !componentstatus.IsUnhealthy(cs.Event, now, ht.recoverableErrorThreshold),
+				Status:    cs.Event.Status().String(),
+				Timestamp: cs.Event.Timestamp(),
+			}
+			if cs.Event.Err() != nil {
+				chh.Error = cs.Event.Err().Error()
+			}
+			ph.Components[key] = chh
+			ph.Healthy = ph.Healthy && chh.Healthy
+		}
+		ch.Pipelines[pipeline] = ph
+		ch.Healthy = ch.Healthy && ph.Healthy
+	}
+	ch.Ready = ht.isReady() && ch.Healthy && ch.Status != component.StatusStarting.String()
+	return ch
+}
+
+// nextChange returns the time at which a recoverable error will exceed the threshold,
+// changing the health of the collector, or the zero time if there is none.
+func (ht *healthTracker) nextChange() time.Time {
+	now := ht.now()
+	var next time.Time
+	for _, ps := range ht.status().Children {
+		for _, cs := range ps.Children {
+			if cs.Event.Status() != component.StatusRecoverableError {
+				continue
+			}
+			deadline := cs.Event.Timestamp().Add(ht.recoverableErrorThreshold)
+			if deadline.After(now) && (next.IsZero() || deadline.Before(next)) {
+				next = deadline
+			}
+		}
+	}
+	return next
+}
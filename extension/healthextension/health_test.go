@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	tracesID  = component.MustNewID("traces")
+	metricsID = component.MustNewID("metrics")
+
+	receiverID = &component.InstanceID{
+		ID:          component.MustNewID("otlp"),
+		Kind:        component.KindReceiver,
+		PipelineIDs: map[component.ID]struct{}{tracesID: {}, metricsID: {}},
+	}
+	exporterID = &component.InstanceID{
+		ID:          component.MustNewIDWithName("otlp", "backend"),
+		Kind:        component.KindExporter,
+		PipelineIDs: map[component.ID]struct{}{tracesID: {}},
+	}
+	extensionID = &component.InstanceID{
+		ID:   component.MustNewID("health"),
+		Kind: component.KindExtension,
+	}
+)
+
+func TestHealthTrackerStarting(t *testing.T) {
+	ht := newHealthTracker(time.Minute)
+	ch := ht.health()
+	assert.True(t, ch.Healthy)
+	assert.False(t, ch.Ready)
+	assert.Equal(t, "StatusStarting", ch.Status)
+
+	ht.record(extensionID, component.NewStatusEvent(component.StatusOK))
+	ht.record(receiverID, component.NewStatusEvent(component.StatusStarting))
+	ht.setReady(true)
+	ch = ht.health()
+	assert.True(t, ch.Healthy)
+	assert.False(t, ch.Ready)
+	assert.Equal(t, "StatusStarting", ch.Status)
+}
+
+func TestHealthTrackerPipelines(t *testing.T) {
+	ht := newHealthTracker(time.Minute)
+	ht.record(extensionID, component.NewStatusEvent(component.StatusOK))
+	ht.record(receiverID, component.NewStatusEvent(component.StatusOK))
+	ht.record(exporterID, component.NewStatusEvent(component.StatusOK))
+	ht.setReady(true)
+
+	ch := ht.health()
+	assert.True(t, ch.Healthy)
+	assert.True(t, ch.Ready)
+	assert.Equal(t, "StatusOK", ch.Status)
+	assert.ElementsMatch(t, []string{"extensions", "traces", "metrics"}, keys(ch.Pipelines))
+	assert.ElementsMatch(t, []string{"receiver:otlp", "exporter:otlp/backend"}, keys(ch.Pipelines["traces"].Components))
+	assert.ElementsMatch(t, []string{"receiver:otlp"}, keys(ch.Pipelines["metrics"].Components))
+	assert.ElementsMatch(t, []string{"extension:health"}, keys(ch.Pipelines["extensions"].Components))
+
+	ht.record(exporterID, component.NewPermanentErrorEvent(errors.New("invalid endpoint")))
+	ch = ht.health()
+	assert.False(t, ch.Healthy)
+	assert.False(t, ch.Ready)
+	assert.Equal(t, "StatusPermanentError", ch.Status)
+	assert.False(t, ch.Pipelines["traces"].Healthy)
+	assert.Equal(t, "StatusPermanentError", ch.Pipelines["traces"].Status)
+	assert.Equal(t, "invalid endpoint", ch.Pipelines["traces"].Components["exporter:otlp/backend"].Error)
+	assert.True(t, ch.Pipelines["metrics"].Healthy)
+	assert.Equal(t, "StatusOK", ch.Pipelines["metrics"].Status)
+
+	ht.setReady(false)
+	ht.record(exporterID, component.NewStatusEvent(component.StatusOK))
+	ch = ht.health()
+	assert.True(t, ch.Healthy)
+	assert.False(t, ch.Ready)
+}
+
+func TestHealthTrackerRecoverableError(t *testing.T) {
+	ht := newHealthTracker(time.Minute)
+	now := time.Now()
+	ht.now = func() time.Time { return now }
+	ht.record(receiverID, component.NewStatusEvent(component.StatusOK))
+	ht.setReady(true)
+	assert.True(t, ht.nextChange().IsZero())
+
+	first := component.NewRecoverableErrorEvent(errors.New("connection refused"))
+	ht.record(exporterID, first)
+	// The threshold is relative to the first event of the recoverable error.
+	ht.record(exporterID, component.NewRecoverableErrorEvent(errors.New("connection reset")))
+	assert.Equal(t, first.Timestamp().Add(time.Minute), ht.nextChange())
+
+	ch := ht.health()
+	assert.True(t, ch.Healthy)
+	assert.True(t, ch.Ready)
+	assert.Equal(t, "StatusRecoverableError", ch.Pipelines["traces"].Status)
+	assert.Equal(t, "connection refused", ch.Pipelines["traces"].Components["exporter:otlp/backend"].Error)
+
+	now = first.Timestamp().Add(time.Minute)
+	assert.True(t, ht.nextChange().IsZero())
+	ch = ht.health()
+	assert.False(t, ch.Healthy)
+	assert.False(t, ch.Ready)
+	assert.False(t, ch.Pipelines["traces"].Healthy)
+	assert.True(t, ch.Pipelines["metrics"].Healthy)
+
+	ht.record(exporterID, component.NewStatusEvent(component.StatusOK))
+	assert.True(t, ht.health().Healthy)
+}
+
+func TestHealthTrackerZeroThreshold(t *testing.T) {
+	ht := newHealthTracker(0)
+	ht.record(exporterID, component.NewRecoverableErrorEvent(errors.New("connection refused")))
+	assert.False(t, ht.health().Healthy)
+	assert.True(t, ht.nextChange().IsZero())
+}
+
+func keys[V any](m map[string]V) []string {
+	ret := make([]string, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+	return ret
+}
@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+)
+
+type healthExtension struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+	tracker   *healthTracker
+
+	httpServer   *http.Server
+	grpcServer   *grpc.Server
+	healthServer *health.Server
+	wg           sync.WaitGroup
+
+	// mu protects the timer updating the gRPC serving statuses once a recoverable error
+	// exceeds the threshold.
+	mu      sync.Mutex
+	timer   *time.Timer
+	stopped bool
+}
+
+var (
+	_ extension.StatusWatcher   = (*healthExtension)(nil)
+	_ extension.PipelineWatcher = (*healthExtension)(nil)
+)
+
+func newHealthExtension(config *Config, telemetry component.TelemetrySettings) *healthExtension {
+	return &healthExtension{
+		config:       config,
+		telemetry:    telemetry,
+		tracker:      newHealthTracker(config.RecoverableErrorThreshold),
+		healthServer: health.NewServer(),
+	}
+}
+
+func (he *healthExtension) Start(ctx context.Context, host component.Host) error {
+	he.tracker.useHost(host)
+	// Not serving until the pipelines are ready.
+	he.healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	if he.config.HTTP != nil {
+		if err := he.startHTTP(ctx, host); err != nil {
+			return err
+		}
+	}
+	if he.config.GRPC != nil {
+		if err := he.startGRPC(ctx, host); err != nil {
+			return multierr.Append(err, he.Shutdown(ctx))
+		}
+	}
+	return nil
+}
+
+func (he *healthExtension) startHTTP(ctx context.Context, host component.Host) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(he.config.HTTP.LivenessPath, func(w http.ResponseWriter, _ *http.Request) {
+		ch := he.tracker.health()
+		writeHealth(w, ch, ch.Healthy)
+	})
+	mux.HandleFunc(he.config.HTTP.ReadinessPath, func(w http.ResponseWriter, _ *http.Request) {
+		ch := he.tracker.health()
+		writeHealth(w, ch, ch.Ready)
+	})
+
+	// Start the listener here so we can have earlier failure if port is
+	// already in use.
+	ln, err := he.config.HTTP.ToListener(ctx)
+	if err != nil {
+		return err
+	}
+	he.httpServer, err = he.config.HTTP.ToServer(ctx, host, he.telemetry, mux)
+	if err != nil {
+		return multierr.Append(err, ln.Close())
+	}
+
+	he.telemetry.Logger.Info("Starting HTTP health server", zap.String("endpoint", he.config.HTTP.Endpoint))
+	he.wg.Add(1)
+	go func() {
+		defer he.wg.Done()
+		if errHTTP := he.httpServer.Serve(ln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			he.telemetry.ReportStatus(component.NewFatalErrorEvent(errHTTP))
+		}
+	}()
+	return nil
+}
+
+func (he *healthExtension) startGRPC(ctx context.Context, host component.Host) error {
+	var err error
+	if he.grpcServer, err = he.config.GRPC.ToServer(ctx, host, he.telemetry); err != nil {
+		return err
+	}
+	healthpb.RegisterHealthServer(he.grpcServer, he.healthServer)
+
+	ln, err := he.config.GRPC.NetAddr.Listen(ctx)
+	if err != nil {
+		return err
+	}
+
+	he.telemetry.Logger.Info("Starting gRPC health server", zap.String("endpoint", he.config.GRPC.NetAddr.Endpoint))
+	he.wg.Add(1)
+	go func() {
+		defer he.wg.Done()
+		if errGRPC := he.grpcServer.Serve(ln); errGRPC != nil && !errors.Is(errGRPC, grpc.ErrServerStopped) {
+			he.telemetry.ReportStatus(component.NewFatalErrorEvent(errGRPC))
+		}
+	}()
+	return nil
+}
+
+func (he *healthExtension) Shutdown(context.Context) error {
+	he.mu.Lock()
+	he.stopped = true
+	if he.timer != nil {
+		he.timer.Stop()
+	}
+	he.mu.Unlock()
+
+	he.healthServer.Shutdown()
+
+	var err error
+	if he.httpServer != nil {
+		err = multierr.Append(err, he.httpServer.Close())
+	}
+	if he.grpcServer != nil {
+		he.grpcServer.Stop()
+	}
+	he.wg.Wait()
+	he.tracker.close()
+	return err
+}
+
+// ComponentStatusChanged implements extension.StatusWatcher.
+func (he *healthExtension) ComponentStatusChanged(source *component.InstanceID, event *component.StatusEvent) {
+	he.tracker.record(source, event)
+	he.updateServingStatus()
+}
+
+// Ready implements extension.PipelineWatcher.
+func (he *healthExtension) Ready() error {
+	he.tracker.setReady(true)
+	he.updateServingStatus()
+	return nil
+}
+
+// NotReady implements extension.PipelineWatcher.
+func (he *healthExtension) NotReady() error {
+	he.tracker.setReady(false)
+	he.updateServingStatus()
+	return nil
+}
+
+// updateServingStatus updates the serving statuses of the gRPC health service: the collector
+// under the empty service name, and each pipeline under its ID.
+func (he *healthExtension) updateServingStatus() {
+	he.mu.Lock()
+	defer he.mu.Unlock()
+	if he.stopped {
+		return
+	}
+
+	ch := he.tracker.health()
+	he.healthServer.SetServingStatus("", servingStatus(ch.Ready))
+	for pipeline, ph := range ch.Pipelines {
+		he.healthServer.SetServingStatus(pipeline, servingStatus(ch.Ready && ph.Healthy))
+	}
+
+	// Update again once the next recoverable error exceeds the threshold.
+	if he.timer != nil {
+		he.timer.Stop()
+		he.timer = nil
+	}
+	if next := he.tracker.nextChange(); !next.IsZero() {
+		he.timer = time.AfterFunc(time.Until(next), he.updateServingStatus)
+	}
+}
+
+func servingStatus(serving bool) healthpb.HealthCheckResponse_ServingStatus {
+	if serving {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+func writeHealth(w http.ResponseWriter, ch *collectorHealth, ok bool) {
+	w.Header().Set("Content-Type", "application/json")
+	if ok {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(ch)
+}
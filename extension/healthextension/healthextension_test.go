@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/internal/testutil"
+)
+
+func newTestConfig(t *testing.T) *Config {
+	return &Config{
+		HTTP: &HTTPConfig{
+			ServerConfig: confighttp.ServerConfig{
+				Endpoint: testutil.GetAvailableLocalAddress(t),
+			},
+			LivenessPath:  defaultLivenessPath,
+			ReadinessPath: defaultReadinessPath,
+		},
+		GRPC: &configgrpc.ServerConfig{
+			NetAddr: confignet.AddrConfig{
+				Endpoint:  testutil.GetAvailableLocalAddress(t),
+				Transport: confignet.TransportTypeTCP,
+			},
+		},
+		RecoverableErrorThreshold: time.Minute,
+	}
+}
+
+func TestHealthExtensionHTTP(t *testing.T) {
+	cfg := newTestConfig(t)
+	ext := newHealthExtension(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ext.Shutdown(context.Background())) })
+
+	livez := "http://" + cfg.HTTP.Endpoint + defaultLivenessPath
+	readyz := "http://" + cfg.HTTP.Endpoint + defaultReadinessPath
+
+	assertHealth(t, livez, http.StatusOK)
+	assertHealth(t, readyz, http.StatusServiceUnavailable)
+
+	ext.ComponentStatusChanged(receiverID, component.NewStatusEvent(component.StatusOK))
+	ext.ComponentStatusChanged(exporterID, component.NewStatusEvent(component.StatusOK))
+	require.NoError(t, ext.Ready())
+	assertHealth(t, livez, http.StatusOK)
+	ch := assertHealth(t, readyz, http.StatusOK)
+	assert.Equal(t, "StatusOK", ch.Status)
+	assert.Equal(t, "StatusOK", ch.Pipelines["traces"].Components["exporter:otlp/backend"].Status)
+
+	ext.ComponentStatusChanged(exporterID, component.NewFatalErrorEvent(errors.New("failed")))
+	ch = assertHealth(t, livez, http.StatusServiceUnavailable)
+	assert.Equal(t, "failed", ch.Pipelines["traces"].Components["exporter:otlp/backend"].Error)
+	assertHealth(t, readyz, http.StatusServiceUnavailable)
+}
+
+func TestHealthExtensionGRPC(t *testing.T) {
+	cfg := newTestConfig(t)
+	ext := newHealthExtension(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ext.Shutdown(context.Background())) })
+
+	conn, err := grpc.NewClient(cfg.GRPC.NetAddr.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, conn.Close()) })
+	client := healthpb.NewHealthClient(conn)
+
+	assertServingStatus(t, client, "", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	ext.ComponentStatusChanged(receiverID, component.NewStatusEvent(component.StatusOK))
+	ext.ComponentStatusChanged(exporterID, component.NewStatusEvent(component.StatusOK))
+	require.NoError(t, ext.Ready())
+	assertServingStatus(t, client, "", healthpb.HealthCheckResponse_SERVING)
+	assertServingStatus(t, client, "traces", healthpb.HealthCheckResponse_SERVING)
+	assertServingStatus(t, client, "metrics", healthpb.HealthCheckResponse_SERVING)
+
+	ext.ComponentStatusChanged(exporterID, component.NewPermanentErrorEvent(errors.New("failed")))
+	assertServingStatus(t, client, "", healthpb.HealthCheckResponse_NOT_SERVING)
+	assertServingStatus(t, client, "traces", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	require.NoError(t, ext.NotReady())
+	assertServingStatus(t, client, "metrics", healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func TestHealthExtensionRecoverableErrorThreshold(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.HTTP = nil
+	cfg.RecoverableErrorThreshold = 50 * time.Millisecond
+	ext := newHealthExtension(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ext.Shutdown(context.Background())) })
+
+	conn, err := grpc.NewClient(cfg.GRPC.NetAddr.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, conn.Close()) })
+	client := healthpb.NewHealthClient(conn)
+
+	ext.ComponentStatusChanged(exporterID, component.NewStatusEvent(component.StatusOK))
+	require.NoError(t, ext.Ready())
+	ext.ComponentStatusChanged(exporterID, component.NewRecoverableErrorEvent(errors.New("connection refused")))
+	assertServingStatus(t, client, "traces", healthpb.HealthCheckResponse_SERVING)
+
+	// The serving status is updated once the error exceeds the threshold, without new events.
+	assert.Eventually(t, func() bool {
+		resp, errCheck := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "traces"})
+		return errCheck == nil && resp.Status == healthpb.HealthCheckResponse_NOT_SERVING
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+type statusHost struct {
+	component.Host
+	aggregator *componentstatus.Aggregator
+}
+
+func (h *statusHost) StatusAggregator() *componentstatus.Aggregator {
+	return h.aggregator
+}
+
+func TestHealthExtensionHostAggregator(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.GRPC = nil
+	host := &statusHost{Host: componenttest.NewNopHost(), aggregator: componentstatus.NewAggregator()}
+	ext := newHealthExtension(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, ext.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, ext.Shutdown(context.Background())) })
+
+	// The events are recorded by the host, the extension is only notified of them.
+	host.aggregator.RecordStatus(exporterID, component.NewPermanentErrorEvent(errors.New("failed")))
+	ext.ComponentStatusChanged(exporterID, component.NewPermanentErrorEvent(errors.New("failed")))
+	ch := assertHealth(t, "http://"+cfg.HTTP.Endpoint+defaultLivenessPath, http.StatusServiceUnavailable)
+	assert.Equal(t, "failed", ch.Pipelines["traces"].Components["exporter:otlp/backend"].Error)
+	assert.Equal(t, "StatusPermanentError", host.aggregator.Status().Event.Status().String())
+}
+
+func TestHealthExtensionPortAlreadyInUse(t *testing.T) {
+	cfg := newTestConfig(t)
+	ln, err := net.Listen("tcp", cfg.GRPC.NetAddr.Endpoint)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	ext := newHealthExtension(cfg, componenttest.NewNopTelemetrySettings())
+	require.Error(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+}
+
+func assertHealth(t *testing.T, url string, expectedStatusCode int) *collectorHealth {
+	resp, err := http.Get(url) //nolint:gosec
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, expectedStatusCode, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	ch := &collectorHealth{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(ch))
+	return ch
+}
+
+func assertServingStatus(t *testing.T, client healthpb.HealthClient, service string, expected healthpb.HealthCheckResponse_ServingStatus) {
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	assert.Equal(t, expected, resp.Status)
+}
@@ -0,0 +1,22 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package logctx passes the context of a log entry to the logger of the collector, so that the
+// log records exported with the OpenTelemetry logs SDK are correlated with the span in the context.
+package logctx // import "go.opentelemetry.io/collector/internal/logctx"
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Key is the key of the fields returned by Field.
+const Key = "context"
+
+// Field returns a zap field carrying the context of a log entry. It is not written to the zap
+// outputs, and it is ignored by the loggers which do not export the log records.
+func Field(ctx context.Context) zap.Field {
+	return zap.Field{Key: Key, Type: zapcore.SkipType, Interface: ctx}
+}
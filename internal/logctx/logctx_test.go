@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logctx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type ctxKey struct{}
+
+func TestField(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	field := Field(ctx)
+	assert.Equal(t, Key, field.Key)
+	assert.Equal(t, ctx, field.Interface)
+
+	// The context is not written to the zap outputs.
+	core, logs := observer.New(zap.InfoLevel)
+	zap.New(core).Info("message", field)
+	assert.Empty(t, logs.All()[0].ContextMap())
+}
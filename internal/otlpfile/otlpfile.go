@@ -0,0 +1,330 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package otlpfile reads and writes files of OTLP data. A file is a sequence of records, each
+// holding the batch of a signal: in the JSON format, a record is an OTLP/JSON export request on
+// its own line; in the proto format, a record is an OTLP/protobuf export request preceded by a
+// header of a signal byte and of the big-endian uint32 size of the request.
+package otlpfile // import "go.opentelemetry.io/collector/internal/otlpfile"
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Format is the format of the records of a file.
+type Format string
+
+const (
+	FormatJSON  Format = "json"
+	FormatProto Format = "proto"
+)
+
+// Validate returns an error if the format is not supported.
+func (f Format) Validate() error {
+	switch f {
+	case FormatJSON, FormatProto:
+		return nil
+	}
+	return fmt.Errorf("unsupported format %q", f)
+}
+
+// Compression is the compression of a file.
+type Compression string
+
+const (
+	CompressionNone Compression = ""
+	CompressionGzip Compression = "gzip"
+)
+
+// Validate returns an error if the compression is not supported.
+func (c Compression) Validate() error {
+	switch c {
+	case CompressionNone, CompressionGzip:
+		return nil
+	}
+	return fmt.Errorf("unsupported compression %q", c)
+}
+
+// Signal is the signal of a record.
+type Signal byte
+
+const (
+	SignalTraces Signal = iota + 1
+	SignalMetrics
+	SignalLogs
+)
+
+// headerSize is the size of the header of the records in the proto format.
+const headerSize = 5
+
+// maxRecordSize is the maximum size of the records read in the proto format.
+const maxRecordSize = 1 << 30
+
+var (
+	tracesMarshaler    = &ptrace.ProtoMarshaler{}
+	tracesUnmarshaler  = &ptrace.ProtoUnmarshaler{}
+	metricsMarshaler   = &pmetric.ProtoMarshaler{}
+	metricsUnmarshaler = &pmetric.ProtoUnmarshaler{}
+	logsMarshaler      = &plog.ProtoMarshaler{}
+	logsUnmarshaler    = &plog.ProtoUnmarshaler{}
+
+	tracesJSONMarshaler    = &ptrace.JSONMarshaler{}
+	tracesJSONUnmarshaler  = &ptrace.JSONUnmarshaler{}
+	metricsJSONMarshaler   = &pmetric.JSONMarshaler{}
+	metricsJSONUnmarshaler = &pmetric.JSONUnmarshaler{}
+	logsJSONMarshaler      = &plog.JSONMarshaler{}
+	logsJSONUnmarshaler    = &plog.JSONUnmarshaler{}
+)
+
+// Record is the batch of a signal, only the field of the signal is set.
+type Record struct {
+	Signal  Signal
+	Traces  ptrace.Traces
+	Metrics pmetric.Metrics
+	Logs    plog.Logs
+}
+
+// TracesRecord returns the record of the traces.
+func TracesRecord(td ptrace.Traces) Record {
+	return Record{Signal: SignalTraces, Traces: td}
+}
+
+// MetricsRecord returns the record of the metrics.
+func MetricsRecord(md pmetric.Metrics) Record {
+	return Record{Signal: SignalMetrics, Metrics: md}
+}
+
+// LogsRecord returns the record of the logs.
+func LogsRecord(ld plog.Logs) Record {
+	return Record{Signal: SignalLogs, Logs: ld}
+}
+
+// Encoder writes records to a writer.
+type Encoder struct {
+	w      io.Writer
+	format Format
+}
+
+// NewEncoder returns an Encoder writing the records in the format.
+func NewEncoder(w io.Writer, format Format) *Encoder {
+	return &Encoder{w: w, format: format}
+}
+
+// Encode writes the record with a single call to the writer.
+func (e *Encoder) Encode(rec Record) error {
+	var buf []byte
+	var err error
+	if e.format == FormatJSON {
+		if buf, err = marshalJSON(rec); err != nil {
+			return err
+		}
+		buf = append(buf, '\n')
+	} else {
+		var data []byte
+		if data, err = marshalProto(rec); err != nil {
+			return err
+		}
+		buf = make([]byte, headerSize, headerSize+len(data))
+		buf[0] = byte(rec.Signal)
+		binary.BigEndian.PutUint32(buf[1:], uint32(len(data)))
+		buf = append(buf, data...)
+	}
+	_, err = e.w.Write(buf)
+	return err
+}
+
+// Decoder reads records from a reader.
+type Decoder struct {
+	r      *bufio.Reader
+	format Format
+}
+
+// NewDecoder returns a Decoder reading the records in the format.
+func NewDecoder(r io.Reader, format Format) *Decoder {
+	return &Decoder{r: bufio.NewReader(r), format: format}
+}
+
+// Decode reads the next record. It returns io.EOF at the end of the records, and
+// io.ErrUnexpectedEOF if the last record is truncated.
+func (d *Decoder) Decode() (Record, error) {
+	if d.format == FormatJSON {
+		return d.decodeJSON()
+	}
+	return d.decodeProto()
+}
+
+func (d *Decoder) decodeJSON() (Record, error) {
+	for {
+		line, err := d.r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(bytes.TrimSpace(line)) > 0 {
+				return Record{}, io.ErrUnexpectedEOF
+			}
+			return Record{}, io.EOF
+		}
+		if err != nil {
+			return Record{}, err
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		// The empty batches, without the field of their signal, are skipped.
+		rec, ok, err := unmarshalJSON(line)
+		if err != nil || ok {
+			return rec, err
+		}
+	}
+}
+
+func (d *Decoder) decodeProto() (Record, error) {
+	var header [headerSize]byte
+	if _, err := io.ReadFull(d.r, header[:]); err != nil {
+		return Record{}, err
+	}
+	size := binary.BigEndian.Uint32(header[1:])
+	if size > maxRecordSize {
+		return Record{}, fmt.Errorf("record of %d bytes exceeds the maximum of %d bytes", size, maxRecordSize)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(d.r, data); err != nil {
+		if errors.Is(err, io.EOF) {
+			return Record{}, io.ErrUnexpectedEOF
+		}
+		return Record{}, err
+	}
+	return unmarshalProto(Signal(header[0]), data)
+}
+
+// NewWriter returns a writer compressing the data written to w. Flush writes the data buffered by
+// the compression, and Close writes the end of the compressed stream without closing w.
+func NewWriter(w io.Writer, compression Compression) (WriteFlushCloser, error) {
+	switch compression {
+	case CompressionNone:
+		return nopWriteFlushCloser{w}, nil
+	case CompressionGzip:
+		return gzip.NewWriter(w), nil
+	}
+	return nil, compression.Validate()
+}
+
+// WriteFlushCloser is a writer whose buffered data can be flushed.
+type WriteFlushCloser interface {
+	io.WriteCloser
+	Flush() error
+}
+
+type nopWriteFlushCloser struct {
+	io.Writer
+}
+
+func (nopWriteFlushCloser) Flush() error {
+	return nil
+}
+
+func (nopWriteFlushCloser) Close() error {
+	return nil
+}
+
+// NewReader returns a reader decompressing the data read from r. Close does not close r.
+func NewReader(r io.Reader, compression Compression) (io.ReadCloser, error) {
+	switch compression {
+	case CompressionNone:
+		return io.NopCloser(r), nil
+	case CompressionGzip:
+		return gzip.NewReader(r)
+	}
+	return nil, compression.Validate()
+}
+
+func marshalJSON(rec Record) ([]byte, error) {
+	switch rec.Signal {
+	case SignalTraces:
+		return tracesJSONMarshaler.MarshalTraces(rec.Traces)
+	case SignalMetrics:
+		return metricsJSONMarshaler.MarshalMetrics(rec.Metrics)
+	case SignalLogs:
+		return logsJSONMarshaler.MarshalLogs(rec.Logs)
+	}
+	return nil, fmt.Errorf("unknown signal %d", rec.Signal)
+}
+
+func marshalProto(rec Record) ([]byte, error) {
+	switch rec.Signal {
+	case SignalTraces:
+		return tracesMarshaler.MarshalTraces(rec.Traces)
+	case SignalMetrics:
+		return metricsMarshaler.MarshalMetrics(rec.Metrics)
+	case SignalLogs:
+		return logsMarshaler.MarshalLogs(rec.Logs)
+	}
+	return nil, fmt.Errorf("unknown signal %d", rec.Signal)
+}
+
+// jsonRequest holds the field identifying the signal of an OTLP/JSON export request, in the
+// lowerCamelCase or in the snake_case form accepted by OTLP/JSON.
+type jsonRequest struct {
+	ResourceSpans        json.RawMessage `json:"resourceSpans"`
+	ResourceSpansSnake   json.RawMessage `json:"resource_spans"`
+	ResourceMetrics      json.RawMessage `json:"resourceMetrics"`
+	ResourceMetricsSnake json.RawMessage `json:"resource_metrics"`
+	ResourceLogs         json.RawMessage `json:"resourceLogs"`
+	ResourceLogsSnake    json.RawMessage `json:"resource_logs"`
+}
+
+// unmarshalJSON unmarshals an OTLP/JSON export request, returning false if the request has no data.
+func unmarshalJSON(data []byte) (Record, bool, error) {
+	var req jsonRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return Record{}, false, fmt.Errorf("invalid record: %w", err)
+	}
+	var rec Record
+	var err error
+	switch {
+	case req.ResourceSpans != nil || req.ResourceSpansSnake != nil:
+		rec.Signal = SignalTraces
+		rec.Traces, err = tracesJSONUnmarshaler.UnmarshalTraces(data)
+	case req.ResourceMetrics != nil || req.ResourceMetricsSnake != nil:
+		rec.Signal = SignalMetrics
+		rec.Metrics, err = metricsJSONUnmarshaler.UnmarshalMetrics(data)
+	case req.ResourceLogs != nil || req.ResourceLogsSnake != nil:
+		rec.Signal = SignalLogs
+		rec.Logs, err = logsJSONUnmarshaler.UnmarshalLogs(data)
+	default:
+		return Record{}, false, nil
+	}
+	if err != nil {
+		return Record{}, false, fmt.Errorf("invalid record: %w", err)
+	}
+	return rec, true, nil
+}
+
+func unmarshalProto(signal Signal, data []byte) (Record, error) {
+	rec := Record{Signal: signal}
+	var err error
+	switch signal {
+	case SignalTraces:
+		rec.Traces, err = tracesUnmarshaler.UnmarshalTraces(data)
+	case SignalMetrics:
+		rec.Metrics, err = metricsUnmarshaler.UnmarshalMetrics(data)
+	case SignalLogs:
+		rec.Logs, err = logsUnmarshaler.UnmarshalLogs(data)
+	default:
+		return Record{}, fmt.Errorf("unknown signal %d", signal)
+	}
+	if err != nil {
+		return Record{}, fmt.Errorf("invalid record: %w", err)
+	}
+	return rec, nil
+}
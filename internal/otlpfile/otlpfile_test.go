@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfile
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	records := []Record{
+		TracesRecord(testdata.GenerateTraces(2)),
+		MetricsRecord(testdata.GenerateMetrics(3)),
+		LogsRecord(testdata.GenerateLogs(4)),
+	}
+	for _, format := range []Format{FormatJSON, FormatProto} {
+		for _, compression := range []Compression{CompressionNone, CompressionGzip} {
+			t.Run(string(format)+"_"+string(compression), func(t *testing.T) {
+				buf := &bytes.Buffer{}
+				w, err := NewWriter(buf, compression)
+				require.NoError(t, err)
+				enc := NewEncoder(w, format)
+				for _, rec := range records {
+					require.NoError(t, enc.Encode(rec))
+				}
+				require.NoError(t, w.Close())
+
+				r, err := NewReader(buf, compression)
+				require.NoError(t, err)
+				dec := NewDecoder(r, format)
+				for _, rec := range records {
+					got, err := dec.Decode()
+					require.NoError(t, err)
+					assert.Equal(t, rec, got)
+				}
+				_, err = dec.Decode()
+				assert.ErrorIs(t, err, io.EOF)
+				require.NoError(t, r.Close())
+			})
+		}
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, NewEncoder(buf, FormatJSON).Encode(LogsRecord(plog.NewLogs())))
+	buf.WriteString("\n")
+	require.NoError(t, NewEncoder(buf, FormatJSON).Encode(LogsRecord(testdata.GenerateLogs(1))))
+	buf.WriteString(`{"resource_spans":[{"scope_spans":[{"spans":[{"name":"span"}]}]}]}` + "\n")
+
+	// The empty batches and lines are skipped, the snake_case fields are accepted.
+	dec := NewDecoder(buf, FormatJSON)
+	rec, err := dec.Decode()
+	require.NoError(t, err)
+	assert.Equal(t, testdata.GenerateLogs(1), rec.Logs)
+	rec, err = dec.Decode()
+	require.NoError(t, err)
+	assert.Equal(t, SignalTraces, rec.Signal)
+	assert.Equal(t, 1, rec.Traces.SpanCount())
+
+	_, err = NewDecoder(strings.NewReader(`{"resourceLogs":[]`), FormatJSON).Decode()
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	_, err = NewDecoder(strings.NewReader("not json\n"), FormatJSON).Decode()
+	assert.ErrorContains(t, err, "invalid record")
+}
+
+func TestDecodeProtoErrors(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, NewEncoder(buf, FormatProto).Encode(LogsRecord(testdata.GenerateLogs(1))))
+	truncated := buf.Bytes()[:buf.Len()-1]
+	_, err := NewDecoder(bytes.NewReader(truncated), FormatProto).Decode()
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	_, err = NewDecoder(bytes.NewReader(truncated[:3]), FormatProto).Decode()
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	_, err = NewDecoder(bytes.NewReader([]byte{9, 0, 0, 0, 0}), FormatProto).Decode()
+	assert.EqualError(t, err, "unknown signal 9")
+	_, err = NewDecoder(bytes.NewReader([]byte{1, 0xff, 0xff, 0xff, 0xff}), FormatProto).Decode()
+	assert.EqualError(t, err, "record of 4294967295 bytes exceeds the maximum of 1073741824 bytes")
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, FormatProto.Validate())
+	assert.EqualError(t, Format("xml").Validate(), `unsupported format "xml"`)
+	assert.NoError(t, CompressionGzip.Validate())
+	assert.EqualError(t, Compression("lz4").Validate(), `unsupported compression "lz4"`)
+	_, err := NewWriter(io.Discard, "lz4")
+	assert.Error(t, err)
+}
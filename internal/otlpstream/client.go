@@ -0,0 +1,370 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpstream // import "go.opentelemetry.io/collector/internal/otlpstream"
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+// ErrUnsupported is returned by the clients when the server does not support the streams: the
+// data must be exported with unary OTLP requests instead.
+var ErrUnsupported = errors.New("OTLP streams are not supported by the server")
+
+// errRetired is returned when a batch is sent on a stream retired concurrently.
+var errRetired = errors.New("stream retired")
+
+// The backoff after which a server that did not support the streams is probed again, e.g. after
+// it was upgraded, doubled after each probe the server still does not support.
+const (
+	initialUnsupportedBackoff = time.Minute
+	maxUnsupportedBackoff     = 30 * time.Minute
+)
+
+// ClientSettings configures a Client.
+type ClientSettings struct {
+	// MaxDictionarySize is the maximum number of entries of each dictionary of a stream. The
+	// lowest of this size and the maximum size of the server is used.
+	MaxDictionarySize int
+	// MaxLifetime is the duration after which a stream is closed and replaced by a new one with
+	// empty dictionaries. If zero, the streams are kept until they fail.
+	MaxLifetime time.Duration
+	// Metadata is sent as the headers of the streams.
+	Metadata metadata.MD
+	// CallOptions are the options of the streams.
+	CallOptions []grpc.CallOption
+}
+
+// Client exports the batches on a stream, opening a new stream when the current one fails or
+// reaches its maximum lifetime.
+type Client struct {
+	conn     grpc.ClientConnInterface
+	settings ClientSettings
+
+	mu     sync.Mutex
+	stream *clientStream
+	// unsupportedUntil is the time until which the server is assumed not to support the streams,
+	// and unsupportedBackoff the duration of the next such period.
+	unsupportedUntil   time.Time
+	unsupportedBackoff time.Duration
+}
+
+// NewClient returns a Client opening its streams on the connection.
+func NewClient(conn grpc.ClientConnInterface, settings ClientSettings) *Client {
+	return &Client{conn: conn, settings: settings}
+}
+
+// ExportTraces exports the traces of the request. It returns ErrUnsupported if the server does
+// not support the streams, and a gRPC status error if the export fails.
+func (c *Client) ExportTraces(ctx context.Context, req ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	resp, err := c.export(ctx, func(enc *encoder, id uint64) (*request, error) {
+		return enc.encodeTraces(id, req.Traces())
+	})
+	exportResp := ptraceotlp.NewExportResponse()
+	if err != nil {
+		return exportResp, err
+	}
+	exportResp.PartialSuccess().SetRejectedSpans(resp.rejected)
+	exportResp.PartialSuccess().SetErrorMessage(resp.message)
+	return exportResp, nil
+}
+
+// ExportMetrics exports the metrics of the request. It returns ErrUnsupported if the server does
+// not support the streams, and a gRPC status error if the export fails.
+func (c *Client) ExportMetrics(ctx context.Context, req pmetricotlp.ExportRequest) (pmetricotlp.ExportResponse, error) {
+	resp, err := c.export(ctx, func(enc *encoder, id uint64) (*request, error) {
+		return enc.encodeMetrics(id, req.Metrics())
+	})
+	exportResp := pmetricotlp.NewExportResponse()
+	if err != nil {
+		return exportResp, err
+	}
+	exportResp.PartialSuccess().SetRejectedDataPoints(resp.rejected)
+	exportResp.PartialSuccess().SetErrorMessage(resp.message)
+	return exportResp, nil
+}
+
+// ExportLogs exports the logs of the request. It returns ErrUnsupported if the server does not
+// support the streams, and a gRPC status error if the export fails.
+func (c *Client) ExportLogs(ctx context.Context, req plogotlp.ExportRequest) (plogotlp.ExportResponse, error) {
+	resp, err := c.export(ctx, func(enc *encoder, id uint64) (*request, error) {
+		return enc.encodeLogs(id, req.Logs())
+	})
+	exportResp := plogotlp.NewExportResponse()
+	if err != nil {
+		return exportResp, err
+	}
+	exportResp.PartialSuccess().SetRejectedLogRecords(resp.rejected)
+	exportResp.PartialSuccess().SetErrorMessage(resp.message)
+	return exportResp, nil
+}
+
+// Close closes the current stream, failing the exports waiting for their response.
+func (c *Client) Close() {
+	c.mu.Lock()
+	cs := c.stream
+	c.stream = nil
+	c.mu.Unlock()
+	if cs != nil {
+		cs.cancel()
+		<-cs.done
+	}
+}
+
+func (c *Client) export(ctx context.Context, encode func(*encoder, uint64) (*request, error)) (*response, error) {
+	for {
+		cs, err := c.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := cs.export(ctx, encode)
+		if errors.Is(err, errRetired) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if codes.Code(resp.code) != codes.OK {
+			return nil, status.Error(codes.Code(resp.code), resp.message)
+		}
+		return resp, nil
+	}
+}
+
+// acquire returns the current stream, or opens a new one if there is no usable stream.
+func (c *Client) acquire(ctx context.Context) (*clientStream, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if time.Now().Before(c.unsupportedUntil) {
+		return nil, ErrUnsupported
+	}
+	if c.stream != nil {
+		if c.stream.usable(c.settings.MaxLifetime) {
+			return c.stream, nil
+		}
+		c.stream.retire()
+		c.stream = nil
+	}
+
+	cs, err := c.open(ctx)
+	if status.Code(err) == codes.Unimplemented {
+		c.unsupportedBackoff = min(max(2*c.unsupportedBackoff, initialUnsupportedBackoff), maxUnsupportedBackoff)
+		c.unsupportedUntil = time.Now().Add(c.unsupportedBackoff)
+		return nil, ErrUnsupported
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.unsupportedBackoff = 0
+	c.stream = cs
+	return cs, nil
+}
+
+func (c *Client) open(ctx context.Context) (*clientStream, error) {
+	// The stream outlives the export opening it.
+	streamCtx, cancel := context.WithCancel(context.Background())
+	if c.settings.Metadata.Len() > 0 {
+		streamCtx = metadata.NewOutgoingContext(streamCtx, c.settings.Metadata)
+	}
+	opts := append([]grpc.CallOption{grpc.CallContentSubtype(codecName)}, c.settings.CallOptions...)
+	stream, err := c.conn.NewStream(streamCtx, &streamDesc, streamMethod, opts...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// The servers send their header at the start of the streams, or fail the streams with the
+	// Unimplemented code if they do not support them.
+	stop := context.AfterFunc(ctx, cancel)
+	header, err := stream.Header()
+	values := header.Get(maxDictionarySizeHeader)
+	if err == nil && len(values) == 0 {
+		// The stream ended without the header, get its status.
+		if err = stream.RecvMsg(&response{}); err == nil || errors.Is(err, io.EOF) {
+			err = status.Error(codes.Unimplemented, "missing OTLP stream header")
+		}
+	}
+	if !stop() {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	maxSize := c.settings.MaxDictionarySize
+	if size, parseErr := strconv.Atoi(values[0]); parseErr == nil && size < maxSize {
+		maxSize = size
+	}
+	cs := &clientStream{
+		stream:  stream,
+		cancel:  cancel,
+		created: time.Now(),
+		sendLock: make(chan struct{}, 1),
+		encoder:  newEncoder(maxSize),
+		pending:  map[uint64]chan *response{},
+		done:     make(chan struct{}),
+	}
+	go cs.receive()
+	return cs, nil
+}
+
+// clientStream is a stream opened by a client.
+type clientStream struct {
+	stream  grpc.ClientStream
+	cancel  context.CancelFunc
+	created time.Time
+
+	// sendLock serializes the encoding and the sending of the batches, which must be decoded in
+	// the same order by the server. It is a channel so that the exports waiting for it stop when
+	// their context is done.
+	sendLock chan struct{}
+	encoder  *encoder
+	nextID  uint64
+	retired bool
+
+	pendingMu sync.Mutex
+	pending   map[uint64]chan *response
+
+	// done is closed when the stream ends, after err is set.
+	done chan struct{}
+	err  error
+}
+
+// usable returns whether new batches can be sent on the stream.
+func (cs *clientStream) usable(maxLifetime time.Duration) bool {
+	select {
+	case <-cs.done:
+		return false
+	default:
+	}
+	return maxLifetime <= 0 || time.Since(cs.created) < maxLifetime
+}
+
+// retire closes the sending side of the stream: the stream ends once the server responded to
+// all the batches.
+func (cs *clientStream) retire() {
+	cs.sendLock <- struct{}{}
+	defer cs.unlock()
+	if !cs.retired {
+		cs.retired = true
+		_ = cs.stream.CloseSend()
+	}
+}
+
+func (cs *clientStream) export(ctx context.Context, encode func(*encoder, uint64) (*request, error)) (*response, error) {
+	ch := make(chan *response, 1)
+
+	if err := cs.lock(ctx); err != nil {
+		return nil, err
+	}
+	if cs.retired {
+		cs.unlock()
+		return nil, errRetired
+	}
+	id := cs.nextID
+	cs.nextID++
+	req, err := encode(cs.encoder, id)
+	if err != nil {
+		// The dictionaries may have been updated with entries the server will never receive.
+		cs.retired = true
+		cs.unlock()
+		cs.cancel()
+		return nil, err
+	}
+	cs.pendingMu.Lock()
+	cs.pending[id] = ch
+	cs.pendingMu.Unlock()
+	// SendMsg blocks while the flow control of the stream does not allow sending the batch. The
+	// stream is aborted if the context is done meanwhile, as the batches sent afterwards could not
+	// be decoded without the partially sent one: the other batches pending on the stream fail with
+	// a retryable error.
+	stop := context.AfterFunc(ctx, cs.cancel)
+	err = cs.stream.SendMsg(req)
+	aborted := !stop()
+	if err != nil || aborted {
+		// The stream is broken, its error is returned once it ends.
+		cs.retired = true
+	}
+	cs.unlock()
+	if aborted {
+		cs.pendingMu.Lock()
+		delete(cs.pending, id)
+		cs.pendingMu.Unlock()
+		return nil, ctx.Err()
+	}
+
+	select {
+	case resp := <-ch:
+		return resp, nil
+	case <-cs.done:
+		select {
+		case resp := <-ch:
+			return resp, nil
+		default:
+		}
+		return nil, cs.err
+	case <-ctx.Done():
+		cs.pendingMu.Lock()
+		delete(cs.pending, id)
+		cs.pendingMu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// lock acquires sendLock, or returns the error of the context if it is done first.
+func (cs *clientStream) lock(ctx context.Context) error {
+	select {
+	case cs.sendLock <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (cs *clientStream) unlock() {
+	<-cs.sendLock
+}
+
+func (cs *clientStream) receive() {
+	for {
+		resp := &response{}
+		if err := cs.stream.RecvMsg(resp); err != nil {
+			cs.finish(err)
+			return
+		}
+		cs.pendingMu.Lock()
+		ch, ok := cs.pending[resp.id]
+		delete(cs.pending, resp.id)
+		cs.pendingMu.Unlock()
+		if ok {
+			ch <- resp
+		}
+	}
+}
+
+// finish ends the stream with the error returned by the stream. The batches waiting for their
+// response may be retried on another stream, the error is returned as an Unavailable error.
+func (cs *clientStream) finish(err error) {
+	if errors.Is(err, io.EOF) {
+		cs.err = status.Error(codes.Unavailable, "OTLP stream closed by the server")
+	} else {
+		cs.err = status.Error(codes.Unavailable, "OTLP stream failed: "+status.Convert(err).Message())
+	}
+	close(cs.done)
+	cs.cancel()
+}
@@ -0,0 +1,291 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpstream
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+type tracesServer struct {
+	ptraceotlp.UnimplementedGRPCServer
+	rejected int64
+	err      error
+}
+
+func (s *tracesServer) Export(context.Context, ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	resp := ptraceotlp.NewExportResponse()
+	if s.rejected > 0 {
+		resp.PartialSuccess().SetRejectedSpans(s.rejected)
+		resp.PartialSuccess().SetErrorMessage("rejected")
+	}
+	return resp, s.err
+}
+
+type logsServer struct {
+	plogotlp.UnimplementedGRPCServer
+	mu   sync.Mutex
+	logs []plog.Logs
+}
+
+func (s *logsServer) Export(_ context.Context, req plogotlp.ExportRequest) (plogotlp.ExportResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logs = append(s.logs, req.Logs())
+	return plogotlp.NewExportResponse(), nil
+}
+
+func (s *logsServer) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.logs)
+}
+
+// startServer starts a gRPC server, registering the stream service if server is set, and returns
+// a connection to it.
+func startServer(t *testing.T, server *Server) *grpc.ClientConn {
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	if server != nil {
+		server.Register(srv)
+	}
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(ln.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+	return conn
+}
+
+func TestClientExport(t *testing.T) {
+	logs := &logsServer{}
+	conn := startServer(t, NewServer(ServerSettings{MaxDictionarySize: 100, Logs: logs}))
+	client := NewClient(conn, ClientSettings{MaxDictionarySize: 1000})
+	defer client.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.ExportLogs(context.Background(), plogotlp.NewExportRequestFromLogs(testdata.GenerateLogs(3)))
+			assert.NoError(t, err)
+			assert.Equal(t, int64(0), resp.PartialSuccess().RejectedLogRecords())
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 10, logs.count())
+	for _, ld := range logs.logs {
+		assertEqualLogs(t, testdata.GenerateLogs(3), ld)
+	}
+
+	// The signals without a server are rejected.
+	_, err := client.ExportMetrics(context.Background(), pmetricotlp.NewExportRequestFromMetrics(testdata.GenerateMetrics(1)))
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestClientExportResponses(t *testing.T) {
+	traces := &tracesServer{rejected: 2}
+	conn := startServer(t, NewServer(ServerSettings{MaxDictionarySize: 100, Traces: traces}))
+	client := NewClient(conn, ClientSettings{MaxDictionarySize: 100})
+	defer client.Close()
+
+	resp, err := client.ExportTraces(context.Background(), ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(3)))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), resp.PartialSuccess().RejectedSpans())
+	assert.Equal(t, "rejected", resp.PartialSuccess().ErrorMessage())
+
+	traces.rejected = 0
+	traces.err = status.Error(codes.InvalidArgument, "invalid")
+	_, err = client.ExportTraces(context.Background(), ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(3)))
+	assert.Equal(t, status.Error(codes.InvalidArgument, "invalid"), err)
+}
+
+// blockingLogsServer blocks the exports until release is closed, recording the maximum number of
+// concurrent exports.
+type blockingLogsServer struct {
+	plogotlp.UnimplementedGRPCServer
+	release     chan struct{}
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (s *blockingLogsServer) Export(context.Context, plogotlp.ExportRequest) (plogotlp.ExportResponse, error) {
+	n := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		m := s.maxInFlight.Load()
+		if n <= m || s.maxInFlight.CompareAndSwap(m, n) {
+			break
+		}
+	}
+	<-s.release
+	return plogotlp.NewExportResponse(), nil
+}
+
+func TestServerMaxInFlightBatches(t *testing.T) {
+	logs := &blockingLogsServer{release: make(chan struct{})}
+	conn := startServer(t, NewServer(ServerSettings{MaxDictionarySize: 100, MaxInFlightBatches: 2, Logs: logs}))
+	client := NewClient(conn, ClientSettings{MaxDictionarySize: 100})
+	defer client.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.ExportLogs(context.Background(), plogotlp.NewExportRequestFromLogs(testdata.GenerateLogs(1)))
+			assert.NoError(t, err)
+		}()
+	}
+	// The next batches are not read from the stream until an export completes.
+	assert.Eventually(t, func() bool { return logs.inFlight.Load() == 2 }, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(2), logs.inFlight.Load())
+
+	close(logs.release)
+	wg.Wait()
+	assert.Equal(t, int32(2), logs.maxInFlight.Load())
+}
+
+func TestServerAdmit(t *testing.T) {
+	logs := &logsServer{}
+	var admitted, released atomic.Int32
+	admit := func(_ context.Context, size int64) (func(), error) {
+		assert.Positive(t, size)
+		if admitted.Add(1) == 2 {
+			return nil, status.Error(codes.ResourceExhausted, "refused")
+		}
+		return func() { released.Add(1) }, nil
+	}
+	conn := startServer(t, NewServer(ServerSettings{MaxDictionarySize: 100, Admit: admit, Logs: logs}))
+	client := NewClient(conn, ClientSettings{MaxDictionarySize: 100})
+	defer client.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err := client.ExportLogs(context.Background(), plogotlp.NewExportRequestFromLogs(testdata.GenerateLogs(2)))
+		if i == 1 {
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+			continue
+		}
+		require.NoError(t, err)
+	}
+	// The dictionaries stay in sync with the client after a refused batch.
+	require.Equal(t, 2, logs.count())
+	for _, ld := range logs.logs {
+		assertEqualLogs(t, testdata.GenerateLogs(2), ld)
+	}
+	assert.Equal(t, int32(2), released.Load())
+}
+
+func TestClientMaxLifetime(t *testing.T) {
+	logs := &logsServer{}
+	conn := startServer(t, NewServer(ServerSettings{MaxDictionarySize: 100, Logs: logs}))
+	client := NewClient(conn, ClientSettings{MaxDictionarySize: 100, MaxLifetime: time.Nanosecond})
+	defer client.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err := client.ExportLogs(context.Background(), plogotlp.NewExportRequestFromLogs(testdata.GenerateLogs(1)))
+		require.NoError(t, err)
+	}
+	// Each batch is sent on a new stream with empty dictionaries.
+	assert.Equal(t, 3, logs.count())
+	for _, ld := range logs.logs {
+		assertEqualLogs(t, testdata.GenerateLogs(1), ld)
+	}
+}
+
+func TestClientUnsupported(t *testing.T) {
+	conn := startServer(t, nil)
+	client := NewClient(conn, ClientSettings{MaxDictionarySize: 100})
+	defer client.Close()
+
+	_, err := client.ExportLogs(context.Background(), plogotlp.NewExportRequestFromLogs(testdata.GenerateLogs(1)))
+	assert.ErrorIs(t, err, ErrUnsupported)
+	_, err = client.ExportLogs(context.Background(), plogotlp.NewExportRequestFromLogs(testdata.GenerateLogs(1)))
+	assert.ErrorIs(t, err, ErrUnsupported)
+	assert.Equal(t, initialUnsupportedBackoff, client.unsupportedBackoff)
+
+	// The server is probed again after the backoff, which doubles while it is still unsupported.
+	client.unsupportedUntil = time.Time{}
+	_, err = client.ExportLogs(context.Background(), plogotlp.NewExportRequestFromLogs(testdata.GenerateLogs(1)))
+	assert.ErrorIs(t, err, ErrUnsupported)
+	assert.Equal(t, 2*initialUnsupportedBackoff, client.unsupportedBackoff)
+	assert.True(t, client.unsupportedUntil.After(time.Now().Add(initialUnsupportedBackoff)))
+}
+
+// blockedConn opens streams on which SendMsg blocks until the stream is canceled, as when the flow
+// control of the stream does not allow sending.
+type blockedConn struct {
+	grpc.ClientConnInterface
+}
+
+func (blockedConn) NewStream(ctx context.Context, _ *grpc.StreamDesc, _ string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+	return &blockedStream{ctx: ctx}, nil
+}
+
+type blockedStream struct {
+	grpc.ClientStream
+	ctx context.Context
+}
+
+func (s *blockedStream) Header() (metadata.MD, error) {
+	return metadata.Pairs(maxDictionarySizeHeader, "100"), nil
+}
+
+func (s *blockedStream) SendMsg(any) error {
+	<-s.ctx.Done()
+	return s.ctx.Err()
+}
+
+func (s *blockedStream) RecvMsg(any) error {
+	<-s.ctx.Done()
+	return s.ctx.Err()
+}
+
+func (s *blockedStream) CloseSend() error {
+	return nil
+}
+
+func TestClientExportBlockedSend(t *testing.T) {
+	client := NewClient(blockedConn{}, ClientSettings{MaxDictionarySize: 100})
+	defer client.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// The export blocked in SendMsg, and the export waiting for it, stop at their deadline.
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			_, err := client.ExportLogs(ctx, plogotlp.NewExportRequestFromLogs(testdata.GenerateLogs(1)))
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,545 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpstream // import "go.opentelemetry.io/collector/internal/otlpstream"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// refKey is the key of the attributes referencing the dictionaries in the payloads. The
+// OpenTelemetry attribute keys must not be empty, so the key is not expected in the data.
+const refKey = "\x00otlpstream.ref"
+
+// Field numbers of the attribute dictionary entries.
+const (
+	attributeKeyField    protowire.Number = 1
+	attributeStrField    protowire.Number = 2
+	attributeBoolField   protowire.Number = 3
+	attributeIntField    protowire.Number = 4
+	attributeDoubleField protowire.Number = 5
+)
+
+var (
+	entryMarshaler   = &plog.ProtoMarshaler{}
+	entryUnmarshaler = &plog.ProtoUnmarshaler{}
+
+	tracesMarshaler    = &ptrace.ProtoMarshaler{}
+	tracesUnmarshaler  = &ptrace.ProtoUnmarshaler{}
+	metricsMarshaler   = &pmetric.ProtoMarshaler{}
+	metricsUnmarshaler = &pmetric.ProtoUnmarshaler{}
+	logsMarshaler      = &plog.ProtoMarshaler{}
+	logsUnmarshaler    = &plog.ProtoUnmarshaler{}
+)
+
+// walker is called with the resources, the scopes and the attribute maps of the data.
+type walker interface {
+	resource(pcommon.Resource) error
+	scope(pcommon.InstrumentationScope) error
+	attributes(pcommon.Map) error
+}
+
+// attributeKey identifies an attribute with a primitive value in the attribute dictionary.
+type attributeKey struct {
+	key string
+	typ pcommon.ValueType
+	str string
+	num uint64
+}
+
+// encoder encodes the batches sent on a stream, adding the attributes, resources and scopes
+// to the dictionaries of the stream until they are full.
+type encoder struct {
+	maxSize       int
+	attributeRefs map[attributeKey]uint64
+	entryRefs     map[string]uint64
+
+	// req is the request being encoded.
+	req *request
+}
+
+func newEncoder(maxSize int) *encoder {
+	return &encoder{
+		maxSize:       maxSize,
+		attributeRefs: map[attributeKey]uint64{},
+		entryRefs:     map[string]uint64{},
+	}
+}
+
+func (e *encoder) encodeTraces(id uint64, td ptrace.Traces) (*request, error) {
+	// The data must not be modified, the references are set on a copy.
+	enc := ptrace.NewTraces()
+	td.CopyTo(enc)
+	e.req = &request{id: id, signal: signalTraces}
+	if err := walkTraces(enc, e); err != nil {
+		return nil, err
+	}
+	var err error
+	e.req.payload, err = tracesMarshaler.MarshalTraces(enc)
+	return e.req, err
+}
+
+func (e *encoder) encodeMetrics(id uint64, md pmetric.Metrics) (*request, error) {
+	enc := pmetric.NewMetrics()
+	md.CopyTo(enc)
+	e.req = &request{id: id, signal: signalMetrics}
+	if err := walkMetrics(enc, e); err != nil {
+		return nil, err
+	}
+	var err error
+	e.req.payload, err = metricsMarshaler.MarshalMetrics(enc)
+	return e.req, err
+}
+
+func (e *encoder) encodeLogs(id uint64, ld plog.Logs) (*request, error) {
+	enc := plog.NewLogs()
+	ld.CopyTo(enc)
+	e.req = &request{id: id, signal: signalLogs}
+	if err := walkLogs(enc, e); err != nil {
+		return nil, err
+	}
+	var err error
+	e.req.payload, err = logsMarshaler.MarshalLogs(enc)
+	return e.req, err
+}
+
+func (e *encoder) resource(res pcommon.Resource) error {
+	ld := plog.NewLogs()
+	res.CopyTo(ld.ResourceLogs().AppendEmpty().Resource())
+	ref, ok, err := e.entryRef(ld)
+	if err != nil || !ok {
+		return err
+	}
+	res.Attributes().Clear()
+	res.SetDroppedAttributesCount(0)
+	res.Attributes().PutInt(refKey, int64(ref))
+	return nil
+}
+
+func (e *encoder) scope(scope pcommon.InstrumentationScope) error {
+	ld := plog.NewLogs()
+	scope.CopyTo(ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().Scope())
+	ref, ok, err := e.entryRef(ld)
+	if err != nil || !ok {
+		return err
+	}
+	scope.SetName("")
+	scope.SetVersion("")
+	scope.Attributes().Clear()
+	scope.SetDroppedAttributesCount(0)
+	scope.Attributes().PutInt(refKey, int64(ref))
+	return nil
+}
+
+// entryRef returns the reference of the resource or scope entry, adding it to the dictionary
+// if it is not full.
+func (e *encoder) entryRef(entry plog.Logs) (uint64, bool, error) {
+	b, err := entryMarshaler.MarshalLogs(entry)
+	if err != nil {
+		return 0, false, err
+	}
+	if ref, ok := e.entryRefs[string(b)]; ok {
+		return ref, true, nil
+	}
+	if len(e.entryRefs) >= e.maxSize {
+		return 0, false, nil
+	}
+	ref := uint64(len(e.entryRefs) + 1)
+	e.entryRefs[string(b)] = ref
+	e.req.entries = append(e.req.entries, b)
+	return ref, true, nil
+}
+
+// attributes replaces the attributes found in or added to the dictionary with an attribute
+// listing their references in order, the other attributes being kept in place and listed
+// with a 0 reference.
+func (e *encoder) attributes(m pcommon.Map) error {
+	if m.Len() == 0 {
+		return nil
+	}
+	refs := make([]byte, 0, m.Len())
+	referenced := false
+	m.RemoveIf(func(k string, v pcommon.Value) bool {
+		ref, ok := e.attributeRef(k, v)
+		refs = protowire.AppendVarint(refs, ref)
+		referenced = referenced || ok
+		return ok
+	})
+	if referenced {
+		m.PutEmptyBytes(refKey).FromRaw(refs)
+	}
+	return nil
+}
+
+func (e *encoder) attributeRef(k string, v pcommon.Value) (uint64, bool) {
+	key := attributeKey{key: k, typ: v.Type()}
+	switch v.Type() {
+	case pcommon.ValueTypeStr:
+		key.str = v.Str()
+	case pcommon.ValueTypeBool:
+		if v.Bool() {
+			key.num = 1
+		}
+	case pcommon.ValueTypeInt:
+		key.num = uint64(v.Int())
+	case pcommon.ValueTypeDouble:
+		key.num = math.Float64bits(v.Double())
+	default:
+		// Only the attributes with a primitive value are added to the dictionary.
+		return 0, false
+	}
+	if ref, ok := e.attributeRefs[key]; ok {
+		return ref, true
+	}
+	if len(e.attributeRefs) >= e.maxSize {
+		return 0, false
+	}
+	ref := uint64(len(e.attributeRefs) + 1)
+	e.attributeRefs[key] = ref
+	e.req.attributes = append(e.req.attributes, marshalAttribute(key))
+	return ref, true
+}
+
+func marshalAttribute(key attributeKey) []byte {
+	var b []byte
+	b = protowire.AppendTag(b, attributeKeyField, protowire.BytesType)
+	b = protowire.AppendString(b, key.key)
+	switch key.typ {
+	case pcommon.ValueTypeStr:
+		b = protowire.AppendTag(b, attributeStrField, protowire.BytesType)
+		b = protowire.AppendString(b, key.str)
+	case pcommon.ValueTypeBool:
+		b = protowire.AppendTag(b, attributeBoolField, protowire.VarintType)
+		b = protowire.AppendVarint(b, key.num)
+	case pcommon.ValueTypeInt:
+		b = protowire.AppendTag(b, attributeIntField, protowire.VarintType)
+		b = protowire.AppendVarint(b, key.num)
+	case pcommon.ValueTypeDouble:
+		b = protowire.AppendTag(b, attributeDoubleField, protowire.Fixed64Type)
+		b = protowire.AppendFixed64(b, key.num)
+	}
+	return b
+}
+
+// attribute is an entry of the attribute dictionary of a decoder.
+type attribute struct {
+	key   string
+	value pcommon.Value
+}
+
+// decoder decodes the batches received on a stream, resolving the references to the
+// dictionaries of the stream.
+type decoder struct {
+	maxSize         int
+	attributeValues []attribute
+	entryValues     []plog.Logs
+}
+
+func newDecoder(maxSize int) *decoder {
+	return &decoder{maxSize: maxSize}
+}
+
+// addEntries adds the dictionary entries of the request to the dictionaries.
+func (d *decoder) addEntries(req *request) error {
+	if len(d.attributeValues)+len(req.attributes) > d.maxSize || len(d.entryValues)+len(req.entries) > d.maxSize {
+		return fmt.Errorf("dictionary size exceeds the maximum of %d entries", d.maxSize)
+	}
+	for _, b := range req.attributes {
+		attr, err := unmarshalAttribute(b)
+		if err != nil {
+			return err
+		}
+		d.attributeValues = append(d.attributeValues, attr)
+	}
+	for _, b := range req.entries {
+		entry, err := entryUnmarshaler.UnmarshalLogs(b)
+		if err != nil {
+			return fmt.Errorf("invalid dictionary entry: %w", err)
+		}
+		if entry.ResourceLogs().Len() != 1 {
+			return errors.New("invalid dictionary entry: must have one resource")
+		}
+		d.entryValues = append(d.entryValues, entry)
+	}
+	return nil
+}
+
+func (d *decoder) decodeTraces(req *request) (ptrace.Traces, error) {
+	if err := d.addEntries(req); err != nil {
+		return ptrace.Traces{}, err
+	}
+	td, err := tracesUnmarshaler.UnmarshalTraces(req.payload)
+	if err != nil {
+		return ptrace.Traces{}, err
+	}
+	return td, walkTraces(td, d)
+}
+
+func (d *decoder) decodeMetrics(req *request) (pmetric.Metrics, error) {
+	if err := d.addEntries(req); err != nil {
+		return pmetric.Metrics{}, err
+	}
+	md, err := metricsUnmarshaler.UnmarshalMetrics(req.payload)
+	if err != nil {
+		return pmetric.Metrics{}, err
+	}
+	return md, walkMetrics(md, d)
+}
+
+func (d *decoder) decodeLogs(req *request) (plog.Logs, error) {
+	if err := d.addEntries(req); err != nil {
+		return plog.Logs{}, err
+	}
+	ld, err := logsUnmarshaler.UnmarshalLogs(req.payload)
+	if err != nil {
+		return plog.Logs{}, err
+	}
+	return ld, walkLogs(ld, d)
+}
+
+func (d *decoder) resource(res pcommon.Resource) error {
+	entry, ok, err := d.entry(res.Attributes())
+	if err != nil || !ok {
+		return err
+	}
+	rl := entry.ResourceLogs().At(0)
+	if rl.ScopeLogs().Len() != 0 {
+		return errors.New("resource references a scope entry")
+	}
+	rl.Resource().CopyTo(res)
+	return nil
+}
+
+func (d *decoder) scope(scope pcommon.InstrumentationScope) error {
+	entry, ok, err := d.entry(scope.Attributes())
+	if err != nil || !ok {
+		return err
+	}
+	rl := entry.ResourceLogs().At(0)
+	if rl.ScopeLogs().Len() != 1 {
+		return errors.New("scope references a resource entry")
+	}
+	rl.ScopeLogs().At(0).Scope().CopyTo(scope)
+	return nil
+}
+
+// entry returns the entry referenced by the attributes of a resource or a scope, if any.
+func (d *decoder) entry(m pcommon.Map) (plog.Logs, bool, error) {
+	v, ok := m.Get(refKey)
+	if !ok {
+		return plog.Logs{}, false, nil
+	}
+	if v.Type() != pcommon.ValueTypeInt || v.Int() < 1 || v.Int() > int64(len(d.entryValues)) {
+		return plog.Logs{}, false, fmt.Errorf("invalid dictionary entry reference %v", v.AsRaw())
+	}
+	return d.entryValues[v.Int()-1], true, nil
+}
+
+func (d *decoder) attributes(m pcommon.Map) error {
+	v, ok := m.Get(refKey)
+	if !ok {
+		return nil
+	}
+	if v.Type() != pcommon.ValueTypeBytes {
+		return errors.New("invalid attribute references")
+	}
+	refs := v.Bytes().AsRaw()
+	m.Remove(refKey)
+
+	// The attributes kept in place are listed with a 0 reference.
+	inline := pcommon.NewMap()
+	m.CopyTo(inline)
+	var inlineKeys []string
+	inline.Range(func(k string, _ pcommon.Value) bool {
+		inlineKeys = append(inlineKeys, k)
+		return true
+	})
+	m.Clear()
+	m.EnsureCapacity(inline.Len() + len(refs))
+	for len(refs) > 0 {
+		ref, n := protowire.ConsumeVarint(refs)
+		if n < 0 {
+			return fmt.Errorf("invalid attribute references: %w", protowire.ParseError(n))
+		}
+		refs = refs[n:]
+		if ref == 0 {
+			if len(inlineKeys) == 0 {
+				return errors.New("invalid attribute references: missing attribute")
+			}
+			v, _ := inline.Get(inlineKeys[0])
+			v.CopyTo(m.PutEmpty(inlineKeys[0]))
+			inlineKeys = inlineKeys[1:]
+			continue
+		}
+		if ref > uint64(len(d.attributeValues)) {
+			return fmt.Errorf("invalid attribute reference %d", ref)
+		}
+		attr := d.attributeValues[ref-1]
+		attr.value.CopyTo(m.PutEmpty(attr.key))
+	}
+	return nil
+}
+
+func unmarshalAttribute(b []byte) (attribute, error) {
+	attr := attribute{value: pcommon.NewValueEmpty()}
+	err := unmarshalFields(b, func(num protowire.Number, typ protowire.Type, b []byte) int {
+		switch {
+		case num == attributeKeyField && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			attr.key = v
+			return n
+		case num == attributeStrField && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			attr.value.SetStr(v)
+			return n
+		case num == attributeBoolField && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			attr.value.SetBool(v != 0)
+			return n
+		case num == attributeIntField && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			attr.value.SetInt(int64(v))
+			return n
+		case num == attributeDoubleField && typ == protowire.Fixed64Type:
+			v, n := protowire.ConsumeFixed64(b)
+			attr.value.SetDouble(math.Float64frombits(v))
+			return n
+		}
+		return protowire.ConsumeFieldValue(num, typ, b)
+	})
+	if err != nil {
+		return attribute{}, fmt.Errorf("invalid dictionary attribute: %w", err)
+	}
+	return attr, nil
+}
+
+func walkTraces(td ptrace.Traces, w walker) error {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if err := w.resource(rs.Resource()); err != nil {
+			return err
+		}
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			if err := w.scope(ss.Scope()); err != nil {
+				return err
+			}
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if err := w.attributes(span.Attributes()); err != nil {
+					return err
+				}
+				events := span.Events()
+				for l := 0; l < events.Len(); l++ {
+					if err := w.attributes(events.At(l).Attributes()); err != nil {
+						return err
+					}
+				}
+				links := span.Links()
+				for l := 0; l < links.Len(); l++ {
+					if err := w.attributes(links.At(l).Attributes()); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func walkMetrics(md pmetric.Metrics, w walker) error {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		if err := w.resource(rm.Resource()); err != nil {
+			return err
+		}
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			if err := w.scope(sm.Scope()); err != nil {
+				return err
+			}
+			metrics := sm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				if err := walkMetric(metrics.At(k), w); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func walkMetric(m pmetric.Metric, w walker) error {
+	var attrs []pcommon.Map
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			attrs = append(attrs, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			attrs = append(attrs, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			attrs = append(attrs, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			attrs = append(attrs, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			attrs = append(attrs, dps.At(i).Attributes())
+		}
+	}
+	for _, a := range attrs {
+		if err := w.attributes(a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkLogs(ld plog.Logs, w walker) error {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if err := w.resource(rl.Resource()); err != nil {
+			return err
+		}
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			if err := w.scope(sl.Scope()); err != nil {
+				return err
+			}
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				if err := w.attributes(lrs.At(k).Attributes()); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpstream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+// assertEqualTraces compares the traces after a protobuf round trip of the decoded traces, whose
+// empty attributes are not nil.
+func assertEqualTraces(t *testing.T, expected, actual ptrace.Traces) {
+	b, err := tracesMarshaler.MarshalTraces(actual)
+	require.NoError(t, err)
+	actual, err = tracesUnmarshaler.UnmarshalTraces(b)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func assertEqualMetrics(t *testing.T, expected, actual pmetric.Metrics) {
+	b, err := metricsMarshaler.MarshalMetrics(actual)
+	require.NoError(t, err)
+	actual, err = metricsUnmarshaler.UnmarshalMetrics(b)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func assertEqualLogs(t *testing.T, expected, actual plog.Logs) {
+	b, err := logsMarshaler.MarshalLogs(actual)
+	require.NoError(t, err)
+	actual, err = logsUnmarshaler.UnmarshalLogs(b)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+// roundTrip marshals and unmarshals the request, as sent on a stream.
+func roundTrip(t *testing.T, req *request) *request {
+	got := &request{}
+	require.NoError(t, got.unmarshal(req.marshal()))
+	return got
+}
+
+func TestEncodeTraces(t *testing.T) {
+	enc, dec := newEncoder(100), newDecoder(100)
+	td := testdata.GenerateTraces(5)
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Scope().SetName("scope")
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutEmptySlice("slice").AppendEmpty().SetStr("value")
+	orig := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().AsRaw()
+
+	first, err := enc.encodeTraces(0, td)
+	require.NoError(t, err)
+	assert.NotEmpty(t, first.attributes)
+	assert.Len(t, first.entries, 2)
+	got, err := dec.decodeTraces(roundTrip(t, first))
+	require.NoError(t, err)
+	assertEqualTraces(t, td, got)
+	// The data is not modified.
+	assert.Equal(t, orig, td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().AsRaw())
+
+	// The second batch only references the dictionaries.
+	second, err := enc.encodeTraces(1, td)
+	require.NoError(t, err)
+	assert.Empty(t, second.attributes)
+	assert.Empty(t, second.entries)
+	assert.Less(t, len(second.marshal()), len(first.marshal()))
+	got, err = dec.decodeTraces(roundTrip(t, second))
+	require.NoError(t, err)
+	assertEqualTraces(t, td, got)
+}
+
+func TestEncodeMetrics(t *testing.T) {
+	enc, dec := newEncoder(100), newDecoder(100)
+	md := testdata.GenerateMetricsAllTypes()
+	for i := 0; i < 2; i++ {
+		req, err := enc.encodeMetrics(uint64(i), md)
+		require.NoError(t, err)
+		got, err := dec.decodeMetrics(roundTrip(t, req))
+		require.NoError(t, err)
+		assertEqualMetrics(t, md, got)
+	}
+}
+
+func TestEncodeLogs(t *testing.T) {
+	enc, dec := newEncoder(100), newDecoder(100)
+	ld := testdata.GenerateLogs(5)
+	for i := 0; i < 2; i++ {
+		req, err := enc.encodeLogs(uint64(i), ld)
+		require.NoError(t, err)
+		got, err := dec.decodeLogs(roundTrip(t, req))
+		require.NoError(t, err)
+		assertEqualLogs(t, ld, got)
+	}
+}
+
+func TestEncodeFullDictionaries(t *testing.T) {
+	enc, dec := newEncoder(1), newDecoder(1)
+	ld := testdata.GenerateLogs(1)
+	attrs := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes()
+	attrs.Clear()
+	attrs.PutStr("first", "value")
+	attrs.PutInt("second", 2)
+	attrs.PutBool("third", true)
+
+	req, err := enc.encodeLogs(0, ld)
+	require.NoError(t, err)
+	// Only the resource and the first attribute are added to the dictionaries, the other
+	// attributes are kept in place and in order.
+	assert.Len(t, req.attributes, 1)
+	assert.Len(t, req.entries, 1)
+	got, err := dec.decodeLogs(roundTrip(t, req))
+	require.NoError(t, err)
+	assertEqualLogs(t, ld, got)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	enc := newEncoder(100)
+	req, err := enc.encodeLogs(0, testdata.GenerateLogs(1))
+	require.NoError(t, err)
+
+	_, err = newDecoder(1).decodeLogs(req)
+	assert.EqualError(t, err, "dictionary size exceeds the maximum of 1 entries")
+
+	// The references of the second request are unknown to a new decoder.
+	req, err = enc.encodeLogs(1, testdata.GenerateLogs(1))
+	require.NoError(t, err)
+	_, err = newDecoder(100).decodeLogs(req)
+	assert.ErrorContains(t, err, "invalid dictionary entry reference")
+
+	ld := testdata.GenerateLogs(1)
+	ld.ResourceLogs().At(0).Resource().Attributes().Clear()
+	ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutEmptyBytes(refKey).FromRaw([]byte{5})
+	req, err = newEncoder(0).encodeLogs(0, ld)
+	require.NoError(t, err)
+	_, err = newDecoder(100).decodeLogs(req)
+	assert.EqualError(t, err, "invalid attribute reference 5")
+}
+
+func TestAttributeEntries(t *testing.T) {
+	for _, v := range []pcommon.Value{
+		pcommon.NewValueStr("str"),
+		pcommon.NewValueBool(true),
+		pcommon.NewValueInt(-42),
+		pcommon.NewValueDouble(1.5),
+	} {
+		enc := newEncoder(1)
+		enc.req = &request{}
+		ref, ok := enc.attributeRef("key", v)
+		require.True(t, ok)
+		assert.Equal(t, uint64(1), ref)
+		attr, err := unmarshalAttribute(enc.req.attributes[0])
+		require.NoError(t, err)
+		assert.Equal(t, "key", attr.key)
+		assert.Equal(t, v, attr.value)
+	}
+}
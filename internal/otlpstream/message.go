@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpstream // import "go.opentelemetry.io/collector/internal/otlpstream"
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers of the request messages.
+const (
+	requestIDField        protowire.Number = 1
+	requestSignalField    protowire.Number = 2
+	requestAttributeField protowire.Number = 3
+	requestEntryField     protowire.Number = 4
+	requestPayloadField   protowire.Number = 5
+)
+
+// Field numbers of the response messages.
+const (
+	responseIDField       protowire.Number = 1
+	responseCodeField     protowire.Number = 2
+	responseMessageField  protowire.Number = 3
+	responseRejectedField protowire.Number = 4
+)
+
+// request is a batch sent by a client on a stream.
+type request struct {
+	// id identifies the batch on the stream, its response has the same id.
+	id     uint64
+	signal signal
+	// attributes are the attributes added to the attribute dictionary of the stream.
+	attributes [][]byte
+	// entries are the resources and scopes added to the entry dictionary of the stream.
+	entries [][]byte
+	// payload is the OTLP export request of the batch, referencing the dictionaries.
+	payload []byte
+}
+
+// response is the result of the export of a batch, sent by a server on a stream.
+type response struct {
+	id       uint64
+	code     uint32
+	message  string
+	rejected int64
+}
+
+// size returns the number of bytes of the attributes, entries and payload of the request.
+func (r *request) size() int64 {
+	n := len(r.payload)
+	for _, b := range r.attributes {
+		n += len(b)
+	}
+	for _, b := range r.entries {
+		n += len(b)
+	}
+	return int64(n)
+}
+
+func (r *request) marshal() []byte {
+	size := protowire.SizeTag(requestIDField) + protowire.SizeVarint(r.id) +
+		protowire.SizeTag(requestSignalField) + protowire.SizeVarint(uint64(r.signal)) +
+		protowire.SizeTag(requestPayloadField) + protowire.SizeBytes(len(r.payload))
+	for _, a := range r.attributes {
+		size += protowire.SizeTag(requestAttributeField) + protowire.SizeBytes(len(a))
+	}
+	for _, e := range r.entries {
+		size += protowire.SizeTag(requestEntryField) + protowire.SizeBytes(len(e))
+	}
+
+	b := make([]byte, 0, size)
+	b = protowire.AppendTag(b, requestIDField, protowire.VarintType)
+	b = protowire.AppendVarint(b, r.id)
+	b = protowire.AppendTag(b, requestSignalField, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(r.signal))
+	for _, a := range r.attributes {
+		b = protowire.AppendTag(b, requestAttributeField, protowire.BytesType)
+		b = protowire.AppendBytes(b, a)
+	}
+	for _, e := range r.entries {
+		b = protowire.AppendTag(b, requestEntryField, protowire.BytesType)
+		b = protowire.AppendBytes(b, e)
+	}
+	b = protowire.AppendTag(b, requestPayloadField, protowire.BytesType)
+	return protowire.AppendBytes(b, r.payload)
+}
+
+func (r *request) unmarshal(b []byte) error {
+	*r = request{}
+	return unmarshalFields(b, func(num protowire.Number, typ protowire.Type, b []byte) int {
+		switch {
+		case num == requestIDField && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			r.id = v
+			return n
+		case num == requestSignalField && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			r.signal = signal(v)
+			return n
+		case num == requestAttributeField && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			r.attributes = append(r.attributes, v)
+			return n
+		case num == requestEntryField && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			r.entries = append(r.entries, v)
+			return n
+		case num == requestPayloadField && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			r.payload = v
+			return n
+		}
+		return protowire.ConsumeFieldValue(num, typ, b)
+	})
+}
+
+func (r *response) marshal() []byte {
+	var b []byte
+	b = protowire.AppendTag(b, responseIDField, protowire.VarintType)
+	b = protowire.AppendVarint(b, r.id)
+	if r.code != 0 {
+		b = protowire.AppendTag(b, responseCodeField, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(r.code))
+	}
+	if r.message != "" {
+		b = protowire.AppendTag(b, responseMessageField, protowire.BytesType)
+		b = protowire.AppendString(b, r.message)
+	}
+	if r.rejected != 0 {
+		b = protowire.AppendTag(b, responseRejectedField, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(r.rejected))
+	}
+	return b
+}
+
+func (r *response) unmarshal(b []byte) error {
+	*r = response{}
+	return unmarshalFields(b, func(num protowire.Number, typ protowire.Type, b []byte) int {
+		switch {
+		case num == responseIDField && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			r.id = v
+			return n
+		case num == responseCodeField && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			r.code = uint32(v)
+			return n
+		case num == responseMessageField && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			r.message = v
+			return n
+		case num == responseRejectedField && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			r.rejected = int64(v)
+			return n
+		}
+		return protowire.ConsumeFieldValue(num, typ, b)
+	})
+}
+
+// unmarshalFields calls consume with the number, the type and the value of each field of the
+// message b. consume returns the length of the value, or a negative length if it is invalid.
+func unmarshalFields(b []byte, consume func(protowire.Number, protowire.Type, []byte) int) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return fmt.Errorf("invalid field tag: %w", protowire.ParseError(n))
+		}
+		b = b[n:]
+		n = consume(num, typ, b)
+		if n < 0 {
+			return fmt.Errorf("invalid field %d: %w", num, protowire.ParseError(n))
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+// codec marshals the messages of the streams, which are not generated protobuf messages.
+type codec struct{}
+
+func (codec) Marshal(v any) ([]byte, error) {
+	switch m := v.(type) {
+	case *request:
+		return m.marshal(), nil
+	case *response:
+		return m.marshal(), nil
+	}
+	return nil, fmt.Errorf("unsupported message type %T", v)
+}
+
+func (codec) Unmarshal(data []byte, v any) error {
+	switch m := v.(type) {
+	case *request:
+		return m.unmarshal(data)
+	case *response:
+		return m.unmarshal(data)
+	}
+	return fmt.Errorf("unsupported message type %T", v)
+}
+
+func (codec) Name() string {
+	return codecName
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package otlpstream implements a bidirectional gRPC stream transport for OTLP. Each stream
+// keeps dictionaries of the attributes, resources and scopes sent on it: the batches sent after
+// the first one only reference the repeated attributes, resources and scopes.
+package otlpstream // import "go.opentelemetry.io/collector/internal/otlpstream"
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+const (
+	serviceName  = "opentelemetry.collector.otlpstream.v1.StreamService"
+	streamMethod = "/" + serviceName + "/Stream"
+
+	// codecName is the content subtype of the streams, see
+	// https://github.com/grpc/grpc/blob/master/doc/PROTOCOL-HTTP2.md#requests.
+	codecName = "otlpstream"
+
+	// maxDictionarySizeHeader is the header sent by servers at the start of the streams with
+	// the maximum number of entries of each dictionary.
+	maxDictionarySizeHeader = "otlp-stream-max-dictionary-size"
+)
+
+// signal is the signal of the data of a batch.
+type signal uint64
+
+const (
+	signalTraces signal = iota + 1
+	signalMetrics
+	signalLogs
+)
+
+func (s signal) String() string {
+	switch s {
+	case signalTraces:
+		return "traces"
+	case signalMetrics:
+		return "metrics"
+	case signalLogs:
+		return "logs"
+	}
+	return fmt.Sprintf("signal(%d)", uint64(s))
+}
+
+var streamDesc = grpc.StreamDesc{
+	StreamName:    "Stream",
+	ServerStreams: true,
+	ClientStreams: true,
+}
+
+func init() {
+	encoding.RegisterCodec(codec{})
+}
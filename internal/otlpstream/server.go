@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpstream // import "go.opentelemetry.io/collector/internal/otlpstream"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+// ServerSettings configures a Server.
+type ServerSettings struct {
+	// MaxDictionarySize is the maximum number of entries of each dictionary of a stream.
+	MaxDictionarySize int
+	// MaxInFlightBatches is the maximum number of batches of a stream exported concurrently. The
+	// next batches are not read from the stream until an export completes, so that the client
+	// is slowed down by the flow control of the stream. It is at least 1.
+	MaxInFlightBatches int
+	// Admit, if set, is called with the size of each batch once read, before it is exported. The
+	// batch is rejected with the returned error, if any, otherwise release is called once the
+	// batch is exported.
+	Admit func(ctx context.Context, size int64) (release func(), err error)
+	// Traces, Metrics and Logs export the batches of their signal. The batches of the signals
+	// without a server are rejected with the Unimplemented code.
+	Traces  ptraceotlp.GRPCServer
+	Metrics pmetricotlp.GRPCServer
+	Logs    plogotlp.GRPCServer
+}
+
+// Server exports the batches received on the streams with the OTLP gRPC server of their signal.
+type Server struct {
+	maxDictionarySize  int
+	maxInFlightBatches int
+	admit              func(context.Context, int64) (func(), error)
+	traces             ptraceotlp.GRPCServer
+	metrics            pmetricotlp.GRPCServer
+	logs               plogotlp.GRPCServer
+}
+
+// streamServer is the handler type of the stream service.
+type streamServer interface {
+	stream(grpc.ServerStream) error
+}
+
+// NewServer returns a Server with the given settings.
+func NewServer(set ServerSettings) *Server {
+	s := &Server{
+		maxDictionarySize:  set.MaxDictionarySize,
+		maxInFlightBatches: max(set.MaxInFlightBatches, 1),
+		admit:              set.Admit,
+		traces:             set.Traces,
+		metrics:            set.Metrics,
+		logs:               set.Logs,
+	}
+	if s.admit == nil {
+		s.admit = func(context.Context, int64) (func(), error) { return func() {}, nil }
+	}
+	return s
+}
+
+// Register registers the stream service on the gRPC server.
+func (s *Server) Register(srv *grpc.Server) {
+	desc := streamDesc
+	desc.Handler = func(srv any, stream grpc.ServerStream) error {
+		return srv.(streamServer).stream(stream)
+	}
+	srv.RegisterService(&grpc.ServiceDesc{
+		ServiceName: serviceName,
+		HandlerType: (*streamServer)(nil),
+		Streams:     []grpc.StreamDesc{desc},
+	}, s)
+}
+
+func (s *Server) stream(stream grpc.ServerStream) error {
+	// The header is sent right away: the clients wait for it to know that the streams are supported.
+	header := metadata.Pairs(maxDictionarySizeHeader, strconv.Itoa(s.maxDictionarySize))
+	if err := stream.SendHeader(header); err != nil {
+		return err
+	}
+
+	dec := newDecoder(s.maxDictionarySize)
+	var (
+		wg     sync.WaitGroup
+		sendMu sync.Mutex
+	)
+	defer wg.Wait()
+	// inFlight holds a token per batch being exported.
+	inFlight := make(chan struct{}, s.maxInFlightBatches)
+	for {
+		select {
+		case inFlight <- struct{}{}:
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+		req := &request{}
+		if err := stream.RecvMsg(req); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		// The batches are decoded in order, the dictionaries being updated by each batch, and
+		// exported concurrently. The batches refused by the admission are decoded all the same,
+		// for the dictionaries to stay in sync with the client.
+		export, err := s.decode(dec, req)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		release, admitErr := s.admit(stream.Context(), req.size())
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-inFlight
+				wg.Done()
+			}()
+			var resp *response
+			if admitErr != nil {
+				resp = errorResponse(admitErr)
+			} else {
+				resp = export(stream.Context())
+				release()
+			}
+			resp.id = req.id
+			sendMu.Lock()
+			defer sendMu.Unlock()
+			// The client gets the error ending the stream if the response cannot be sent.
+			_ = stream.SendMsg(resp)
+		}()
+	}
+}
+
+// decode decodes the batch of the request, returning the function exporting it.
+func (s *Server) decode(dec *decoder, req *request) (func(context.Context) *response, error) {
+	switch req.signal {
+	case signalTraces:
+		td, err := dec.decodeTraces(req)
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context) *response {
+			if s.traces == nil {
+				return unimplementedResponse(req.signal)
+			}
+			resp, err := s.traces.Export(ctx, ptraceotlp.NewExportRequestFromTraces(td))
+			if err != nil {
+				return errorResponse(err)
+			}
+			return &response{rejected: resp.PartialSuccess().RejectedSpans(), message: resp.PartialSuccess().ErrorMessage()}
+		}, nil
+	case signalMetrics:
+		md, err := dec.decodeMetrics(req)
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context) *response {
+			if s.metrics == nil {
+				return unimplementedResponse(req.signal)
+			}
+			resp, err := s.metrics.Export(ctx, pmetricotlp.NewExportRequestFromMetrics(md))
+			if err != nil {
+				return errorResponse(err)
+			}
+			return &response{rejected: resp.PartialSuccess().RejectedDataPoints(), message: resp.PartialSuccess().ErrorMessage()}
+		}, nil
+	case signalLogs:
+		ld, err := dec.decodeLogs(req)
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context) *response {
+			if s.logs == nil {
+				return unimplementedResponse(req.signal)
+			}
+			resp, err := s.logs.Export(ctx, plogotlp.NewExportRequestFromLogs(ld))
+			if err != nil {
+				return errorResponse(err)
+			}
+			return &response{rejected: resp.PartialSuccess().RejectedLogRecords(), message: resp.PartialSuccess().ErrorMessage()}
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported %v", req.signal)
+}
+
+func errorResponse(err error) *response {
+	st := status.Convert(err)
+	return &response{code: uint32(st.Code()), message: st.Message()}
+}
+
+func unimplementedResponse(sig signal) *response {
+	return &response{code: uint32(codes.Unimplemented), message: fmt.Sprintf("%v are not supported by the server", sig)}
+}
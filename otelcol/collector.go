@@ -8,6 +8,7 @@ package otelcol // import "go.opentelemetry.io/collector/otelcol"
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"sync/atomic"
@@ -15,8 +16,10 @@ import (
 
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/exporter"
@@ -25,6 +28,7 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service"
+	"go.opentelemetry.io/collector/service/telemetry"
 )
 
 // State defines Collector's state.
@@ -109,6 +113,9 @@ type Collector struct {
 	signalsChannel chan os.Signal
 	// asyncErrorChannel is used to signal a fatal error from any component.
 	asyncErrorChannel chan error
+
+	// capture records the batches entering a pipeline of each service, see newCaptureSubCommand.
+	capture *pipelineCapture
 }
 
 // NewCollector creates and returns a new instance of Collector.
@@ -203,6 +210,12 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 		return err
 	}
 
+	if col.capture != nil {
+		if _, err = col.service.RecordPipeline(col.capture.pipelineID, col.capture.record); err != nil {
+			return multierr.Combine(err, col.service.Shutdown(ctx))
+		}
+	}
+
 	if !col.set.SkipSettingGRPCLogger {
 		grpclog.SetLogger(col.service.Logger(), cfg.Service.Telemetry.Logs.Level)
 	}
@@ -243,6 +256,47 @@ func (col *Collector) DryRun(ctx context.Context) error {
 	return cfg.Validate()
 }
 
+// WriteTopology writes the graph of the pipelines of the configuration, in the given format: "dot",
+// "mermaid" or "json". The components are created to report their capabilities, but not started.
+func (col *Collector) WriteTopology(ctx context.Context, w io.Writer, format string) error {
+	factories, err := col.set.Factories()
+	if err != nil {
+		return fmt.Errorf("failed to initialize factories: %w", err)
+	}
+	cfg, err := col.configProvider.Get(ctx, factories)
+	if err != nil {
+		return fmt.Errorf("failed to get config: %w", err)
+	}
+
+	if err = cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// The own telemetry of the collector is not needed to build the pipelines.
+	cfg.Service.Telemetry.Metrics.Level = configtelemetry.LevelNone
+	cfg.Service.Telemetry.Metrics.Pipeline = component.ID{}
+	cfg.Service.Telemetry.Traces = telemetry.TracesConfig{}
+	cfg.Service.Telemetry.Logs.Processors = nil
+	cfg.Service.Telemetry.Logs.Pipeline = component.ID{}
+
+	srv, err := service.New(ctx, service.Settings{
+		BuildInfo:         col.set.BuildInfo,
+		Receivers:         receiver.NewBuilder(cfg.Receivers, factories.Receivers),
+		Processors:        processor.NewBuilder(cfg.Processors, factories.Processors),
+		Exporters:         exporter.NewBuilder(cfg.Exporters, factories.Exporters),
+		Connectors:        connector.NewBuilder(cfg.Connectors, factories.Connectors),
+		Extensions:        extension.NewBuilder(cfg.Extensions, factories.Extensions),
+		AsyncErrorChannel: col.asyncErrorChannel,
+		LoggingOptions: []zap.Option{zap.WrapCore(func(zapcore.Core) zapcore.Core {
+			return zapcore.NewNopCore()
+		})},
+	}, cfg.Service)
+	if err != nil {
+		return err
+	}
+	return multierr.Append(srv.WriteTopology(w, format), srv.Shutdown(ctx))
+}
+
 // Run starts the collector according to the given configuration, and waits for it to complete.
 // Consecutive calls to Run are not allowed, Run shouldn't be called once a collector is shut down.
 // Sets up the control logic for config reloading and shutdown.
@@ -37,6 +37,9 @@ func NewCommand(set CollectorSettings) *cobra.Command {
 	}
 	rootCmd.AddCommand(newComponentsCommand(set))
 	rootCmd.AddCommand(newValidateSubCommand(set, flagSet))
+	rootCmd.AddCommand(newGraphSubCommand(set, flagSet))
+	rootCmd.AddCommand(newCaptureSubCommand(set, flagSet))
+	rootCmd.AddCommand(newReplaySubCommand(set, flagSet))
 	rootCmd.Flags().AddGoFlagSet(flagSet)
 	return rootCmd
 }
@@ -59,6 +62,7 @@ func updateSettingsUsingFlags(set *CollectorSettings, flags *flag.FlagSet) error
 		if len(resolverSet.ProviderFactories) == 0 && len(resolverSet.ConverterFactories) == 0 {
 			set.ConfigProviderSettings = newDefaultConfigProviderSettings(resolverSet.URIs)
 		}
+		setConfigMergeStrategy(flags, &set.ConfigProviderSettings.ResolverSettings)
 	}
 	return nil
 }
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/otelcol/internal/capture"
+)
+
+// pipelineCapture records the batches entering a pipeline.
+type pipelineCapture struct {
+	pipelineID component.ID
+	record     func(ctx context.Context, data any)
+}
+
+// newCaptureSubCommand constructs a new capture sub command using the given CollectorSettings.
+func newCaptureSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var pipeline, output string
+	var duration time.Duration
+	captureCmd := &cobra.Command{
+		Use:   "capture",
+		Short: "Runs the collector, capturing the batches entering a pipeline to a file",
+		Long: `Runs the collector, capturing the batches of traces, metrics or logs entering a pipeline to a
+file, with the time they entered the pipeline and the information about the client that sent them.
+The capture can be replayed into a pipeline with the replay command.
+
+The information about the clients includes the request metadata and the authentication data, which
+may contain credentials such as the authorization headers: the capture file is created readable only
+by its owner, and must be handled as a secret.`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			pipelineID, err := parsePipelineID(pipeline)
+			if err != nil {
+				return err
+			}
+			if output == "" {
+				return errors.New("output flag must be provided")
+			}
+			if err = updateSettingsUsingFlags(&set, flagSet); err != nil {
+				return err
+			}
+			col, err := NewCollector(set)
+			if err != nil {
+				return err
+			}
+
+			// The capture may contain credentials, it is only readable by its owner.
+			f, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+			if err != nil {
+				return err
+			}
+			start := time.Now()
+			w, err := capture.NewWriter(f, capture.Header{Pipeline: pipelineID.String(), Start: start})
+			if err != nil {
+				return multierr.Append(err, f.Close())
+			}
+			// The first write error is returned when the writer is closed.
+			col.capture = &pipelineCapture{
+				pipelineID: pipelineID,
+				record: func(ctx context.Context, data any) {
+					_ = w.Write(capture.Entry{Offset: time.Since(start), Client: client.FromContext(ctx), Data: data})
+				},
+			}
+
+			ctx := cmd.Context()
+			if duration > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, duration)
+				defer cancel()
+			}
+			err = col.Run(ctx)
+			return multierr.Combine(err, w.Close(), f.Close())
+		},
+	}
+	captureCmd.Flags().StringVar(&pipeline, "pipeline", "", "ID of the pipeline to capture, e.g. traces/in")
+	captureCmd.Flags().StringVar(&output, "output", "", "Path of the capture file")
+	captureCmd.Flags().DurationVar(&duration, "duration", 0, "Duration of the capture, the collector runs until stopped if 0")
+	captureCmd.Flags().AddGoFlagSet(flagSet)
+	return captureCmd
+}
+
+func parsePipelineID(pipeline string) (component.ID, error) {
+	var pipelineID component.ID
+	if pipeline == "" {
+		return pipelineID, errors.New("pipeline flag must be provided")
+	}
+	if err := pipelineID.UnmarshalText([]byte(pipeline)); err != nil {
+		return pipelineID, fmt.Errorf("invalid pipeline %q: %w", pipeline, err)
+	}
+	return pipelineID, nil
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/otelcol/internal/capture"
+)
+
+func TestCaptureSubCommandErrors(t *testing.T) {
+	tests := []struct {
+		args        []string
+		expectedErr string
+	}{
+		{args: []string{"--output", "capture.bin"}, expectedErr: "pipeline flag must be provided"},
+		{args: []string{"--pipeline", "traces/", "--output", "capture.bin"}, expectedErr: `invalid pipeline "traces/"`},
+		{args: []string{"--pipeline", "traces"}, expectedErr: "output flag must be provided"},
+		{args: []string{"--pipeline", "traces", "--output", "capture.bin"}, expectedErr: "at least one config flag must be provided"},
+	}
+	for _, tt := range tests {
+		cmd := newCaptureSubCommand(CollectorSettings{Factories: nopFactories}, flags(featuregate.GlobalRegistry()))
+		cmd.SetArgs(tt.args)
+		assert.ErrorContains(t, cmd.Execute(), tt.expectedErr)
+	}
+}
+
+func TestCaptureSubCommand(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "capture.bin")
+	cmd := newCaptureSubCommand(CollectorSettings{Factories: nopFactories}, flags(featuregate.GlobalRegistry()))
+	cmd.SetArgs([]string{"--config", filepath.Join("testdata", "otelcol-nometrics.yaml"),
+		"--pipeline", "metrics", "--output", output, "--duration", "100ms"})
+	require.NoError(t, cmd.Execute())
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(output)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+	f, err := os.Open(output)
+	require.NoError(t, err)
+	defer f.Close()
+	r, err := capture.NewReader(f)
+	require.NoError(t, err)
+	assert.Equal(t, "metrics", r.Header().Pipeline)
+	assert.False(t, r.Header().Start.IsZero())
+}
+
+func TestCaptureSubCommandUnknownPipeline(t *testing.T) {
+	cmd := newCaptureSubCommand(CollectorSettings{Factories: nopFactories}, flags(featuregate.GlobalRegistry()))
+	cmd.SetArgs([]string{"--config", filepath.Join("testdata", "otelcol-nometrics.yaml"),
+		"--pipeline", "traces", "--output", filepath.Join(t.TempDir(), "capture.bin")})
+	assert.EqualError(t, cmd.Execute(), `pipeline "traces" not found`)
+}
@@ -4,6 +4,7 @@
 package otelcol // import "go.opentelemetry.io/collector/otelcol"
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 
@@ -34,7 +35,8 @@ type componentsOutput struct {
 
 // newComponentsCommand constructs a new components command using the given CollectorSettings.
 func newComponentsCommand(set CollectorSettings) *cobra.Command {
-	return &cobra.Command{
+	var schema bool
+	componentsCmd := &cobra.Command{
 		Use:   "components",
 		Short: "Outputs available components in this collector distribution",
 		Long:  "Outputs available components in this collector distribution including their stability levels. The output format is not stable and can change between releases.",
@@ -46,6 +48,15 @@ func newComponentsCommand(set CollectorSettings) *cobra.Command {
 				return fmt.Errorf("failed to initialize factories: %w", err)
 			}
 
+			if schema {
+				jsonData, err := json.MarshalIndent(newConfigSchema(set.BuildInfo, factories), "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(jsonData))
+				return nil
+			}
+
 			components := componentsOutput{}
 			for _, con := range sortFactoriesByType[connector.Factory](factories.Connectors) {
 				components.Connectors = append(components.Connectors, componentWithStability{
@@ -112,6 +123,8 @@ func newComponentsCommand(set CollectorSettings) *cobra.Command {
 			return nil
 		},
 	}
+	componentsCmd.Flags().BoolVar(&schema, "schema", false, "Outputs the JSON Schema of the configuration accepted by this collector distribution instead.")
+	return componentsCmd
 }
 
 func sortFactoriesByType[T component.Factory](factories map[component.Type]T) []T {
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/otelcol/internal/configschema"
+	"go.opentelemetry.io/collector/otelcol/internal/templates"
+)
+
+// pipelineIDPattern matches the pipeline IDs accepted by service::pipelines.
+const pipelineIDPattern = `^(traces|metrics|logs|profiles)(/.+)?$`
+
+// newConfigSchema builds the JSON Schema of the whole configuration accepted by a collector
+// built with the given factories, including references from service::pipelines.
+func newConfigSchema(info component.BuildInfo, factories Factories) *configschema.Schema {
+	svc := configschema.Generate(defaultServiceConfig())
+	if extensions := svc.Properties["extensions"]; extensions != nil {
+		extensions.Items = idSchema(append(factoryTypes(factories.Extensions), templates.Type))
+	}
+	if pipelines := svc.Properties["pipelines"]; pipelines != nil {
+		pipelines.PropertyNames = &configschema.Schema{Pattern: pipelineIDPattern}
+		if pipeline, ok := pipelines.AdditionalProperties.(*configschema.Schema); ok {
+			// Connectors act as exporters in one pipeline and receivers in another.
+			receivers := append(factoryTypes(factories.Receivers), factoryTypes(factories.Connectors)...)
+			pipeline.Properties["receivers"].Items = idSchema(append(receivers, templates.Type))
+			pipeline.Properties["processors"].Items = idSchema(append(factoryTypes(factories.Processors), templates.Type))
+			exporters := append(factoryTypes(factories.Exporters), factoryTypes(factories.Connectors)...)
+			pipeline.Properties["exporters"].Items = idSchema(append(exporters, templates.Type))
+		}
+	}
+
+	return &configschema.Schema{
+		Schema:      configschema.Draft,
+		Title:       info.Description,
+		Description: info.Command + " " + info.Version,
+		Type:        "object",
+		Properties: map[string]*configschema.Schema{
+			"receivers":  componentsSchema(factories.Receivers),
+			"processors": componentsSchema(factories.Processors),
+			"exporters":  componentsSchema(factories.Exporters),
+			"connectors": componentsSchema(factories.Connectors),
+			"extensions": componentsSchema(factories.Extensions),
+			"service":    svc,
+			"templates": {
+				Type: "object",
+				// Templates are text/template documents, or YAML documents with quoted actions.
+				AdditionalProperties: &configschema.Schema{
+					AnyOf: []*configschema.Schema{{Type: "string"}, {Type: "object"}},
+				},
+			},
+		},
+		AdditionalProperties: false,
+	}
+}
+
+// componentsSchema returns the schema of a top-level component section, where each key
+// is a component ID whose type selects the schema of the default config of its factory.
+func componentsSchema[F component.Factory](factories map[component.Type]F) *configschema.Schema {
+	s := &configschema.Schema{
+		Type:                 "object",
+		PatternProperties:    map[string]*configschema.Schema{},
+		AdditionalProperties: false,
+	}
+	// Template instances accept any parameter.
+	s.PatternProperties["^"+templates.Type.String()+"/.+$"] = &configschema.Schema{}
+	for _, factory := range sortFactoriesByType(factories) {
+		s.PatternProperties[idPattern(factory.Type())] = &configschema.Schema{
+			AnyOf: []*configschema.Schema{
+				configschema.Generate(factory.CreateDefaultConfig()),
+				// A component can be declared without any setting to use its defaults.
+				{Type: "null"},
+			},
+		}
+	}
+	return s
+}
+
+// idSchema returns the schema of a component ID whose type is one of the given types.
+func idSchema(types []component.Type) *configschema.Schema {
+	if len(types) == 0 {
+		return &configschema.Schema{Type: "string", Pattern: configschema.IDPattern}
+	}
+	seen := make(map[component.Type]bool, len(types))
+	quoted := make([]string, 0, len(types))
+	for _, typ := range types {
+		if seen[typ] {
+			continue
+		}
+		seen[typ] = true
+		quoted = append(quoted, regexp.QuoteMeta(typ.String()))
+	}
+	return &configschema.Schema{Type: "string", Pattern: "^(" + strings.Join(quoted, "|") + ")(/.+)?$"}
+}
+
+func factoryTypes[F component.Factory](factories map[component.Type]F) []component.Type {
+	types := make([]component.Type, 0, len(factories))
+	for _, factory := range sortFactoriesByType(factories) {
+		types = append(types, factory.Type())
+	}
+	return types
+}
+
+func idPattern(typ component.Type) string {
+	return "^" + regexp.QuoteMeta(typ.String()) + "(/.+)?$"
+}
@@ -5,6 +5,7 @@ package otelcol
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
 
 	"go.opentelemetry.io/collector/component"
 )
@@ -40,3 +42,40 @@ func TestNewBuildSubCommand(t *testing.T) {
 	// line that makes the test fail.
 	assert.Equal(t, strings.ReplaceAll(strings.ReplaceAll(string(ExpectedOutput), "\n", ""), "\r", ""), strings.ReplaceAll(strings.ReplaceAll(b.String(), "\n", ""), "\r", ""))
 }
+
+func TestComponentsSchemaSubCommand(t *testing.T) {
+	set := CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: nopFactories,
+	}
+	cmd := NewCommand(set)
+	cmd.SetArgs([]string{"components", "--schema"})
+
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.NoError(t, cmd.Execute())
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(b.Bytes(), &schema))
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+
+	properties := schema["properties"].(map[string]any)
+	assert.ElementsMatch(t, []string{"receivers", "processors", "exporters", "connectors", "extensions", "service", "templates"}, maps.Keys(properties))
+
+	receivers := properties["receivers"].(map[string]any)["patternProperties"].(map[string]any)
+	assert.ElementsMatch(t, []string{"^nop(/.+)?$", "^nop_logs(/.+)?$", "^template/.+$"}, maps.Keys(receivers))
+
+	service := properties["service"].(map[string]any)["properties"].(map[string]any)
+	extensions := service["extensions"].(map[string]any)["items"].(map[string]any)
+	assert.Equal(t, "^(nop|template)(/.+)?$", extensions["pattern"])
+
+	pipelines := service["pipelines"].(map[string]any)
+	assert.Equal(t, pipelineIDPattern, pipelines["propertyNames"].(map[string]any)["pattern"])
+	pipeline := pipelines["additionalProperties"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, "^(nop|nop_logs|template)(/.+)?$", pipeline["receivers"].(map[string]any)["items"].(map[string]any)["pattern"])
+	assert.Equal(t, "^(nop|template)(/.+)?$", pipeline["processors"].(map[string]any)["items"].(map[string]any)["pattern"])
+	assert.Equal(t, "^(nop|template)(/.+)?$", pipeline["exporters"].(map[string]any)["items"].(map[string]any)["pattern"])
+
+	logs := service["telemetry"].(map[string]any)["properties"].(map[string]any)["logs"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, "console", logs["encoding"].(map[string]any)["default"])
+}
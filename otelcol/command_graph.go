@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"flag"
+
+	"github.com/spf13/cobra"
+)
+
+// newGraphSubCommand constructs a new graph sub command using the given CollectorSettings.
+func newGraphSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var format string
+	graphCmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Outputs the graph of the pipelines of the config as DOT, Mermaid or JSON",
+		Long: `Outputs the graph of the pipelines of the config, without running the collector.
+The graph includes the receivers, processors, exporters and the connectors linking the pipelines,
+annotated with whether they mutate data.`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := updateSettingsUsingFlags(&set, flagSet); err != nil {
+				return err
+			}
+			col, err := NewCollector(set)
+			if err != nil {
+				return err
+			}
+			return col.WriteTopology(cmd.Context(), cmd.OutOrStdout(), format)
+		},
+	}
+	graphCmd.Flags().StringVar(&format, "format", "dot", "Output format of the graph: dot, mermaid or json")
+	graphCmd.Flags().AddGoFlagSet(flagSet)
+	return graphCmd
+}
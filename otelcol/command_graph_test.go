@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func TestGraphSubCommandNoConfig(t *testing.T) {
+	cmd := newGraphSubCommand(CollectorSettings{Factories: nopFactories}, flags(featuregate.GlobalRegistry()))
+	err := cmd.Execute()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "at least one config flag must be provided")
+}
+
+func TestGraphSubCommand(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{format: "dot", expected: `"fanout:traces" -> "connector:traces:logs:nop/con" [style=dashed];`},
+		{format: "mermaid", expected: "flowchart LR\n"},
+		{format: "json", expected: `"id": "connector:traces:logs:nop/con"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			cmd := newGraphSubCommand(CollectorSettings{Factories: nopFactories}, flags(featuregate.GlobalRegistry()))
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetArgs([]string{"--config", filepath.Join("testdata", "otelcol-nop.yaml"), "--format", tt.format})
+			require.NoError(t, cmd.Execute())
+			assert.Contains(t, out.String(), tt.expected)
+		})
+	}
+}
+
+func TestGraphSubCommandJSON(t *testing.T) {
+	cmd := newGraphSubCommand(CollectorSettings{Factories: nopFactories}, flags(featuregate.GlobalRegistry()))
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--config", filepath.Join("testdata", "otelcol-nop.yaml"), "--format", "json"})
+	require.NoError(t, cmd.Execute())
+
+	var topology struct {
+		Pipelines []struct {
+			ID string `json:"id"`
+		} `json:"pipelines"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &topology))
+	require.Len(t, topology.Pipelines, 3)
+	assert.Equal(t, "logs", topology.Pipelines[0].ID)
+}
+
+func TestGraphSubCommandInvalidFormat(t *testing.T) {
+	cmd := newGraphSubCommand(CollectorSettings{Factories: nopFactories}, flags(featuregate.GlobalRegistry()))
+	cmd.SetArgs([]string{"--config", filepath.Join("testdata", "otelcol-nop.yaml"), "--format", "svg"})
+	assert.ErrorContains(t, cmd.Execute(), `unsupported topology format "svg"`)
+}
@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/otelcol/internal/capture"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// newReplaySubCommand constructs a new replay sub command using the given CollectorSettings.
+func newReplaySubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.Command {
+	var pipeline, input string
+	var speed float64
+	replayCmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Runs the collector, replaying a capture into a pipeline",
+		Long: `Runs the collector, passing the batches of a file written by the capture command to a pipeline
+as if they were received by its receivers, with the information about the client that sent them.
+The batches are replayed with their original timing, accelerated by the speed factor, and the
+collector shuts down once the capture is replayed.`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if input == "" {
+				return errors.New("input flag must be provided")
+			}
+			if speed < 0 {
+				return errors.New("speed must not be negative")
+			}
+			f, err := os.Open(input)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r, err := capture.NewReader(f)
+			if err != nil {
+				return err
+			}
+			if pipeline == "" {
+				pipeline = r.Header().Pipeline
+			}
+			pipelineID, err := parsePipelineID(pipeline)
+			if err != nil {
+				return err
+			}
+
+			if err = updateSettingsUsingFlags(&set, flagSet); err != nil {
+				return err
+			}
+			col, err := NewCollector(set)
+			if err != nil {
+				return err
+			}
+			return col.replay(cmd.Context(), r, pipelineID, speed)
+		},
+	}
+	replayCmd.Flags().StringVar(&pipeline, "pipeline", "", "ID of the pipeline to replay the capture into, defaults to the captured pipeline")
+	replayCmd.Flags().StringVar(&input, "input", "", "Path of the capture file")
+	replayCmd.Flags().Float64Var(&speed, "speed", 1, "Speed factor of the replay, the batches are replayed as fast as possible if 0")
+	replayCmd.Flags().AddGoFlagSet(flagSet)
+	return replayCmd
+}
+
+// replay starts the collector, passes the entries of the capture to the pipeline, and shuts down
+// the collector. The entries refused by the pipeline are logged and not retried. As with Run, the
+// replay is interrupted by SIGINT and SIGTERM, an asynchronous error of a component, or Shutdown;
+// the configuration is not reloaded.
+func (col *Collector) replay(ctx context.Context, r *capture.Reader, pipelineID component.ID, speed float64) error {
+	if err := col.setupConfigurationComponents(ctx); err != nil {
+		col.setCollectorState(StateClosed)
+		return err
+	}
+
+	// Only notify with SIGTERM and SIGINT if graceful shutdown is enabled.
+	if !col.set.DisableGracefulShutdown {
+		signal.Notify(col.signalsChannel, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(col.signalsChannel)
+	}
+
+	replayCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	replayed := make(chan error, 1)
+	go func() {
+		replayed <- col.replayEntries(replayCtx, r, pipelineID, speed)
+	}()
+
+	var err error
+	select {
+	case err = <-replayed:
+		// The passed in context may have been canceled.
+		return multierr.Append(err, col.shutdown(context.Background()))
+	case err = <-col.asyncErrorChannel:
+		col.service.Logger().Error("Asynchronous error received, terminating process", zap.Error(err))
+	case s := <-col.signalsChannel:
+		col.service.Logger().Info("Received signal from OS", zap.String("signal", s.String()))
+	case <-col.shutdownChan:
+		col.service.Logger().Info("Received shutdown request")
+	case <-ctx.Done():
+		col.service.Logger().Info("Context done, terminating process", zap.Error(ctx.Err()))
+	}
+	// The replay is interrupted before shutting down the pipelines it passes the entries to.
+	cancel()
+	<-replayed
+	// The passed in context may have been canceled.
+	return col.shutdown(context.Background())
+}
+
+func (col *Collector) replayEntries(ctx context.Context, r *capture.Reader, pipelineID component.ID, speed float64) error {
+	if _, ok := col.serviceConfig.Pipelines[pipelineID]; !ok {
+		return fmt.Errorf("pipeline %q not found", pipelineID)
+	}
+	logger := col.service.Logger()
+	logger.Info("Replaying the capture", zap.String("pipeline", pipelineID.String()), zap.Float64("speed", speed))
+
+	var start time.Time
+	var first time.Duration
+	var replayed, refused int
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		e, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if dataType := entryDataType(e); dataType != pipelineID.Type() {
+			return fmt.Errorf("capture of %s cannot be replayed into pipeline %q", dataType, pipelineID)
+		}
+
+		// The entries are replayed relative to the first one.
+		if start.IsZero() {
+			start, first = time.Now(), e.Offset
+		} else if speed > 0 {
+			delay := time.Until(start.Add(time.Duration(float64(e.Offset-first) / speed)))
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if err = col.service.ConsumePipeline(client.NewContext(ctx, e.Client), pipelineID, e.Data); err != nil {
+			logger.Warn("Batch refused by the pipeline", zap.Duration("offset", e.Offset), zap.Error(err))
+			refused++
+		}
+		replayed++
+	}
+	logger.Info("Capture replayed", zap.Int("batches", replayed), zap.Int("refused", refused))
+	return nil
+}
+
+func entryDataType(e capture.Entry) component.DataType {
+	switch e.Data.(type) {
+	case ptrace.Traces:
+		return component.DataTypeTraces
+	case pmetric.Metrics:
+		return component.DataTypeMetrics
+	case plog.Logs:
+		return component.DataTypeLogs
+	}
+	return component.Type{}
+}
@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/otelcol/internal/capture"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func writeCapture(t *testing.T, pipeline string, entries ...capture.Entry) string {
+	path := filepath.Join(t.TempDir(), "capture.bin")
+	buf := &bytes.Buffer{}
+	w, err := capture.NewWriter(buf, capture.Header{Pipeline: pipeline, Start: time.Now()})
+	require.NoError(t, err)
+	for _, e := range entries {
+		require.NoError(t, w.Write(e))
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+	return path
+}
+
+func TestReplaySubCommandErrors(t *testing.T) {
+	metrics := writeCapture(t, "metrics", capture.Entry{Data: testdata.GenerateMetrics(1)})
+	config := filepath.Join("testdata", "otelcol-nometrics.yaml")
+	tests := []struct {
+		args        []string
+		expectedErr string
+	}{
+		{args: []string{"--config", config}, expectedErr: "input flag must be provided"},
+		{args: []string{"--config", config, "--input", metrics, "--speed", "-1"}, expectedErr: "speed must not be negative"},
+		{args: []string{"--config", config, "--input", filepath.Join(t.TempDir(), "missing.bin")}, expectedErr: "no such file or directory"},
+		{args: []string{"--config", config, "--input", metrics, "--pipeline", "metrics/"}, expectedErr: `invalid pipeline "metrics/"`},
+		{args: []string{"--input", metrics}, expectedErr: "at least one config flag must be provided"},
+		{args: []string{"--config", config, "--input", metrics, "--pipeline", "metrics/other"}, expectedErr: `pipeline "metrics/other" not found`},
+		{args: []string{"--config", config, "--input", writeCapture(t, "metrics", capture.Entry{Data: testdata.GenerateLogs(1)})},
+			expectedErr: `capture of logs cannot be replayed into pipeline "metrics"`},
+	}
+	for _, tt := range tests {
+		cmd := newReplaySubCommand(CollectorSettings{Factories: nopFactories}, flags(featuregate.GlobalRegistry()))
+		cmd.SetArgs(tt.args)
+		assert.ErrorContains(t, cmd.Execute(), tt.expectedErr)
+	}
+}
+
+func TestReplaySubCommand(t *testing.T) {
+	input := writeCapture(t, "metrics",
+		capture.Entry{Data: testdata.GenerateMetrics(1)},
+		capture.Entry{Offset: time.Millisecond, Data: testdata.GenerateMetrics(2)})
+	cmd := newReplaySubCommand(CollectorSettings{Factories: nopFactories}, flags(featuregate.GlobalRegistry()))
+	cmd.SetArgs([]string{"--config", filepath.Join("testdata", "otelcol-nometrics.yaml"), "--input", input})
+	require.NoError(t, cmd.Execute())
+}
+
+func TestCollectorCaptureReplay(t *testing.T) {
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo:              component.NewDefaultBuildInfo(),
+		Factories:              nopFactories,
+		ConfigProviderSettings: newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nometrics.yaml")}),
+	})
+	require.NoError(t, err)
+
+	// The replayed entries enter the pipeline, where they are captured.
+	var infos []client.Info
+	var recorded []any
+	col.capture = &pipelineCapture{
+		pipelineID: component.MustNewID("metrics"),
+		record: func(ctx context.Context, data any) {
+			infos = append(infos, client.FromContext(ctx))
+			recorded = append(recorded, data)
+		},
+	}
+	info := client.Info{Metadata: client.NewMetadata(map[string][]string{"tenant": {"acme"}})}
+	buf := &bytes.Buffer{}
+	w, err := capture.NewWriter(buf, capture.Header{Pipeline: "metrics"})
+	require.NoError(t, err)
+	require.NoError(t, w.Write(capture.Entry{Offset: time.Second, Client: info, Data: testdata.GenerateMetrics(1)}))
+	require.NoError(t, w.Write(capture.Entry{Offset: 2 * time.Second, Data: testdata.GenerateMetrics(2)}))
+	require.NoError(t, w.Close())
+	r, err := capture.NewReader(buf)
+	require.NoError(t, err)
+
+	// The second entry is replayed a second after the first one, 100 times faster.
+	start := time.Now()
+	require.NoError(t, col.replay(context.Background(), r, component.MustNewID("metrics"), 100))
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+	assert.Equal(t, StateClosed, col.GetState())
+	assert.Equal(t, []any{testdata.GenerateMetrics(1), testdata.GenerateMetrics(2)}, recorded)
+	require.Len(t, infos, 2)
+	assert.Equal(t, []string{"acme"}, infos[0].Metadata.Get("tenant"))
+	assert.Empty(t, infos[1].Metadata.Keys())
+}
+
+func TestCollectorReplayInterrupted(t *testing.T) {
+	tests := []struct {
+		name      string
+		interrupt func(col *Collector)
+	}{
+		{name: "signal", interrupt: func(col *Collector) { col.signalsChannel <- syscall.SIGTERM }},
+		{name: "async_error", interrupt: func(col *Collector) { col.asyncErrorChannel <- errors.New("fatal") }},
+		{name: "shutdown", interrupt: func(col *Collector) { col.Shutdown() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			col, err := NewCollector(CollectorSettings{
+				BuildInfo:              component.NewDefaultBuildInfo(),
+				Factories:              nopFactories,
+				ConfigProviderSettings: newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nometrics.yaml")}),
+			})
+			require.NoError(t, err)
+			var mu sync.Mutex
+			var recorded []any
+			col.capture = &pipelineCapture{
+				pipelineID: component.MustNewID("metrics"),
+				record: func(_ context.Context, data any) {
+					mu.Lock()
+					defer mu.Unlock()
+					recorded = append(recorded, data)
+				},
+			}
+			buf := &bytes.Buffer{}
+			w, err := capture.NewWriter(buf, capture.Header{Pipeline: "metrics"})
+			require.NoError(t, err)
+			require.NoError(t, w.Write(capture.Entry{Data: testdata.GenerateMetrics(1)}))
+			require.NoError(t, w.Write(capture.Entry{Offset: time.Hour, Data: testdata.GenerateMetrics(2)}))
+			require.NoError(t, w.Close())
+			r, err := capture.NewReader(buf)
+			require.NoError(t, err)
+
+			// The replay waiting for the second entry is interrupted, and the collector shut down.
+			replayed := make(chan error, 1)
+			go func() {
+				replayed <- col.replay(context.Background(), r, component.MustNewID("metrics"), 1)
+			}()
+			assert.Eventually(t, func() bool {
+				mu.Lock()
+				defer mu.Unlock()
+				return len(recorded) == 1
+			}, 2*time.Second, 10*time.Millisecond)
+			tt.interrupt(col)
+			select {
+			case err = <-replayed:
+				require.NoError(t, err)
+			case <-time.After(5 * time.Second):
+				require.Fail(t, "replay not interrupted")
+			}
+			assert.Equal(t, StateClosed, col.GetState())
+		})
+	}
+}
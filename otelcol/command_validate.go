@@ -25,7 +25,9 @@ func newValidateSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cobra.
 					return errors.New("at least one config flag must be provided")
 				}
 
-				set.ConfigProvider, err = NewConfigProvider(newDefaultConfigProviderSettings(configFlags))
+				providerSet := newDefaultConfigProviderSettings(configFlags)
+				setConfigMergeStrategy(flagSet, &providerSet.ResolverSettings)
+				set.ConfigProvider, err = NewConfigProvider(providerSet)
 				if err != nil {
 					return err
 				}
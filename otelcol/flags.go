@@ -8,16 +8,28 @@ import (
 	"flag"
 	"strings"
 
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/featuregate"
 )
 
 const (
-	configFlag = "config"
+	configFlag              = "config"
+	configMergeStrategyFlag = "config-merge-strategy"
 )
 
+// componentListPaths are the lists of components merged with the strategy set with the
+// config-merge-strategy flag.
+var componentListPaths = []string{
+	"service::extensions",
+	"service::pipelines::*::receivers",
+	"service::pipelines::*::processors",
+	"service::pipelines::*::exporters",
+}
+
 type configFlagValue struct {
-	values []string
-	sets   []string
+	values        []string
+	sets          []string
+	mergeStrategy confmap.MergeStrategy
 }
 
 func (s *configFlagValue) Set(val string) error {
@@ -49,6 +61,14 @@ func flags(reg *featuregate.Registry) *flag.FlagSet {
 			return nil
 		})
 
+	flagSet.Func(configMergeStrategyFlag,
+		"Strategy used to merge the lists of components of the service when several config locations are set:"+
+			" replace (default), append, prepend or unique-append. The lists set with --set always replace the merged lists."+
+			" Example --config-merge-strategy=unique-append",
+		func(s string) error {
+			return cfgs.mergeStrategy.UnmarshalText([]byte(s))
+		})
+
 	reg.RegisterFlags(flagSet)
 	return flagSet
 }
@@ -57,3 +77,15 @@ func getConfigFlag(flagSet *flag.FlagSet) []string {
 	cfv := flagSet.Lookup(configFlag).Value.(*configFlagValue)
 	return append(cfv.values, cfv.sets...)
 }
+
+// setConfigMergeStrategy applies the strategy set with the config-merge-strategy flag, if any, to
+// the config locations. The lists set with the set flag replace the merged lists.
+func setConfigMergeStrategy(flagSet *flag.FlagSet, set *confmap.ResolverSettings) {
+	cfv := flagSet.Lookup(configFlag).Value.(*configFlagValue)
+	if cfv.mergeStrategy == "" {
+		return
+	}
+	set.MergeStrategy = cfv.mergeStrategy
+	set.MergePaths = componentListPaths
+	set.MergeStrategyURIs = len(cfv.values)
+}
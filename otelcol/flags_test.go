@@ -4,11 +4,13 @@
 package otelcol
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/featuregate"
 )
 
@@ -69,3 +71,45 @@ func TestSetFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigMergeStrategyFlag(t *testing.T) {
+	flgs := flags(featuregate.NewRegistry())
+	require.NoError(t, flgs.Parse([]string{"--config=file:testdata/otelcol-nop.yaml", "--config-merge-strategy=unique-append"}))
+
+	set := confmap.ResolverSettings{}
+	setConfigMergeStrategy(flgs, &set)
+	assert.Equal(t, confmap.MergeStrategyUniqueAppend, set.MergeStrategy)
+	assert.Equal(t, componentListPaths, set.MergePaths)
+}
+
+func TestConfigMergeStrategyFlagWithSet(t *testing.T) {
+	flgs := flags(featuregate.NewRegistry())
+	require.NoError(t, flgs.Parse([]string{"--config=file:testdata/otelcol-nop.yaml", "--config=yaml:service::extensions: [nop/2]",
+		"--config-merge-strategy=unique-append", "--set=service.extensions=[nop/3]"}))
+
+	set := newDefaultConfigProviderSettings(getConfigFlag(flgs))
+	setConfigMergeStrategy(flgs, &set.ResolverSettings)
+	assert.Equal(t, 2, set.ResolverSettings.MergeStrategyURIs)
+
+	// The extensions of the config locations are merged, and replaced by the set flag.
+	resolver, err := confmap.NewResolver(set.ResolverSettings)
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []any{"nop/3"}, conf.Get("service::extensions"))
+}
+
+func TestConfigMergeStrategyFlagDefault(t *testing.T) {
+	flgs := flags(featuregate.NewRegistry())
+	require.NoError(t, flgs.Parse([]string{"--config=file:testdata/otelcol-nop.yaml"}))
+
+	set := confmap.ResolverSettings{}
+	setConfigMergeStrategy(flgs, &set)
+	assert.Equal(t, confmap.ResolverSettings{}, set)
+}
+
+func TestConfigMergeStrategyFlagInvalid(t *testing.T) {
+	flgs := flags(featuregate.NewRegistry())
+	err := flgs.Parse([]string{"--config-merge-strategy=merge"})
+	assert.EqualError(t, err, `invalid value "merge" for flag -config-merge-strategy: unsupported merge strategy "merge", expected one of "replace", "append", "prepend" or "unique-append"`)
+}
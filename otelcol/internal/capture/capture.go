@@ -0,0 +1,290 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package capture reads and writes the captures of the batches entering a pipeline. A capture
+// starts with a JSON header on its own line, followed by an entry per batch. An entry is the JSON
+// encoded time and client information of the batch, followed by the OTLP/protobuf encoded batch,
+// each preceded by its big-endian uint32 size.
+package capture // import "go.opentelemetry.io/collector/otelcol/internal/capture"
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// maxSize is the maximum size of the parts of the entries read.
+const maxSize = 1 << 30
+
+const (
+	signalTraces  = "traces"
+	signalMetrics = "metrics"
+	signalLogs    = "logs"
+)
+
+var (
+	tracesMarshaler    = &ptrace.ProtoMarshaler{}
+	tracesUnmarshaler  = &ptrace.ProtoUnmarshaler{}
+	metricsMarshaler   = &pmetric.ProtoMarshaler{}
+	metricsUnmarshaler = &pmetric.ProtoUnmarshaler{}
+	logsMarshaler      = &plog.ProtoMarshaler{}
+	logsUnmarshaler    = &plog.ProtoUnmarshaler{}
+)
+
+// Header describes a capture.
+type Header struct {
+	// Pipeline is the ID of the captured pipeline.
+	Pipeline string `json:"pipeline"`
+	// Start is the time the capture started.
+	Start time.Time `json:"start"`
+}
+
+// Entry is a batch entering the captured pipeline.
+type Entry struct {
+	// Offset is the time the batch entered the pipeline, relative to the start of the capture.
+	Offset time.Duration
+	// Client is the information about the client that sent the batch. The values of the
+	// authentication attributes are read back as decoded from JSON.
+	Client client.Info
+	// Data is the batch, a ptrace.Traces, pmetric.Metrics or plog.Logs.
+	Data any
+}
+
+// entryInfo is the JSON encoded part of an entry.
+type entryInfo struct {
+	Offset   time.Duration       `json:"offset"`
+	Signal   string              `json:"signal"`
+	Addr     *addr               `json:"addr,omitempty"`
+	Metadata map[string][]string `json:"metadata,omitempty"`
+	Auth     map[string]any      `json:"auth,omitempty"`
+}
+
+// Writer writes the entries of a capture. It is safe for concurrent use.
+type Writer struct {
+	mu  sync.Mutex
+	w   *bufio.Writer
+	err error
+}
+
+// NewWriter writes the header of the capture and returns a Writer of its entries.
+func NewWriter(w io.Writer, header Header) (*Writer, error) {
+	cw := &Writer{w: bufio.NewWriter(w)}
+	data, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = cw.w.Write(append(data, '\n')); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+// Write writes the entry. Once a write fails, the next writes return the same error.
+func (cw *Writer) Write(e Entry) error {
+	info := entryInfo{
+		Offset:   e.Offset,
+		Metadata: make(map[string][]string),
+	}
+	var payload []byte
+	var err error
+	switch data := e.Data.(type) {
+	case ptrace.Traces:
+		info.Signal = signalTraces
+		payload, err = tracesMarshaler.MarshalTraces(data)
+	case pmetric.Metrics:
+		info.Signal = signalMetrics
+		payload, err = metricsMarshaler.MarshalMetrics(data)
+	case plog.Logs:
+		info.Signal = signalLogs
+		payload, err = logsMarshaler.MarshalLogs(data)
+	default:
+		err = fmt.Errorf("unsupported data %T", e.Data)
+	}
+	if err != nil {
+		return err
+	}
+	if e.Client.Addr != nil {
+		info.Addr = &addr{Net: e.Client.Addr.Network(), Address: e.Client.Addr.String()}
+	}
+	for _, key := range e.Client.Metadata.Keys() {
+		info.Metadata[key] = e.Client.Metadata.Get(key)
+	}
+	if e.Client.Auth != nil {
+		info.Auth = make(map[string]any)
+		for _, name := range e.Client.Auth.GetAttributeNames() {
+			value := e.Client.Auth.GetAttribute(name)
+			// The values that cannot be encoded in JSON are captured as their string representation.
+			if _, jerr := json.Marshal(value); jerr != nil {
+				value = fmt.Sprint(value)
+			}
+			info.Auth[name] = value
+		}
+	}
+	infoData, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	if cw.err != nil {
+		return cw.err
+	}
+	if cw.err = writePart(cw.w, infoData); cw.err == nil {
+		cw.err = writePart(cw.w, payload)
+	}
+	return cw.err
+}
+
+// Close writes the buffered entries, without closing the underlying writer.
+func (cw *Writer) Close() error {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	if cw.err == nil {
+		cw.err = cw.w.Flush()
+	}
+	return cw.err
+}
+
+func writePart(w io.Writer, data []byte) error {
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(data)))
+	if _, err := w.Write(size[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// Reader reads the entries of a capture.
+type Reader struct {
+	r      *bufio.Reader
+	header Header
+}
+
+// NewReader reads the header of the capture and returns a Reader of its entries.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	line, err := br.ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the capture header: %w", err)
+	}
+	cr := &Reader{r: br}
+	if err = json.Unmarshal(line, &cr.header); err != nil {
+		return nil, fmt.Errorf("invalid capture header: %w", err)
+	}
+	return cr, nil
+}
+
+// Header returns the header of the capture.
+func (cr *Reader) Header() Header {
+	return cr.header
+}
+
+// Read reads the next entry. It returns io.EOF at the end of the capture, and io.ErrUnexpectedEOF
+// if the last entry is truncated.
+func (cr *Reader) Read() (Entry, error) {
+	infoData, err := readPart(cr.r)
+	if err != nil {
+		return Entry{}, err
+	}
+	payload, err := readPart(cr.r)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return Entry{}, io.ErrUnexpectedEOF
+		}
+		return Entry{}, err
+	}
+
+	var info entryInfo
+	if err = json.Unmarshal(infoData, &info); err != nil {
+		return Entry{}, fmt.Errorf("invalid capture entry: %w", err)
+	}
+	e := Entry{
+		Offset: info.Offset,
+		Client: client.Info{Metadata: client.NewMetadata(info.Metadata)},
+	}
+	if info.Addr != nil {
+		e.Client.Addr = info.Addr
+	}
+	if info.Auth != nil {
+		e.Client.Auth = authData(info.Auth)
+	}
+	switch info.Signal {
+	case signalTraces:
+		e.Data, err = tracesUnmarshaler.UnmarshalTraces(payload)
+	case signalMetrics:
+		e.Data, err = metricsUnmarshaler.UnmarshalMetrics(payload)
+	case signalLogs:
+		e.Data, err = logsUnmarshaler.UnmarshalLogs(payload)
+	default:
+		return Entry{}, fmt.Errorf("unknown signal %q", info.Signal)
+	}
+	if err != nil {
+		return Entry{}, fmt.Errorf("invalid capture entry: %w", err)
+	}
+	return e, nil
+}
+
+func readPart(r io.Reader) ([]byte, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n > maxSize {
+		return nil, fmt.Errorf("capture entry of %d bytes exceeds the maximum of %d bytes", n, maxSize)
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+var _ net.Addr = (*addr)(nil)
+
+// addr is the captured address of a client.
+type addr struct {
+	Net     string `json:"network"`
+	Address string `json:"address"`
+}
+
+func (a *addr) Network() string {
+	return a.Net
+}
+
+func (a *addr) String() string {
+	return a.Address
+}
+
+var _ client.AuthData = authData(nil)
+
+// authData is the captured authentication data of a client.
+type authData map[string]any
+
+func (a authData) GetAttribute(name string) any {
+	return a[name]
+}
+
+func (a authData) GetAttributeNames() []string {
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	return names
+}
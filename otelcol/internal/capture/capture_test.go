@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package capture
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+type testAuthData struct{}
+
+func (testAuthData) GetAttribute(name string) any {
+	switch name {
+	case "subject":
+		return "user"
+	case "channel":
+		return make(chan int)
+	}
+	return nil
+}
+
+func (testAuthData) GetAttributeNames() []string {
+	return []string{"subject", "channel"}
+}
+
+func TestWriteRead(t *testing.T) {
+	header := Header{Pipeline: "traces/in", Start: time.Date(2024, 5, 2, 15, 4, 5, 0, time.UTC)}
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(buf, header)
+	require.NoError(t, err)
+
+	info := client.Info{
+		Addr:     &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 4317},
+		Auth:     testAuthData{},
+		Metadata: client.NewMetadata(map[string][]string{"Tenant": {"acme"}}),
+	}
+	require.NoError(t, w.Write(Entry{Offset: time.Second, Client: info, Data: testdata.GenerateTraces(2)}))
+	require.NoError(t, w.Write(Entry{Offset: 2 * time.Second, Data: testdata.GenerateMetrics(3)}))
+	require.NoError(t, w.Write(Entry{Offset: 3 * time.Second, Data: testdata.GenerateLogs(4)}))
+	assert.EqualError(t, w.Write(Entry{Data: "data"}), "unsupported data string")
+	require.NoError(t, w.Close())
+
+	r, err := NewReader(buf)
+	require.NoError(t, err)
+	assert.Equal(t, header, r.Header())
+
+	e, err := r.Read()
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, e.Offset)
+	assert.Equal(t, testdata.GenerateTraces(2), e.Data)
+	assert.Equal(t, "tcp", e.Client.Addr.Network())
+	assert.Equal(t, "127.0.0.1:4317", e.Client.Addr.String())
+	assert.Equal(t, []string{"acme"}, e.Client.Metadata.Get("tenant"))
+	assert.Equal(t, "user", e.Client.Auth.GetAttribute("subject"))
+	assert.IsType(t, "", e.Client.Auth.GetAttribute("channel"))
+	assert.ElementsMatch(t, []string{"subject", "channel"}, e.Client.Auth.GetAttributeNames())
+
+	e, err = r.Read()
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, e.Offset)
+	assert.Equal(t, testdata.GenerateMetrics(3), e.Data)
+	assert.Nil(t, e.Client.Addr)
+	assert.Nil(t, e.Client.Auth)
+
+	e, err = r.Read()
+	require.NoError(t, err)
+	assert.Equal(t, testdata.GenerateLogs(4), e.Data)
+
+	_, err = r.Read()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestReadErrors(t *testing.T) {
+	_, err := NewReader(bytes.NewReader(nil))
+	assert.ErrorContains(t, err, "failed to read the capture header")
+	_, err = NewReader(bytes.NewBufferString("not json\n"))
+	assert.ErrorContains(t, err, "invalid capture header")
+
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(buf, Header{Pipeline: "logs"})
+	require.NoError(t, err)
+	require.NoError(t, w.Write(Entry{Data: testdata.GenerateLogs(1)}))
+	require.NoError(t, w.Close())
+	r, err := NewReader(bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+	require.NoError(t, err)
+	_, err = r.Read()
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	r, err = NewReader(bytes.NewBufferString("{}\n\x00\x00\x00\x02{}\x00\x00\x00\x00"))
+	require.NoError(t, err)
+	_, err = r.Read()
+	assert.EqualError(t, err, `unknown signal ""`)
+
+	r, err = NewReader(bytes.NewBufferString("{}\n\xff\xff\xff\xff"))
+	require.NoError(t, err)
+	_, err = r.Read()
+	assert.EqualError(t, err, "capture entry of 4294967295 bytes exceeds the maximum of 1073741824 bytes")
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestWriteError(t *testing.T) {
+	// The header is buffered until the first flush.
+	w, err := NewWriter(errWriter{}, Header{Pipeline: "logs"})
+	require.NoError(t, err)
+	assert.EqualError(t, w.Close(), "write failed")
+	assert.EqualError(t, w.Write(Entry{Data: testdata.GenerateLogs(1)}), "write failed")
+}
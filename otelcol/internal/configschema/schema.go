@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package configschema generates JSON Schema documents from component configuration
+// structs, following the same `mapstructure` conventions used by confmap to unmarshal them.
+package configschema // import "go.opentelemetry.io/collector/otelcol/internal/configschema"
+
+import (
+	"encoding"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// Draft is the JSON Schema dialect of the generated documents.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+const (
+	// durationPattern matches the strings accepted by time.ParseDuration.
+	durationPattern = `^[-+]?(0|([0-9]*(\.[0-9]*)?(ns|us|µs|μs|ms|s|m|h))+)$`
+	// IDPattern matches the string representation of a component.ID.
+	IDPattern = `^[a-zA-Z][0-9a-zA-Z_]{0,62}(/.+)?$`
+)
+
+// Schema is a subset of a JSON Schema document, enough to describe collector configuration.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	WriteOnly            bool               `json:"writeOnly,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	PatternProperties    map[string]*Schema `json:"patternProperties,omitempty"`
+	PropertyNames        *Schema            `json:"propertyNames,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+}
+
+// knownTypes describes types whose YAML representation cannot be derived from their Go kind,
+// keyed by "<package path>.<type name>". They are matched by name to avoid depending on
+// every module that defines a configuration type.
+var knownTypes = map[string]func() *Schema{
+	"time.Duration": func() *Schema {
+		return &Schema{Type: "string", Pattern: durationPattern}
+	},
+	"go.opentelemetry.io/collector/config/configopaque.String": func() *Schema {
+		return &Schema{Type: "string", Format: "password", WriteOnly: true}
+	},
+	"go.opentelemetry.io/collector/config/configcompression.Type": func() *Schema {
+		return &Schema{Type: "string", Enum: []any{"", "none", "gzip", "zlib", "deflate", "snappy", "zstd"}}
+	},
+	"go.opentelemetry.io/collector/config/configtelemetry.Level": func() *Schema {
+		return &Schema{Type: "string", Enum: []any{"none", "basic", "normal", "detailed", "None", "Basic", "Normal", "Detailed"}}
+	},
+	"go.uber.org/zap/zapcore.Level": func() *Schema {
+		return &Schema{Type: "string", Enum: []any{
+			"debug", "info", "warn", "error", "dpanic", "panic", "fatal",
+			"DEBUG", "INFO", "WARN", "ERROR", "DPANIC", "PANIC", "FATAL",
+		}}
+	},
+	"go.opentelemetry.io/collector/component.ID": func() *Schema {
+		return &Schema{Type: "string", Pattern: IDPattern}
+	},
+	"go.opentelemetry.io/collector/component.Type": func() *Schema {
+		return &Schema{Type: "string", Pattern: `^[a-zA-Z][0-9a-zA-Z_]{0,62}$`}
+	},
+}
+
+var (
+	textUnmarshalerType    = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType      = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	confmapUnmarshalerType = reflect.TypeOf((*confmap.Unmarshaler)(nil)).Elem()
+)
+
+// Generate returns the JSON Schema of the given configuration value. Non-zero scalar
+// values found in cfg, usually the result of a factory's CreateDefaultConfig, are
+// reported as defaults.
+func Generate(cfg any) *Schema {
+	g := &generator{inProgress: map[reflect.Type]bool{}}
+	return g.schema(reflect.ValueOf(cfg))
+}
+
+type generator struct {
+	// inProgress records the struct types currently being walked, to stop on recursive types.
+	inProgress map[reflect.Type]bool
+}
+
+func (g *generator) schema(v reflect.Value) *Schema {
+	if !v.IsValid() {
+		return &Schema{}
+	}
+	t := v.Type()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		if v.IsNil() {
+			v = reflect.Zero(t)
+		} else {
+			v = v.Elem()
+		}
+	}
+
+	if known, ok := knownTypes[t.PkgPath()+"."+t.Name()]; ok {
+		s := known()
+		if t.Kind() != reflect.Interface && !isOpaque(t) {
+			s.Default = defaultValue(v)
+		}
+		return s
+	}
+
+	// Types implementing encoding.TextUnmarshaler are always decoded from a string.
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return &Schema{Type: "string", Default: defaultValue(v)}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean", Default: defaultValue(v)}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: "integer", Default: defaultValue(v)}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: "integer", Default: defaultValue(v)}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number", Default: defaultValue(v)}
+	case reflect.String:
+		return &Schema{Type: "string", Default: defaultValue(v)}
+	case reflect.Slice, reflect.Array:
+		s := &Schema{Type: "array", Items: g.schema(reflect.Zero(t.Elem()))}
+		if v.Len() > 0 && isScalar(t.Elem()) {
+			s.Default = defaultValue(v)
+		}
+		return s
+	case reflect.Map:
+		s := &Schema{Type: "object", AdditionalProperties: g.schema(reflect.Zero(t.Elem()))}
+		if known, ok := knownTypes[t.Key().PkgPath()+"."+t.Key().Name()]; ok && known().Pattern != "" {
+			s.PropertyNames = &Schema{Pattern: known().Pattern}
+		}
+		return s
+	case reflect.Struct:
+		return g.structSchema(v)
+	default:
+		// Interfaces and other kinds accept any value.
+		return &Schema{}
+	}
+}
+
+func (g *generator) structSchema(v reflect.Value) *Schema {
+	t := v.Type()
+	s := &Schema{Type: "object"}
+	if g.inProgress[t] {
+		return s
+	}
+	g.inProgress[t] = true
+	defer delete(g.inProgress, t)
+
+	s.Properties = map[string]*Schema{}
+	g.addFields(s, v)
+	// Unknown keys are rejected by confmap, unless the struct implements its own unmarshaling.
+	if !reflect.PointerTo(t).Implements(confmapUnmarshalerType) {
+		s.AdditionalProperties = false
+	}
+	return s
+}
+
+// addFields adds the schema of each field of the struct v to s, inlining squashed fields.
+func (g *generator) addFields(s *Schema, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if name == "-" {
+			continue
+		}
+		fv := v.Field(i)
+		if hasOption(opts, "squash") {
+			for fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					fv = reflect.Zero(fv.Type().Elem())
+				} else {
+					fv = fv.Elem()
+				}
+			}
+			if fv.Kind() == reflect.Struct {
+				g.addFields(s, fv)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		s.Properties[name] = g.schema(fv)
+	}
+}
+
+func hasOption(opts string, opt string) bool {
+	for _, o := range strings.Split(opts, ",") {
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
+
+func isScalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return !isOpaque(t)
+	}
+	return false
+}
+
+func isOpaque(t reflect.Type) bool {
+	return t.PkgPath() == "go.opentelemetry.io/collector/config/configopaque"
+}
+
+// defaultValue returns the YAML representation of v, or nil if v is the zero value.
+func defaultValue(v reflect.Value) any {
+	if !v.IsValid() || v.IsZero() {
+		return nil
+	}
+	return yamlValue(v)
+}
+
+func yamlValue(v reflect.Value) any {
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		return time.Duration(v.Int()).String()
+	}
+	if v.Type().Implements(textMarshalerType) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil
+		}
+		return string(text)
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	case reflect.Slice, reflect.Array:
+		values := make([]any, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, yamlValue(v.Index(i)))
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configschema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+type EmbeddedConfig struct {
+	Endpoint string        `mapstructure:"endpoint"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+}
+
+type nestedConfig struct {
+	Enabled bool     `mapstructure:"enabled"`
+	Paths   []string `mapstructure:"paths"`
+}
+
+type testConfig struct {
+	EmbeddedConfig `mapstructure:",squash"`
+	Nested         *nestedConfig             `mapstructure:"nested"`
+	Auth           component.ID              `mapstructure:"auth"`
+	Headers        map[string]string         `mapstructure:"headers"`
+	ByID           map[component.ID]struct{} `mapstructure:"by_id"`
+	Ratio          float64                   `mapstructure:"ratio"`
+	Ignored        string                    `mapstructure:"-"`
+	Untagged       uint32
+	Any            any `mapstructure:"any"`
+}
+
+type customConfig struct {
+	Value int `mapstructure:"value"`
+}
+
+func (*customConfig) Unmarshal(*confmap.Conf) error {
+	return nil
+}
+
+type recursiveConfig struct {
+	Next *recursiveConfig `mapstructure:"next"`
+}
+
+func TestGenerate(t *testing.T) {
+	cfg := &testConfig{
+		EmbeddedConfig: EmbeddedConfig{Endpoint: "localhost:4317", Timeout: 5 * time.Second},
+		Nested:         &nestedConfig{Enabled: true, Paths: []string{"stderr", ""}},
+		Auth:           component.MustNewID("basicauth"),
+		Ratio:          0.5,
+	}
+
+	s := Generate(cfg)
+	assert.Equal(t, "object", s.Type)
+	assert.Equal(t, false, s.AdditionalProperties)
+	assert.ElementsMatch(t, []string{"endpoint", "timeout", "nested", "auth", "headers", "by_id", "ratio", "Untagged", "any"}, keys(s.Properties))
+
+	assert.Equal(t, &Schema{Type: "string", Default: "localhost:4317"}, s.Properties["endpoint"])
+	assert.Equal(t, &Schema{Type: "string", Pattern: durationPattern, Default: "5s"}, s.Properties["timeout"])
+	assert.Equal(t, &Schema{Type: "string", Pattern: IDPattern, Default: "basicauth"}, s.Properties["auth"])
+	assert.Equal(t, &Schema{Type: "number", Default: 0.5}, s.Properties["ratio"])
+	assert.Equal(t, &Schema{Type: "integer"}, s.Properties["Untagged"])
+	assert.Equal(t, &Schema{}, s.Properties["any"])
+	assert.Equal(t, &Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}}, s.Properties["headers"])
+	assert.Equal(t, &Schema{Pattern: IDPattern}, s.Properties["by_id"].PropertyNames)
+
+	nested := s.Properties["nested"]
+	assert.Equal(t, &Schema{Type: "boolean", Default: true}, nested.Properties["enabled"])
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Type: "string"}, Default: []any{"stderr", ""}}, nested.Properties["paths"])
+}
+
+func TestGenerateNilPointer(t *testing.T) {
+	s := Generate(&testConfig{})
+	nested := s.Properties["nested"]
+	require.NotNil(t, nested)
+	assert.Equal(t, &Schema{Type: "boolean"}, nested.Properties["enabled"])
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Type: "string"}}, nested.Properties["paths"])
+}
+
+func TestGenerateCustomUnmarshaler(t *testing.T) {
+	s := Generate(&customConfig{})
+	assert.Nil(t, s.AdditionalProperties)
+	assert.Equal(t, &Schema{Type: "integer"}, s.Properties["value"])
+}
+
+func TestGenerateRecursive(t *testing.T) {
+	s := Generate(&recursiveConfig{})
+	assert.Equal(t, &Schema{Type: "object"}, s.Properties["next"])
+}
+
+func TestSchemaMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(Generate(&customConfig{Value: 3}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"object","properties":{"value":{"type":"integer","default":3}}}`, string(data))
+}
+
+func keys(m map[string]*Schema) []string {
+	ret := make([]string, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+	return ret
+}
@@ -0,0 +1,420 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package templates expands the component templates defined in the top-level `templates`
+// section of the collector configuration.
+//
+// A template is a text/template rendering a configuration fragment that can define components
+// of any kind and pipelines. It is instantiated by declaring a component with the
+// `template/<template name>[/<instance name>]` ID, whose configuration is passed as parameters
+// to the template. Every component and pipeline defined by the fragment is renamed after the
+// instance, and references to the instance are replaced by the component it exposes:
+//   - if the fragment has no pipelines, the single component of the same kind as the instance;
+//   - otherwise, a forward connector linking the fragment pipelines left open, i.e. without
+//     exporters for a receiver instance or without receivers for an exporter instance. The
+//     fragments defining pipelines thus require the forward connector to be built in the
+//     collector.
+package templates // import "go.opentelemetry.io/collector/otelcol/internal/templates"
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/connector"
+)
+
+const (
+	// templatesKey is the top-level key of the templates section.
+	templatesKey = "templates"
+	// pipelinesKey is the key of the pipelines defined by a template fragment.
+	pipelinesKey = "pipelines"
+)
+
+// Type is the type of the component IDs instantiating a template.
+var Type = component.MustNewType("template")
+
+// forwardType is the type of the connector linking the pipelines of a fragment to the pipelines
+// referencing the instance, see go.opentelemetry.io/collector/connector/forwardconnector.
+var forwardType = component.MustNewType("forward")
+
+const (
+	receiversKind  = "receivers"
+	processorsKind = "processors"
+	exportersKind  = "exporters"
+	connectorsKind = "connectors"
+	extensionsKind = "extensions"
+)
+
+// kinds lists the top-level component sections, in the order they are expanded.
+var kinds = []string{receiversKind, processorsKind, exportersKind, connectorsKind, extensionsKind}
+
+type instance struct {
+	kind   string
+	id     component.ID
+	params any
+}
+
+// Expand returns the configuration where every template instance is replaced by the
+// components and pipelines rendered from its template. The templates section is removed.
+// The connectors are the connector factories of the collector, which must include the forward
+// connector if a fragment defines pipelines.
+func Expand(conf *confmap.Conf, connectors map[component.Type]connector.Factory) (*confmap.Conf, error) {
+	raw := conf.ToStringMap()
+	section, hasTemplates := raw[templatesKey]
+	tmpls, err := parseTemplates(section)
+	if err != nil {
+		return nil, err
+	}
+	delete(raw, templatesKey)
+
+	instances, err := extractInstances(raw)
+	if err != nil {
+		return nil, err
+	}
+	if len(instances) == 0 && !hasTemplates {
+		return conf, nil
+	}
+
+	for _, inst := range instances {
+		tmplName, _, _ := strings.Cut(inst.id.Name(), "/")
+		tmpl, ok := tmpls[tmplName]
+		if !ok {
+			return nil, fmt.Errorf("%s::%s: template %q is not defined", inst.kind, inst.id, tmplName)
+		}
+		if err = expandInstance(raw, tmpl, inst, connectors); err != nil {
+			return nil, fmt.Errorf("%s::%s: %w", inst.kind, inst.id, err)
+		}
+	}
+	return confmap.NewFromStringMap(raw), nil
+}
+
+func parseTemplates(section any) (map[string]*template.Template, error) {
+	if section == nil {
+		return nil, nil
+	}
+	m, ok := section.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: expected a map, got %T", templatesKey, section)
+	}
+	tmpls := make(map[string]*template.Template, len(m))
+	for name, body := range m {
+		text, ok := body.(string)
+		if !ok {
+			// Templates can also be written as YAML, as long as template actions are quoted.
+			out, err := yaml.Marshal(body)
+			if err != nil {
+				return nil, fmt.Errorf("%s::%s: %w", templatesKey, name, err)
+			}
+			text = string(out)
+		}
+		tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s::%s: %w", templatesKey, name, err)
+		}
+		tmpls[name] = tmpl
+	}
+	return tmpls, nil
+}
+
+// extractInstances removes the template instances from the component sections and returns them.
+func extractInstances(raw map[string]any) ([]instance, error) {
+	var instances []instance
+	for _, kind := range kinds {
+		section, ok := raw[kind].(map[string]any)
+		if !ok {
+			continue
+		}
+		var kindInstances []instance
+		for key, params := range section {
+			id := component.ID{}
+			if err := id.UnmarshalText([]byte(key)); err != nil || id.Type() != Type {
+				continue
+			}
+			if id.Name() == "" {
+				return nil, fmt.Errorf("%s::%s: the template name is missing, use %s/<template name>[/<instance name>]", kind, key, Type)
+			}
+			kindInstances = append(kindInstances, instance{kind: kind, id: id, params: params})
+			delete(section, key)
+		}
+		sort.Slice(kindInstances, func(i, j int) bool {
+			return kindInstances[i].id.String() < kindInstances[j].id.String()
+		})
+		instances = append(instances, kindInstances...)
+	}
+	return instances, nil
+}
+
+func expandInstance(raw map[string]any, tmpl *template.Template, inst instance, connectors map[component.Type]connector.Factory) error {
+	params := inst.params
+	if params == nil {
+		params = map[string]any{}
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, params); err != nil {
+		return err
+	}
+	fragment := map[string]any{}
+	if err := yaml.Unmarshal(buf.Bytes(), &fragment); err != nil {
+		return fmt.Errorf("rendered template is not valid YAML: %w", err)
+	}
+
+	r := renamer{suffix: inst.id.Name(), defined: map[string]map[string]bool{}}
+	for key, value := range fragment {
+		if key == pipelinesKey {
+			continue
+		}
+		if !isKind(key) {
+			return fmt.Errorf("rendered template defines unsupported section %q", key)
+		}
+		components, ok := value.(map[string]any)
+		if !ok && value != nil {
+			return fmt.Errorf("rendered template section %q: expected a map, got %T", key, value)
+		}
+		r.defined[key] = map[string]bool{}
+		for id := range components {
+			r.defined[key][id] = true
+		}
+	}
+
+	// Add the rendered components, renamed after the instance.
+	for kind, ids := range r.defined {
+		components, _ := fragment[kind].(map[string]any)
+		section, ok := raw[kind].(map[string]any)
+		if !ok {
+			section = map[string]any{}
+			raw[kind] = section
+		}
+		for id := range ids {
+			renamed, err := r.rename(kind, id)
+			if err != nil {
+				return err
+			}
+			if _, exists := section[renamed]; exists {
+				return fmt.Errorf("rendered component %s::%s is already defined", kind, renamed)
+			}
+			section[renamed] = components[id]
+		}
+	}
+
+	pipelines, err := r.pipelines(fragment[pipelinesKey])
+	if err != nil {
+		return err
+	}
+
+	entry, err := r.entry(raw, inst, pipelines, connectors)
+	if err != nil {
+		return err
+	}
+
+	service, ok := raw["service"].(map[string]any)
+	if !ok {
+		service = map[string]any{}
+		raw["service"] = service
+	}
+	servicePipelines, ok := service[pipelinesKey].(map[string]any)
+	if !ok {
+		servicePipelines = map[string]any{}
+		service[pipelinesKey] = servicePipelines
+	}
+	replaceReferences(service, servicePipelines, inst, entry)
+	for id, pipeline := range pipelines {
+		if _, exists := servicePipelines[id]; exists {
+			return fmt.Errorf("rendered pipeline %q is already defined", id)
+		}
+		servicePipelines[id] = pipeline
+	}
+	return nil
+}
+
+// renamer renames the components and pipelines rendered for a template instance.
+type renamer struct {
+	// suffix is appended to the name of rendered IDs, to make them unique per instance.
+	suffix string
+	// defined records the components defined by the rendered fragment, per kind.
+	defined map[string]map[string]bool
+}
+
+func (r renamer) rename(kind string, id string) (string, error) {
+	parsed := component.ID{}
+	if err := parsed.UnmarshalText([]byte(id)); err != nil {
+		return "", fmt.Errorf("rendered component %s::%s: %w", kind, id, err)
+	}
+	if parsed.Name() == "" {
+		return component.NewIDWithName(parsed.Type(), r.suffix).String(), nil
+	}
+	return component.NewIDWithName(parsed.Type(), parsed.Name()+"/"+r.suffix).String(), nil
+}
+
+// renameReferences renames the references in list to components defined by the fragment,
+// references to other components are kept as is.
+func (r renamer) renameReferences(list any, kinds ...string) ([]any, error) {
+	refs, ok := list.([]any)
+	if !ok && list != nil {
+		return nil, fmt.Errorf("expected a list, got %T", list)
+	}
+	renamed := make([]any, 0, len(refs))
+	for _, ref := range refs {
+		str, ok := ref.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a component ID, got %T", ref)
+		}
+		for _, kind := range kinds {
+			if r.defined[kind][str] {
+				var err error
+				if str, err = r.rename(kind, str); err != nil {
+					return nil, err
+				}
+				break
+			}
+		}
+		renamed = append(renamed, str)
+	}
+	return renamed, nil
+}
+
+// pipelines returns the rendered pipelines, renamed after the instance.
+func (r renamer) pipelines(section any) (map[string]map[string]any, error) {
+	if section == nil {
+		return nil, nil
+	}
+	m, ok := section.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("rendered section %q: expected a map, got %T", pipelinesKey, section)
+	}
+	pipelines := make(map[string]map[string]any, len(m))
+	for id, value := range m {
+		pipeline, ok := value.(map[string]any)
+		if !ok && value != nil {
+			return nil, fmt.Errorf("rendered pipeline %q: expected a map, got %T", id, value)
+		}
+		renamedID, err := r.rename(pipelinesKey, id)
+		if err != nil {
+			return nil, err
+		}
+		renamed := map[string]any{}
+		for key, refs := range pipeline {
+			var err error
+			switch key {
+			case receiversKind:
+				renamed[key], err = r.renameReferences(refs, receiversKind, connectorsKind)
+			case processorsKind:
+				renamed[key], err = r.renameReferences(refs, processorsKind)
+			case exportersKind:
+				renamed[key], err = r.renameReferences(refs, exportersKind, connectorsKind)
+			default:
+				err = fmt.Errorf("unsupported key %q", key)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("rendered pipeline %q: %w", id, err)
+			}
+		}
+		pipelines[renamedID] = renamed
+	}
+	return pipelines, nil
+}
+
+// entry returns the ID of the component replacing the references to the instance.
+func (r renamer) entry(raw map[string]any, inst instance, pipelines map[string]map[string]any, connectors map[component.Type]connector.Factory) (string, error) {
+	if len(pipelines) == 0 {
+		if len(r.defined[inst.kind]) != 1 {
+			return "", fmt.Errorf("rendered template must define exactly one component in %q, found %d", inst.kind, len(r.defined[inst.kind]))
+		}
+		for id := range r.defined[inst.kind] {
+			return r.rename(inst.kind, id)
+		}
+	}
+
+	var openKey string
+	switch inst.kind {
+	case receiversKind:
+		openKey = exportersKind
+	case exportersKind:
+		openKey = receiversKind
+	default:
+		return "", fmt.Errorf("only templates used as receivers or exporters can define %s", pipelinesKey)
+	}
+	if _, ok := connectors[forwardType]; !ok {
+		return "", fmt.Errorf("rendered template defines %s, which requires the %q connector, not built in this collector", pipelinesKey, forwardType)
+	}
+
+	// The rendered pipelines are linked to the pipelines referencing the instance by a forward connector.
+	connectorID := component.NewIDWithName(forwardType, r.suffix).String()
+	found := false
+	for _, pipeline := range pipelines {
+		if refs, _ := pipeline[openKey].([]any); len(refs) == 0 {
+			pipeline[openKey] = []any{connectorID}
+			found = true
+		}
+	}
+	if !found {
+		return "", fmt.Errorf("rendered template must define at least one pipeline without %s", openKey)
+	}
+
+	section, ok := raw[connectorsKind].(map[string]any)
+	if !ok {
+		section = map[string]any{}
+		raw[connectorsKind] = section
+	}
+	if _, exists := section[connectorID]; exists {
+		return "", fmt.Errorf("connector %q is already defined", connectorID)
+	}
+	section[connectorID] = nil
+	return connectorID, nil
+}
+
+// replaceReferences replaces the references to the instance in the service section by entry.
+func replaceReferences(service map[string]any, pipelines map[string]any, inst instance, entry string) {
+	if inst.kind == extensionsKind {
+		service[extensionsKind] = replaceReference(service[extensionsKind], inst.id, entry)
+		return
+	}
+	for _, value := range pipelines {
+		pipeline, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+		if inst.kind == receiversKind || inst.kind == connectorsKind {
+			pipeline[receiversKind] = replaceReference(pipeline[receiversKind], inst.id, entry)
+		}
+		if inst.kind == processorsKind {
+			pipeline[processorsKind] = replaceReference(pipeline[processorsKind], inst.id, entry)
+		}
+		if inst.kind == exportersKind || inst.kind == connectorsKind {
+			pipeline[exportersKind] = replaceReference(pipeline[exportersKind], inst.id, entry)
+		}
+	}
+}
+
+func replaceReference(list any, id component.ID, entry string) any {
+	refs, ok := list.([]any)
+	if !ok {
+		return list
+	}
+	for i, ref := range refs {
+		str, ok := ref.(string)
+		if !ok {
+			continue
+		}
+		refID := component.ID{}
+		if err := refID.UnmarshalText([]byte(str)); err == nil && refID == id {
+			refs[i] = entry
+		}
+	}
+	return refs
+}
+
+func isKind(key string) bool {
+	for _, kind := range kinds {
+		if key == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package templates
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/connector"
+)
+
+var connectors = map[component.Type]connector.Factory{
+	forwardType: connector.NewFactory(forwardType, func() component.Config { return nil }),
+}
+
+func TestExpand(t *testing.T) {
+	tests := []string{
+		"exporter",
+		"receiver_chain",
+		"unused_templates",
+	}
+
+	for _, tt := range tests {
+		t.Run(tt, func(t *testing.T) {
+			conf, err := confmaptest.LoadConf(filepath.Join("testdata", tt+".yaml"))
+			require.NoError(t, err)
+			expected, err := confmaptest.LoadConf(filepath.Join("testdata", tt+"_expected.yaml"))
+			require.NoError(t, err)
+
+			expanded, err := Expand(conf, connectors)
+			require.NoError(t, err)
+			assert.Equal(t, expected.ToStringMap(), expanded.ToStringMap())
+		})
+	}
+}
+
+func TestExpandNoTemplates(t *testing.T) {
+	conf, err := confmaptest.LoadConf(filepath.Join("testdata", "no_templates.yaml"))
+	require.NoError(t, err)
+
+	expanded, err := Expand(conf, connectors)
+	require.NoError(t, err)
+	assert.Same(t, conf, expanded)
+}
+
+func TestExpandErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		conf        map[string]any
+		expectedErr string
+	}{
+		{
+			name:        "invalid_templates_section",
+			conf:        map[string]any{"templates": []any{"foo"}},
+			expectedErr: "templates: expected a map, got []interface {}",
+		},
+		{
+			name:        "invalid_template",
+			conf:        map[string]any{"templates": map[string]any{"foo": "{{ .foo "}},
+			expectedErr: `templates::foo: template: foo:1: unclosed action`,
+		},
+		{
+			name:        "missing_template_name",
+			conf:        map[string]any{"receivers": map[string]any{"template": nil}},
+			expectedErr: "receivers::template: the template name is missing, use template/<template name>[/<instance name>]",
+		},
+		{
+			name:        "undefined_template",
+			conf:        map[string]any{"receivers": map[string]any{"template/foo": nil}},
+			expectedErr: `receivers::template/foo: template "foo" is not defined`,
+		},
+		{
+			name: "missing_parameter",
+			conf: map[string]any{
+				"templates": map[string]any{"foo": "exporters:\n  debug:\n    verbosity: {{ .verbosity }}\n"},
+				"exporters": map[string]any{"template/foo": nil},
+			},
+			expectedErr: `exporters::template/foo: template: foo:3:18: executing "foo" at <.verbosity>: map has no entry for key "verbosity"`,
+		},
+		{
+			name: "unsupported_section",
+			conf: map[string]any{
+				"templates": map[string]any{"foo": "service:\n  extensions: []\n"},
+				"exporters": map[string]any{"template/foo": nil},
+			},
+			expectedErr: `exporters::template/foo: rendered template defines unsupported section "service"`,
+		},
+		{
+			name: "ambiguous_entry",
+			conf: map[string]any{
+				"templates": map[string]any{"foo": "exporters:\n  debug:\n  otlp:\n"},
+				"exporters": map[string]any{"template/foo": nil},
+			},
+			expectedErr: `exporters::template/foo: rendered template must define exactly one component in "exporters", found 2`,
+		},
+		{
+			name: "already_defined",
+			conf: map[string]any{
+				"templates": map[string]any{"foo": "exporters:\n  debug:\n"},
+				"exporters": map[string]any{"template/foo": nil, "debug/foo": nil},
+			},
+			expectedErr: `exporters::template/foo: rendered component exporters::debug/foo is already defined`,
+		},
+		{
+			name: "processor_with_pipelines",
+			conf: map[string]any{
+				"templates":  map[string]any{"foo": "processors:\n  batch:\npipelines:\n  logs:\n    processors: [batch]\n"},
+				"processors": map[string]any{"template/foo": nil},
+			},
+			expectedErr: `processors::template/foo: only templates used as receivers or exporters can define pipelines`,
+		},
+		{
+			name: "closed_pipelines",
+			conf: map[string]any{
+				"templates": map[string]any{"foo": "receivers:\n  otlp:\nexporters:\n  debug:\npipelines:\n  logs:\n    receivers: [otlp]\n    exporters: [debug]\n"},
+				"receivers": map[string]any{"template/foo": nil},
+			},
+			expectedErr: `receivers::template/foo: rendered template must define at least one pipeline without exporters`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Expand(confmap.NewFromStringMap(tt.conf), connectors)
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestExpandWithoutForwardConnector(t *testing.T) {
+	conf, err := confmaptest.LoadConf(filepath.Join("testdata", "receiver_chain.yaml"))
+	require.NoError(t, err)
+	_, err = Expand(conf, nil)
+	assert.ErrorContains(t, err, `rendered template defines pipelines, which requires the "forward" connector, not built in this collector`)
+}
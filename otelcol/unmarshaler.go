@@ -4,6 +4,7 @@
 package otelcol // import "go.opentelemetry.io/collector/otelcol"
 
 import (
+	"fmt"
 	"time"
 
 	"go.uber.org/zap/zapcore"
@@ -14,6 +15,7 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/otelcol/internal/configunmarshaler"
+	"go.opentelemetry.io/collector/otelcol/internal/templates"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service"
@@ -32,6 +34,12 @@ type configSettings struct {
 // unmarshal the configSettings from a confmap.Conf.
 // After the config is unmarshalled, `Validate()` must be called to validate.
 func unmarshal(v *confmap.Conf, factories Factories) (*configSettings, error) {
+	// Expand the component templates before unmarshaling, since they can define any component.
+	v, err := templates.Expand(v, factories.Connectors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand templates: %w", err)
+	}
+
 	// Unmarshal top level sections and validate.
 	cfg := &configSettings{
 		Receivers:  configunmarshaler.NewConfigs(factories.Receivers),
@@ -40,31 +48,36 @@ func unmarshal(v *confmap.Conf, factories Factories) (*configSettings, error) {
 		Connectors: configunmarshaler.NewConfigs(factories.Connectors),
 		Extensions: configunmarshaler.NewConfigs(factories.Extensions),
 		// TODO: Add a component.ServiceFactory to allow this to be defined by the Service.
-		Service: service.Config{
-			Telemetry: telemetry.Config{
-				Logs: telemetry.LogsConfig{
-					Level:       zapcore.InfoLevel,
-					Development: false,
-					Encoding:    "console",
-					Sampling: &telemetry.LogsSamplingConfig{
-						Enabled:    true,
-						Tick:       10 * time.Second,
-						Initial:    10,
-						Thereafter: 100,
-					},
-					OutputPaths:       []string{"stderr"},
-					ErrorOutputPaths:  []string{"stderr"},
-					DisableCaller:     false,
-					DisableStacktrace: false,
-					InitialFields:     map[string]any(nil),
-				},
-				Metrics: telemetry.MetricsConfig{
-					Level:   configtelemetry.LevelNormal,
-					Address: ":8888",
+		Service: defaultServiceConfig(),
+	}
+
+	return cfg, v.Unmarshal(&cfg)
+}
+
+// defaultServiceConfig returns the service configuration used when no value is set by the user.
+func defaultServiceConfig() service.Config {
+	return service.Config{
+		Telemetry: telemetry.Config{
+			Logs: telemetry.LogsConfig{
+				Level:       zapcore.InfoLevel,
+				Development: false,
+				Encoding:    "console",
+				Sampling: &telemetry.LogsSamplingConfig{
+					Enabled:    true,
+					Tick:       10 * time.Second,
+					Initial:    10,
+					Thereafter: 100,
 				},
+				OutputPaths:       []string{"stderr"},
+				ErrorOutputPaths:  []string{"stderr"},
+				DisableCaller:     false,
+				DisableStacktrace: false,
+				InitialFields:     map[string]any(nil),
+			},
+			Metrics: telemetry.MetricsConfig{
+				Level:   configtelemetry.LevelNormal,
+				Address: ":8888",
 			},
 		},
 	}
-
-	return cfg, v.Unmarshal(&cfg)
 }
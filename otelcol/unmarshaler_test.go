@@ -4,6 +4,7 @@
 package otelcol
 
 import (
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -11,7 +12,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/service"
 	"go.opentelemetry.io/collector/service/pipelines"
 	"go.opentelemetry.io/collector/service/telemetry"
@@ -209,3 +212,20 @@ func TestServiceUnmarshalError(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalTemplates(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	conf, err := confmaptest.LoadConf(filepath.Join("testdata", "otelcol-templates.yaml"))
+	require.NoError(t, err)
+	cfg, err := unmarshal(conf, factories)
+	require.NoError(t, err)
+
+	first := component.MustNewIDWithName("nop", "nop_exporter/first")
+	second := component.MustNewIDWithName("nop", "nop_exporter/second")
+	assert.Len(t, cfg.Exporters.Configs(), 2)
+	assert.Contains(t, cfg.Exporters.Configs(), first)
+	assert.Contains(t, cfg.Exporters.Configs(), second)
+	assert.Equal(t, []component.ID{first, second}, cfg.Service.Pipelines[component.NewID(component.DataTypeTraces)].Exporters)
+}
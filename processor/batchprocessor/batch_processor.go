@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -69,6 +70,7 @@ type batchProcessor struct {
 type batcher interface {
 	consume(ctx context.Context, data any) error
 	currentMetadataCardinality() int
+	allShards() []*shard
 }
 
 // shard is a single instance of the batch logic.  When metadata
@@ -92,6 +94,16 @@ type shard struct {
 	// batch is an in-flight data item containing one of the
 	// underlying data types.
 	batch batch
+
+	// buffered is the number of items of the batch, updated by
+	// the shard goroutine to be read by Drain.
+	buffered atomic.Int64
+}
+
+// flushRequest is sent to a shard as a data item by Drain, for
+// the shard to send its batch. done is closed once sent.
+type flushRequest struct {
+	done chan struct{}
 }
 
 // batch is an interface generalizing the individual signal types.
@@ -171,6 +183,38 @@ func (bp *batchProcessor) Start(context.Context, component.Host) error {
 	return nil
 }
 
+// Drain sends the batches and waits until they are sent, or the context is done. It returns the
+// number of items left in the batches when the context is done. The service drains the processors
+// on shutdown before shutting them down.
+func (bp *batchProcessor) Drain(ctx context.Context) int {
+	shards := bp.batcher.allShards()
+	requests := make([]*flushRequest, 0, len(shards))
+	pending := func(shards []*shard) int {
+		var items int64
+		for _, s := range shards {
+			items += s.buffered.Load()
+		}
+		return int(items)
+	}
+	for _, s := range shards {
+		fr := &flushRequest{done: make(chan struct{})}
+		select {
+		case s.newItem <- fr:
+			requests = append(requests, fr)
+		case <-ctx.Done():
+			return pending(shards)
+		}
+	}
+	for i, fr := range requests {
+		select {
+		case <-fr.done:
+		case <-ctx.Done():
+			return pending(shards[i:])
+		}
+	}
+	return 0
+}
+
 // Shutdown is invoked during service shutdown.
 func (bp *batchProcessor) Shutdown(context.Context) error {
 	close(bp.shutdownC)
@@ -229,12 +273,20 @@ func (b *shard) startLoop() {
 }
 
 func (b *shard) processItem(item any) {
+	if fr, ok := item.(*flushRequest); ok {
+		for b.batch.itemCount() > 0 {
+			b.sendItems(triggerTimeout)
+		}
+		close(fr.done)
+		return
+	}
 	b.batch.add(item)
 	sent := false
 	for b.batch.itemCount() > 0 && (!b.hasTimer() || b.batch.itemCount() >= b.processor.sendBatchSize) {
 		sent = true
 		b.sendItems(triggerBatchSize)
 	}
+	b.buffered.Store(int64(b.batch.itemCount()))
 
 	if sent {
 		b.stopTimer()
@@ -260,6 +312,7 @@ func (b *shard) resetTimer() {
 
 func (b *shard) sendItems(trigger trigger) {
 	sent, bytes, err := b.batch.export(b.exportCtx, b.processor.sendBatchMaxSize, b.processor.telemetry.detailed)
+	b.buffered.Store(int64(b.batch.itemCount()))
 	if err != nil {
 		b.processor.logger.Warn("Sender failed", zap.Error(err))
 	} else {
@@ -282,6 +335,10 @@ func (sb *singleShardBatcher) currentMetadataCardinality() int {
 	return 1
 }
 
+func (sb *singleShardBatcher) allShards() []*shard {
+	return []*shard{sb.batcher}
+}
+
 // multiBatcher is used when metadataKeys is not empty.
 type multiShardBatcher struct {
 	*batchProcessor
@@ -342,6 +399,15 @@ func (mb *multiShardBatcher) currentMetadataCardinality() int {
 	return mb.size
 }
 
+func (mb *multiShardBatcher) allShards() []*shard {
+	var shards []*shard
+	mb.batchers.Range(func(_, b any) bool {
+		shards = append(shards, b.(*shard))
+		return true
+	})
+	return shards
+}
+
 // ConsumeTraces implements TracesProcessor
 func (bp *batchProcessor) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
 	return bp.batcher.consume(ctx, td)
@@ -344,6 +344,62 @@ func TestBatchProcessorTraceSendWhenClosing(t *testing.T) {
 	require.Equal(t, 1, len(sink.AllTraces()))
 }
 
+func TestBatchProcessorDrain(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 1000
+	cfg.Timeout = 10 * time.Minute
+	cfg.MetadataKeys = []string{"token"}
+	batcher, err := newBatchTracesProcessor(processortest.NewNopCreateSettings(), sink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	for _, token := range []string{"a", "b"} {
+		ctx := client.NewContext(context.Background(), client.Info{
+			Metadata: client.NewMetadata(map[string][]string{"token": {token}}),
+		})
+		for requestNum := 0; requestNum < 10; requestNum++ {
+			assert.NoError(t, batcher.ConsumeTraces(ctx, testdata.GenerateTraces(10)))
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.Zero(t, batcher.Drain(ctx))
+	assert.Equal(t, 200, sink.SpanCount())
+	assert.Len(t, sink.AllTraces(), 2)
+	require.NoError(t, batcher.Shutdown(context.Background()))
+}
+
+func TestBatchProcessorDrainTimeout(t *testing.T) {
+	unblock := make(chan struct{})
+	next, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error {
+		<-unblock
+		return nil
+	})
+	require.NoError(t, err)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 1000
+	cfg.Timeout = 10 * time.Minute
+	batcher, err := newBatchTracesProcessor(processortest.NewNopCreateSettings(), next, cfg)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	for requestNum := 0; requestNum < 10; requestNum++ {
+		assert.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(10)))
+	}
+	assert.Eventually(t, func() bool {
+		return batcher.batcher.allShards()[0].buffered.Load() == 100
+	}, time.Second, 10*time.Millisecond)
+
+	// The batch is blocked being sent to the next consumer.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, 100, batcher.Drain(ctx))
+	close(unblock)
+	require.NoError(t, batcher.Shutdown(context.Background()))
+}
+
 func TestBatchMetricProcessor_ReceivingData(t *testing.T) {
 	// Instantiate the batch processor with low config values to test data
 	// gets sent through the processor.
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfilereceiver // import "go.opentelemetry.io/collector/receiver/otlpfilereceiver"
+
+import (
+	"errors"
+	"path/filepath"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/otlpfile"
+)
+
+// Config defines configuration for the OTLP file receiver.
+type Config struct {
+	// Path is the path of the files to read, which may contain the patterns of filepath.Match.
+	// The files are read in the lexical order of their paths.
+	Path string `mapstructure:"path"`
+
+	// Format is the format of the files, "json" or "proto", see the file exporter. Default is "json".
+	Format string `mapstructure:"format"`
+
+	// Compression is the compression of the files, "gzip" or none if empty.
+	Compression string `mapstructure:"compression"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("path must be specified")
+	}
+	if _, err := filepath.Match(cfg.Path, ""); err != nil {
+		return errors.New("path is not a valid pattern")
+	}
+	if err := otlpfile.Format(cfg.Format).Validate(); err != nil {
+		return err
+	}
+	return otlpfile.Compression(cfg.Compression).Validate()
+}
@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package otlpfilereceiver reads the files written by the file exporter into the pipelines.
+package otlpfilereceiver // import "go.opentelemetry.io/collector/receiver/otlpfilereceiver"
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfilereceiver // import "go.opentelemetry.io/collector/receiver/otlpfilereceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/otlpfile"
+	"go.opentelemetry.io/collector/internal/sharedcomponent"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/otlpfilereceiver/internal/metadata"
+)
+
+// NewFactory creates a factory for the OTLP file receiver.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithTraces(createTraces, metadata.TracesStability),
+		receiver.WithMetrics(createMetrics, metadata.MetricsStability),
+		receiver.WithLogs(createLogs, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Format: string(otlpfile.FormatJSON),
+	}
+}
+
+func createTraces(_ context.Context, set receiver.CreateSettings, cfg component.Config, nextConsumer consumer.Traces) (receiver.Traces, error) {
+	r, err := getOrCreateFileReceiver(cfg.(*Config), set)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().nextTraces = nextConsumer
+	return r, nil
+}
+
+func createMetrics(_ context.Context, set receiver.CreateSettings, cfg component.Config, nextConsumer consumer.Metrics) (receiver.Metrics, error) {
+	r, err := getOrCreateFileReceiver(cfg.(*Config), set)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().nextMetrics = nextConsumer
+	return r, nil
+}
+
+func createLogs(_ context.Context, set receiver.CreateSettings, cfg component.Config, nextConsumer consumer.Logs) (receiver.Logs, error) {
+	r, err := getOrCreateFileReceiver(cfg.(*Config), set)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().nextLogs = nextConsumer
+	return r, nil
+}
+
+// getOrCreateFileReceiver returns the receiver shared by the signals of the configuration, the
+// files being read once for all the signals.
+func getOrCreateFileReceiver(cfg *Config, set receiver.CreateSettings) (*sharedcomponent.Component[*fileReceiver], error) {
+	return receivers.LoadOrStore(
+		cfg,
+		func() (*fileReceiver, error) {
+			return newFileReceiver(cfg, set)
+		},
+		&set.TelemetrySettings,
+	)
+}
+
+// This is the map of already created OTLP file receivers for particular configurations.
+var receivers = sharedcomponent.NewMap[*Config, *fileReceiver]()
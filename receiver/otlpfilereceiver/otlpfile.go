@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfilereceiver // import "go.opentelemetry.io/collector/receiver/otlpfilereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/otlpfile"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+)
+
+// fileReceiver reads the records of the files once, passing each record to the consumer of its
+// signal. The records of the signals without a consumer are skipped.
+type fileReceiver struct {
+	cfg         *Config
+	settings    receiver.CreateSettings
+	nextTraces  consumer.Traces
+	nextMetrics consumer.Metrics
+	nextLogs    consumer.Logs
+	obsrecv     *receiverhelper.ObsReport
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newFileReceiver(cfg *Config, set receiver.CreateSettings) (*fileReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             set.ID,
+		Transport:              "file",
+		ReceiverCreateSettings: set,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &fileReceiver{
+		cfg:      cfg,
+		settings: set,
+		obsrecv:  obsrecv,
+	}, nil
+}
+
+// Start lists the files and starts reading them in the background.
+func (r *fileReceiver) Start(context.Context, component.Host) error {
+	paths, err := filepath.Glob(r.cfg.Path)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no file matches %q", r.cfg.Path)
+	}
+	// The files rotated by the file exporter sort before the file they were rotated from.
+	sort.Strings(paths)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+	go func() {
+		defer close(r.done)
+		for _, path := range paths {
+			if err := r.readFile(ctx, path); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				r.settings.Logger.Error("Failed to read the file", zap.String("path", path), zap.Error(err))
+			}
+		}
+		r.settings.Logger.Info("Finished reading the files", zap.Int("files", len(paths)))
+	}()
+	return nil
+}
+
+// Shutdown stops reading the files.
+func (r *fileReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+		<-r.done
+	}
+	return nil
+}
+
+func (r *fileReceiver) readFile(ctx context.Context, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	reader, err := otlpfile.NewReader(f, otlpfile.Compression(r.cfg.Compression))
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	dec := otlpfile.NewDecoder(reader, otlpfile.Format(r.cfg.Format))
+	for ctx.Err() == nil {
+		rec, err := dec.Decode()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err = r.consume(ctx, rec); err != nil && ctx.Err() == nil {
+			r.settings.Logger.Warn("Failed to consume the record", zap.String("path", path), zap.Error(err))
+		}
+	}
+	return ctx.Err()
+}
+
+func (r *fileReceiver) consume(ctx context.Context, rec otlpfile.Record) error {
+	var err error
+	switch {
+	case rec.Signal == otlpfile.SignalTraces && r.nextTraces != nil:
+		ctx = r.obsrecv.StartTracesOp(ctx)
+		err = r.nextTraces.ConsumeTraces(ctx, rec.Traces)
+		r.obsrecv.EndTracesOp(ctx, r.cfg.Format, rec.Traces.SpanCount(), err)
+	case rec.Signal == otlpfile.SignalMetrics && r.nextMetrics != nil:
+		ctx = r.obsrecv.StartMetricsOp(ctx)
+		err = r.nextMetrics.ConsumeMetrics(ctx, rec.Metrics)
+		r.obsrecv.EndMetricsOp(ctx, r.cfg.Format, rec.Metrics.DataPointCount(), err)
+	case rec.Signal == otlpfile.SignalLogs && r.nextLogs != nil:
+		ctx = r.obsrecv.StartLogsOp(ctx)
+		err = r.nextLogs.ConsumeLogs(ctx, rec.Logs)
+		r.obsrecv.EndLogsOp(ctx, r.cfg.Format, rec.Logs.LogRecordCount(), err)
+	}
+	return err
+}
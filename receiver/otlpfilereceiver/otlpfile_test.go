@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpfilereceiver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/otlpfile"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestFileReceiver(t *testing.T) {
+	for _, format := range []otlpfile.Format{otlpfile.FormatJSON, otlpfile.FormatProto} {
+		for _, compression := range []otlpfile.Compression{otlpfile.CompressionNone, otlpfile.CompressionGzip} {
+			t.Run(string(format)+"_"+string(compression), func(t *testing.T) {
+				dir := t.TempDir()
+				// The rotated file is read before the current one.
+				writeRecords(t, filepath.Join(dir, "data.bin"), format, compression,
+					otlpfile.LogsRecord(testdata.GenerateLogs(3)),
+					otlpfile.MetricsRecord(testdata.GenerateMetrics(4)))
+				writeRecords(t, filepath.Join(dir, "data-20240502T150405.000000000.bin"), format, compression,
+					otlpfile.TracesRecord(testdata.GenerateTraces(1)),
+					otlpfile.LogsRecord(testdata.GenerateLogs(2)))
+
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig().(*Config)
+				cfg.Path = filepath.Join(dir, "data*.bin")
+				cfg.Format = string(format)
+				cfg.Compression = string(compression)
+				set := receivertest.NewNopCreateSettings()
+				ctx := context.Background()
+
+				traces := new(consumertest.TracesSink)
+				logs := new(consumertest.LogsSink)
+				tr, err := factory.CreateTracesReceiver(ctx, set, cfg, traces)
+				require.NoError(t, err)
+				lr, err := factory.CreateLogsReceiver(ctx, set, cfg, logs)
+				require.NoError(t, err)
+				require.NoError(t, tr.Start(ctx, componenttest.NewNopHost()))
+				require.NoError(t, lr.Start(ctx, componenttest.NewNopHost()))
+
+				// The metrics are skipped, without a consumer.
+				assert.Eventually(t, func() bool {
+					return logs.LogRecordCount() == 5
+				}, 10*time.Second, 5*time.Millisecond)
+				require.NoError(t, tr.Shutdown(ctx))
+				require.NoError(t, lr.Shutdown(ctx))
+
+				assert.Equal(t, []ptrace.Traces{testdata.GenerateTraces(1)}, traces.AllTraces())
+				assert.Equal(t, []plog.Logs{testdata.GenerateLogs(2), testdata.GenerateLogs(3)}, logs.AllLogs())
+			})
+		}
+	}
+}
+
+func TestFileReceiverInvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.json"), []byte("not json\n"), 0o600))
+	writeRecords(t, filepath.Join(dir, "b.json"), otlpfile.FormatJSON, otlpfile.CompressionNone, otlpfile.LogsRecord(testdata.GenerateLogs(1)))
+
+	// The files that cannot be read are skipped.
+	logs := new(consumertest.LogsSink)
+	r, err := newFileReceiver(&Config{Path: filepath.Join(dir, "*.json"), Format: "json"}, receivertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	r.nextLogs = logs
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Eventually(t, func() bool {
+		return logs.LogRecordCount() == 1
+	}, 10*time.Second, 5*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+}
+
+func TestFileReceiverNoFile(t *testing.T) {
+	r, err := newFileReceiver(&Config{Path: filepath.Join(t.TempDir(), "*.json"), Format: "json"}, receivertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	assert.ErrorContains(t, r.Start(context.Background(), componenttest.NewNopHost()), "no file matches")
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
+
+func writeRecords(t *testing.T, path string, format otlpfile.Format, compression otlpfile.Compression, records ...otlpfile.Record) {
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	w, err := otlpfile.NewWriter(f, compression)
+	require.NoError(t, err)
+	enc := otlpfile.NewEncoder(w, format)
+	for _, rec := range records {
+		require.NoError(t, enc.Encode(rec))
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+}
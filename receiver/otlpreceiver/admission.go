@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/tap"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// admissionRetryDelay is the delay after which the clients are asked to retry the refused requests.
+const admissionRetryDelay = time.Second
+
+// memoryLimiter is implemented by the memory limiter extension.
+type memoryLimiter interface {
+	MustRefuse() bool
+}
+
+// admission refuses the requests before they are read, when the memory limiter refuses data or
+// when the requests being processed already hold the maximum number of bytes.
+type admission struct {
+	limiter  memoryLimiter
+	maxBytes int64
+
+	mu            sync.Mutex
+	inFlightBytes int64
+}
+
+func newAdmission(cfg AdmissionConfig, host component.Host) (*admission, error) {
+	a := &admission{maxBytes: cfg.MaxInFlightBytes}
+	if cfg.MemoryLimiter == nil {
+		return a, nil
+	}
+	ext, ok := host.GetExtensions()[*cfg.MemoryLimiter]
+	if !ok {
+		return nil, fmt.Errorf("memory limiter extension %q not found", cfg.MemoryLimiter)
+	}
+	if a.limiter, ok = ext.(memoryLimiter); !ok {
+		return nil, fmt.Errorf("extension %q is not a memory limiter", cfg.MemoryLimiter)
+	}
+	return a, nil
+}
+
+// admit returns a ResourceExhausted error if the memory limiter refuses data.
+func (a *admission) admit() error {
+	if a.limiter != nil && a.limiter.MustRefuse() {
+		return resourceExhaustedError("memory usage is above the limits of the memory limiter")
+	}
+	return nil
+}
+
+// acquire reserves n bytes, returning a ResourceExhausted error if the requests in flight would
+// exceed the maximum.
+func (a *admission) acquire(n int64) error {
+	if a.maxBytes <= 0 {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.inFlightBytes+n > a.maxBytes {
+		return resourceExhaustedError(fmt.Sprintf("requests in flight exceed the maximum of %d bytes", a.maxBytes))
+	}
+	a.inFlightBytes += n
+	return nil
+}
+
+func (a *admission) release(n int64) {
+	if a.maxBytes <= 0 {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.inFlightBytes -= n
+}
+
+// resourceExhaustedError returns a ResourceExhausted error asking the clients to retry later.
+func resourceExhaustedError(msg string) error {
+	st := status.New(codes.ResourceExhausted, msg)
+	if withDetails, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(admissionRetryDelay)}); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// serverOptions returns the gRPC server options checking the memory limiter before the streams are
+// created, and bounding the bytes of the unary requests once they are read and unmarshaled. The
+// streams refused before they are created cannot carry the RetryInfo details. The batches of the
+// OTLP streams are admitted with admitBatch.
+func (a *admission) serverOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if a.limiter != nil {
+		opts = append(opts, grpc.InTapHandle(func(ctx context.Context, _ *tap.Info) (context.Context, error) {
+			return ctx, a.admit()
+		}))
+	}
+	if a.maxBytes > 0 {
+		opts = append(opts, grpc.ChainUnaryInterceptor(a.unaryInterceptor))
+	}
+	return opts
+}
+
+// admitBatch checks the memory limiter and reserves the bytes of a batch of an OTLP stream, once
+// read, returning the function releasing them.
+func (a *admission) admitBatch(_ context.Context, size int64) (func(), error) {
+	if err := a.admit(); err != nil {
+		return nil, err
+	}
+	if err := a.acquire(size); err != nil {
+		return nil, err
+	}
+	return func() { a.release(size) }, nil
+}
+
+func (a *admission) unaryInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var size int64
+	if sized, ok := req.(interface{ Size() int }); ok {
+		size = int64(sized.Size())
+	}
+	if err := a.acquire(size); err != nil {
+		return nil, err
+	}
+	defer a.release(size)
+	return handler(ctx, req)
+}
+
+// httpHandler checks the memory limiter before the requests are read, and bounds the bytes of the
+// requests as their bodies are read.
+func (a *admission) httpHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if err := a.admit(); err != nil {
+			writeAdmissionError(resp, req, err)
+			return
+		}
+		if a.maxBytes <= 0 {
+			next.ServeHTTP(resp, req)
+			return
+		}
+
+		body := &admittedBody{ReadCloser: req.Body, admission: a}
+		defer body.release()
+		// The declared length is reserved up front, the bytes read beyond it, for instance once
+		// decompressed, are reserved as they are read.
+		if req.ContentLength > 0 {
+			if err := body.reserve(req.ContentLength); err != nil {
+				writeAdmissionError(resp, req, err)
+				return
+			}
+		}
+		req.Body = body
+		next.ServeHTTP(resp, req)
+	})
+}
+
+func writeAdmissionError(resp http.ResponseWriter, req *http.Request, err error) {
+	enc := encoder(jsEncoder)
+	if getMimeTypeFromContentType(req.Header.Get("Content-Type")) == pbContentType {
+		enc = pbEncoder
+	}
+	resp.Header().Set("Retry-After", strconv.Itoa(int(admissionRetryDelay.Seconds())))
+	writeError(resp, enc, err, http.StatusTooManyRequests)
+}
+
+// admittedBody reserves the bytes of a request body as they are read.
+type admittedBody struct {
+	io.ReadCloser
+	admission *admission
+	read      int64
+	reserved  int64
+}
+
+func (b *admittedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	if b.read > b.reserved {
+		if reserveErr := b.reserve(b.read - b.reserved); reserveErr != nil {
+			return n, reserveErr
+		}
+	}
+	return n, err
+}
+
+func (b *admittedBody) reserve(n int64) error {
+	if err := b.admission.acquire(n); err != nil {
+		return err
+	}
+	b.reserved += n
+	return nil
+}
+
+func (b *admittedBody) release() {
+	b.admission.release(b.reserved)
+}
@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+var memoryLimiterID = component.MustNewID("memory_limiter")
+
+type fakeMemoryLimiter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	refuse atomic.Bool
+}
+
+func (ml *fakeMemoryLimiter) MustRefuse() bool {
+	return ml.refuse.Load()
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestNewAdmission(t *testing.T) {
+	host := &extensionsHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			memoryLimiterID: &fakeMemoryLimiter{},
+			component.MustNewID("other"): &struct {
+				component.StartFunc
+				component.ShutdownFunc
+			}{},
+		},
+	}
+
+	a, err := newAdmission(AdmissionConfig{}, host)
+	require.NoError(t, err)
+	assert.NoError(t, a.admit())
+	assert.Empty(t, a.serverOptions())
+
+	a, err = newAdmission(AdmissionConfig{MemoryLimiter: &memoryLimiterID}, host)
+	require.NoError(t, err)
+	assert.Len(t, a.serverOptions(), 1)
+
+	missing := component.MustNewID("missing")
+	_, err = newAdmission(AdmissionConfig{MemoryLimiter: &missing}, host)
+	assert.EqualError(t, err, `memory limiter extension "missing" not found`)
+
+	other := component.MustNewID("other")
+	_, err = newAdmission(AdmissionConfig{MemoryLimiter: &other}, host)
+	assert.EqualError(t, err, `extension "other" is not a memory limiter`)
+}
+
+func TestAdmissionInFlightBytes(t *testing.T) {
+	a := &admission{maxBytes: 10}
+	require.NoError(t, a.acquire(6))
+	err := a.acquire(5)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.NoError(t, a.acquire(4))
+	a.release(6)
+	assert.NoError(t, a.acquire(5))
+
+	// The bytes read beyond the declared length are reserved as they are read.
+	a = &admission{maxBytes: 10}
+	body := &admittedBody{ReadCloser: io.NopCloser(strings.NewReader(strings.Repeat("a", 20))), admission: a}
+	require.NoError(t, body.reserve(5))
+	_, err = io.ReadAll(body)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	body.release()
+	assert.Equal(t, int64(0), a.inFlightBytes)
+}
+
+func TestAdmissionBatch(t *testing.T) {
+	ml := &fakeMemoryLimiter{}
+	a := &admission{limiter: ml, maxBytes: 10}
+	release, err := a.admitBatch(context.Background(), 6)
+	require.NoError(t, err)
+	_, err = a.admitBatch(context.Background(), 5)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	release()
+	assert.Equal(t, int64(0), a.inFlightBytes)
+
+	ml.refuse.Store(true)
+	_, err = a.admitBatch(context.Background(), 1)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, int64(0), a.inFlightBytes)
+}
+
+func TestOTLPReceiverAdmission(t *testing.T) {
+	limiter := &fakeMemoryLimiter{}
+	host := &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{memoryLimiterID: limiter},
+	}
+	grpcAddr := testutil.GetAvailableLocalAddress(t)
+	httpAddr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = grpcAddr
+	cfg.HTTP.Endpoint = httpAddr
+	cfg.Admission = AdmissionConfig{MemoryLimiter: &memoryLimiterID, MaxInFlightBytes: 1024 * 1024}
+	sink := newErrOrSinkConsumer()
+	recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	cc, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+	grpcClient := ptraceotlp.NewGRPCClient(cc)
+	req := ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(2))
+	body, err := req.MarshalProto()
+	require.NoError(t, err)
+	url := "http://" + httpAddr + defaultTracesURLPath
+
+	_, err = grpcClient.Export(context.Background(), req)
+	require.NoError(t, err)
+	resp, err := http.Post(url, pbContentType, bytes.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, sink.AllTraces(), 2)
+
+	// The requests are refused while the memory limiter refuses data.
+	limiter.refuse.Store(true)
+	_, err = grpcClient.Export(context.Background(), req)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	resp, err = http.Post(url, pbContentType, bytes.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+	assert.Len(t, sink.AllTraces(), 2)
+}
+
+func TestOTLPReceiverAdmissionInFlightBytes(t *testing.T) {
+	grpcAddr := testutil.GetAvailableLocalAddress(t)
+	httpAddr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = grpcAddr
+	cfg.HTTP.Endpoint = httpAddr
+	cfg.Admission = AdmissionConfig{MaxInFlightBytes: 10}
+	sink := newErrOrSinkConsumer()
+	recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	cc, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+	req := ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(2))
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(context.Background(), req)
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	// The clients are asked to retry the requests refused once read.
+	require.Len(t, st.Details(), 1)
+	assert.IsType(t, &errdetails.RetryInfo{}, st.Details()[0])
+
+	// The requests larger than the maximum are refused before they are read.
+	body, err := req.MarshalProto()
+	require.NoError(t, err)
+	resp, err := http.Post("http://"+httpAddr+defaultTracesURLPath, pbContentType, bytes.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Empty(t, sink.AllTraces())
+}
@@ -21,6 +21,31 @@ const (
 	protoHTTP = "protocols::http"
 )
 
+// GRPCConfig is the configuration of the gRPC server of the receiver, accepting the unary OTLP
+// requests and, if enabled, the OTLP streams.
+type GRPCConfig struct {
+	*configgrpc.ServerConfig `mapstructure:",squash"`
+
+	// Streaming configures the OTLP streams accepted from the exporters, see StreamingConfig.
+	Streaming StreamingConfig `mapstructure:"streaming"`
+}
+
+// StreamingConfig configures the bidirectional gRPC streams, on which the batches only reference
+// the attributes, resources and scopes already sent on the stream. The stream protocol is
+// experimental and may change without backward compatibility.
+type StreamingConfig struct {
+	// Enabled registers the stream service on the gRPC server. Default is false.
+	Enabled bool `mapstructure:"enabled"`
+
+	// MaxDictionarySize is the maximum number of entries of the dictionaries of the attributes,
+	// and of the resources and scopes, of each stream. Default is 16384.
+	MaxDictionarySize int `mapstructure:"max_dictionary_size"`
+
+	// MaxInFlightBatches is the maximum number of batches of each stream processed concurrently.
+	// The next batches are not read from the stream until a batch is processed. Default is 16.
+	MaxInFlightBatches int `mapstructure:"max_in_flight_batches"`
+}
+
 type HTTPConfig struct {
 	*confighttp.ServerConfig `mapstructure:",squash"`
 
@@ -35,18 +60,60 @@ type HTTPConfig struct {
 
 	// The URL path to receive logs on. If omitted "/v1experimental/profiles" will be used.
 	ProfilesURLPath string `mapstructure:"profiles_url_path,omitempty"`
+
+	// The URL path accepting the websocket connections on which the clients send a sequence of
+	// export requests. If omitted the websocket endpoint is disabled.
+	WebSocketURLPath string `mapstructure:"websocket_url_path,omitempty"`
 }
 
 // Protocols is the configuration for the supported protocols.
 type Protocols struct {
-	GRPC *configgrpc.ServerConfig `mapstructure:"grpc"`
-	HTTP *HTTPConfig              `mapstructure:"http"`
+	GRPC *GRPCConfig `mapstructure:"grpc"`
+	HTTP *HTTPConfig `mapstructure:"http"`
+}
+
+// AdmissionConfig configures the admission of the requests, refused before they are read.
+type AdmissionConfig struct {
+	// MemoryLimiter is the ID of the memory limiter extension consulted before reading the requests.
+	// The requests are refused while the memory usage is above its limits.
+	MemoryLimiter *component.ID `mapstructure:"memory_limiter"`
+
+	// MaxInFlightBytes is the maximum total size of the requests being read and processed, across all
+	// the connections. If zero, the size is not limited.
+	MaxInFlightBytes int64 `mapstructure:"max_in_flight_bytes"`
+}
+
+// WALConfig configures the write-ahead log persisting the received data before the requests are
+// acknowledged.
+type WALConfig struct {
+	// Storage is the ID of the storage extension persisting the data. If nil, the data is passed
+	// to the next consumers before the requests are acknowledged.
+	Storage *component.ID `mapstructure:"storage"`
+
+	// MaxEntries is the maximum number of entries of the WAL, each entry holding the data of one
+	// request. The requests are refused with a retryable error while the WAL is full. If zero, the
+	// number of entries is not limited.
+	MaxEntries int64 `mapstructure:"max_entries"`
+
+	// MaxSize is the maximum total size of the entries of the WAL, in bytes. The requests are
+	// refused with a retryable error while the WAL is full. If zero, the size is not limited.
+	MaxSize int64 `mapstructure:"max_size"`
+
+	// NumConsumers is the number of entries passed to the next consumers concurrently. The
+	// entries are passed in order only if it is 1.
+	NumConsumers int `mapstructure:"num_consumers"`
 }
 
 // Config defines configuration for OTLP receiver.
 type Config struct {
 	// Protocols is the configuration for the supported protocols, currently gRPC and HTTP (Proto and JSON).
 	Protocols `mapstructure:"protocols"`
+
+	// Admission configures the admission of the requests of all the protocols, see AdmissionConfig.
+	Admission AdmissionConfig `mapstructure:"admission"`
+
+	// WAL configures the write-ahead log of the traces, metrics and logs, see WALConfig.
+	WAL WALConfig `mapstructure:"wal"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -57,6 +124,24 @@ func (cfg *Config) Validate() error {
 	if cfg.GRPC == nil && cfg.HTTP == nil {
 		return errors.New("must specify at least one protocol when using the OTLP receiver")
 	}
+	if cfg.GRPC != nil && cfg.GRPC.Streaming.MaxDictionarySize < 0 {
+		return errors.New("streaming max_dictionary_size must not be negative")
+	}
+	if cfg.GRPC != nil && cfg.GRPC.Streaming.MaxInFlightBatches <= 0 {
+		return errors.New("streaming max_in_flight_batches must be positive")
+	}
+	if cfg.Admission.MaxInFlightBytes < 0 {
+		return errors.New("admission max_in_flight_bytes must not be negative")
+	}
+	if cfg.WAL.MaxEntries < 0 {
+		return errors.New("wal max_entries must not be negative")
+	}
+	if cfg.WAL.MaxSize < 0 {
+		return errors.New("wal max_size must not be negative")
+	}
+	if cfg.WAL.NumConsumers <= 0 {
+		return errors.New("wal num_consumers must be positive")
+	}
 	return nil
 }
 
@@ -86,6 +171,11 @@ func (cfg *Config) Unmarshal(conf *confmap.Conf) error {
 		if cfg.HTTP.LogsURLPath, err = sanitizeURLPath(cfg.HTTP.LogsURLPath); err != nil {
 			return err
 		}
+		if cfg.HTTP.WebSocketURLPath != "" {
+			if cfg.HTTP.WebSocketURLPath, err = sanitizeURLPath(cfg.HTTP.WebSocketURLPath); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -86,34 +86,41 @@ func TestUnmarshalConfig(t *testing.T) {
 	assert.Equal(t,
 		&Config{
 			Protocols: Protocols{
-				GRPC: &configgrpc.ServerConfig{
-					NetAddr: confignet.AddrConfig{
-						Endpoint:  "0.0.0.0:4317",
-						Transport: confignet.TransportTypeTCP,
-					},
-					TLSSetting: &configtls.ServerConfig{
-						Config: configtls.Config{
-							CertFile: "test.crt",
-							KeyFile:  "test.key",
+				GRPC: &GRPCConfig{
+					ServerConfig: &configgrpc.ServerConfig{
+						NetAddr: confignet.AddrConfig{
+							Endpoint:  "0.0.0.0:4317",
+							Transport: confignet.TransportTypeTCP,
 						},
-					},
-					MaxRecvMsgSizeMiB:    32,
-					MaxConcurrentStreams: 16,
-					ReadBufferSize:       1024,
-					WriteBufferSize:      1024,
-					Keepalive: &configgrpc.KeepaliveServerConfig{
-						ServerParameters: &configgrpc.KeepaliveServerParameters{
-							MaxConnectionIdle:     11 * time.Second,
-							MaxConnectionAge:      12 * time.Second,
-							MaxConnectionAgeGrace: 13 * time.Second,
-							Time:                  30 * time.Second,
-							Timeout:               5 * time.Second,
+						TLSSetting: &configtls.ServerConfig{
+							Config: configtls.Config{
+								CertFile: "test.crt",
+								KeyFile:  "test.key",
+							},
 						},
-						EnforcementPolicy: &configgrpc.KeepaliveEnforcementPolicy{
-							MinTime:             10 * time.Second,
-							PermitWithoutStream: true,
+						MaxRecvMsgSizeMiB:    32,
+						MaxConcurrentStreams: 16,
+						ReadBufferSize:       1024,
+						WriteBufferSize:      1024,
+						Keepalive: &configgrpc.KeepaliveServerConfig{
+							ServerParameters: &configgrpc.KeepaliveServerParameters{
+								MaxConnectionIdle:     11 * time.Second,
+								MaxConnectionAge:      12 * time.Second,
+								MaxConnectionAgeGrace: 13 * time.Second,
+								Time:                  30 * time.Second,
+								Timeout:               5 * time.Second,
+							},
+							EnforcementPolicy: &configgrpc.KeepaliveEnforcementPolicy{
+								MinTime:             10 * time.Second,
+								PermitWithoutStream: true,
+							},
 						},
 					},
+					Streaming: StreamingConfig{
+						Enabled:            true,
+						MaxDictionarySize:  1024,
+						MaxInFlightBatches: 8,
+					},
 				},
 				HTTP: &HTTPConfig{
 					ServerConfig: &confighttp.ServerConfig{
@@ -129,12 +136,17 @@ func TestUnmarshalConfig(t *testing.T) {
 							MaxAge:         7200,
 						},
 					},
-					TracesURLPath:   "/traces",
-					MetricsURLPath:  "/v2/metrics",
-					LogsURLPath:     "/log/ingest",
-					ProfilesURLPath: "/v1/cpu",
+					TracesURLPath:    "/traces",
+					MetricsURLPath:   "/v2/metrics",
+					LogsURLPath:      "/log/ingest",
+					ProfilesURLPath:  "/v1/cpu",
+					WebSocketURLPath: "/v1/websocket",
 				},
 			},
+			WAL: WALConfig{
+				MaxEntries:   defaultWALMaxEntries,
+				NumConsumers: defaultWALNumConsumers,
+			},
 		}, cfg)
 
 }
@@ -148,12 +160,18 @@ func TestUnmarshalConfigUnix(t *testing.T) {
 	assert.Equal(t,
 		&Config{
 			Protocols: Protocols{
-				GRPC: &configgrpc.ServerConfig{
-					NetAddr: confignet.AddrConfig{
-						Endpoint:  "/tmp/grpc_otlp.sock",
-						Transport: confignet.TransportTypeUnix,
+				GRPC: &GRPCConfig{
+					ServerConfig: &configgrpc.ServerConfig{
+						NetAddr: confignet.AddrConfig{
+							Endpoint:  "/tmp/grpc_otlp.sock",
+							Transport: confignet.TransportTypeUnix,
+						},
+						ReadBufferSize: 512 * 1024,
+					},
+					Streaming: StreamingConfig{
+						MaxDictionarySize:  defaultMaxDictionarySize,
+						MaxInFlightBatches: defaultMaxInFlightBatches,
 					},
-					ReadBufferSize: 512 * 1024,
 				},
 				HTTP: &HTTPConfig{
 					ServerConfig: &confighttp.ServerConfig{
@@ -165,6 +183,10 @@ func TestUnmarshalConfigUnix(t *testing.T) {
 					ProfilesURLPath: defaultProfilesURLPath,
 				},
 			},
+			WAL: WALConfig{
+				MaxEntries:   defaultWALMaxEntries,
+				NumConsumers: defaultWALNumConsumers,
+			},
 		}, cfg)
 }
 
@@ -193,6 +215,47 @@ func TestUnmarshalConfigEmptyProtocols(t *testing.T) {
 	assert.EqualError(t, component.ValidateConfig(cfg), "must specify at least one protocol when using the OTLP receiver")
 }
 
+func TestValidateConfigStreaming(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	assert.NoError(t, component.ValidateConfig(cfg))
+	cfg.GRPC.Streaming.MaxDictionarySize = -1
+	assert.EqualError(t, component.ValidateConfig(cfg), "streaming max_dictionary_size must not be negative")
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.GRPC.Streaming.MaxInFlightBatches = 0
+	assert.EqualError(t, component.ValidateConfig(cfg), "streaming max_in_flight_batches must be positive")
+}
+
+func TestUnmarshalConfigAdmission(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "admission.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	memoryLimiter := component.MustNewID("memory_limiter")
+	assert.Equal(t, AdmissionConfig{MemoryLimiter: &memoryLimiter, MaxInFlightBytes: 67108864}, cfg.Admission)
+
+	cfg.Admission.MaxInFlightBytes = -1
+	assert.EqualError(t, component.ValidateConfig(cfg), "admission max_in_flight_bytes must not be negative")
+}
+
+func TestUnmarshalConfigWAL(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "wal.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	assert.NoError(t, component.UnmarshalConfig(cm, cfg))
+	fileStorage := component.MustNewID("file_storage")
+	assert.Equal(t, WALConfig{Storage: &fileStorage, MaxEntries: 100, MaxSize: 1048576, NumConsumers: 2}, cfg.WAL)
+
+	cfg.WAL.MaxEntries = -1
+	assert.EqualError(t, component.ValidateConfig(cfg), "wal max_entries must not be negative")
+	cfg.WAL.MaxEntries = 0
+	cfg.WAL.MaxSize = -1
+	assert.EqualError(t, component.ValidateConfig(cfg), "wal max_size must not be negative")
+	cfg.WAL.MaxSize = 0
+	cfg.WAL.NumConsumers = 0
+	assert.EqualError(t, component.ValidateConfig(cfg), "wal num_consumers must be positive")
+}
+
 func TestUnmarshalConfigInvalidSignalPath(t *testing.T) {
 	tests := []struct {
 		name       string
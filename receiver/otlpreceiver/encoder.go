@@ -5,6 +5,8 @@ package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlprecei
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
 
 	"github.com/gogo/protobuf/jsonpb"
 	"github.com/gogo/protobuf/proto"
@@ -17,13 +19,15 @@ import (
 )
 
 const (
-	pbContentType   = "application/x-protobuf"
-	jsonContentType = "application/json"
+	pbContentType     = "application/x-protobuf"
+	jsonContentType   = "application/json"
+	ndjsonContentType = "application/x-ndjson"
 )
 
 var (
 	pbEncoder       = &protoEncoder{}
 	jsEncoder       = &jsonEncoder{}
+	ndjsEncoder     = &ndjsonEncoder{}
 	jsonPbMarshaler = &jsonpb.Marshaler{}
 )
 
@@ -93,30 +97,39 @@ func (protoEncoder) contentType() string {
 	return pbContentType
 }
 
+// jsonEncoder decodes a single request, or a JSON array of requests merged into a single request.
 type jsonEncoder struct{}
 
 func (jsonEncoder) unmarshalTracesRequest(buf []byte) (ptraceotlp.ExportRequest, error) {
-	req := ptraceotlp.NewExportRequest()
-	err := req.UnmarshalJSON(buf)
-	return req, err
+	reqs, err := splitJSONArray(buf)
+	if err != nil {
+		return ptraceotlp.NewExportRequest(), err
+	}
+	return unmarshalJSONRequests(reqs, ptraceotlp.NewExportRequest, ptraceotlp.ExportRequest.UnmarshalJSON, mergeTracesRequests)
 }
 
 func (jsonEncoder) unmarshalMetricsRequest(buf []byte) (pmetricotlp.ExportRequest, error) {
-	req := pmetricotlp.NewExportRequest()
-	err := req.UnmarshalJSON(buf)
-	return req, err
+	reqs, err := splitJSONArray(buf)
+	if err != nil {
+		return pmetricotlp.NewExportRequest(), err
+	}
+	return unmarshalJSONRequests(reqs, pmetricotlp.NewExportRequest, pmetricotlp.ExportRequest.UnmarshalJSON, mergeMetricsRequests)
 }
 
 func (jsonEncoder) unmarshalLogsRequest(buf []byte) (plogotlp.ExportRequest, error) {
-	req := plogotlp.NewExportRequest()
-	err := req.UnmarshalJSON(buf)
-	return req, err
+	reqs, err := splitJSONArray(buf)
+	if err != nil {
+		return plogotlp.NewExportRequest(), err
+	}
+	return unmarshalJSONRequests(reqs, plogotlp.NewExportRequest, plogotlp.ExportRequest.UnmarshalJSON, mergeLogsRequests)
 }
 
 func (jsonEncoder) unmarshalProfilesRequest(buf []byte) (pprofileotlp.ExportRequest, error) {
-	req := pprofileotlp.NewExportRequest()
-	err := req.UnmarshalJSON(buf)
-	return req, err
+	reqs, err := splitJSONArray(buf)
+	if err != nil {
+		return pprofileotlp.NewExportRequest(), err
+	}
+	return unmarshalJSONRequests(reqs, pprofileotlp.NewExportRequest, pprofileotlp.ExportRequest.UnmarshalJSON, mergeProfilesRequests)
 }
 
 func (jsonEncoder) marshalTracesResponse(resp ptraceotlp.ExportResponse) ([]byte, error) {
@@ -144,3 +157,98 @@ func (jsonEncoder) marshalStatus(resp *spb.Status) ([]byte, error) {
 func (jsonEncoder) contentType() string {
 	return jsonContentType
 }
+
+// ndjsonEncoder decodes newline-delimited JSON requests merged into a single request. The responses
+// are encoded as JSON.
+type ndjsonEncoder struct {
+	jsonEncoder
+}
+
+func (ndjsonEncoder) unmarshalTracesRequest(buf []byte) (ptraceotlp.ExportRequest, error) {
+	return unmarshalJSONRequests(splitNDJSON(buf), ptraceotlp.NewExportRequest, ptraceotlp.ExportRequest.UnmarshalJSON, mergeTracesRequests)
+}
+
+func (ndjsonEncoder) unmarshalMetricsRequest(buf []byte) (pmetricotlp.ExportRequest, error) {
+	return unmarshalJSONRequests(splitNDJSON(buf), pmetricotlp.NewExportRequest, pmetricotlp.ExportRequest.UnmarshalJSON, mergeMetricsRequests)
+}
+
+func (ndjsonEncoder) unmarshalLogsRequest(buf []byte) (plogotlp.ExportRequest, error) {
+	return unmarshalJSONRequests(splitNDJSON(buf), plogotlp.NewExportRequest, plogotlp.ExportRequest.UnmarshalJSON, mergeLogsRequests)
+}
+
+func (ndjsonEncoder) unmarshalProfilesRequest(buf []byte) (pprofileotlp.ExportRequest, error) {
+	return unmarshalJSONRequests(splitNDJSON(buf), pprofileotlp.NewExportRequest, pprofileotlp.ExportRequest.UnmarshalJSON, mergeProfilesRequests)
+}
+
+// jsonRequest is one of the JSON requests of a body.
+type jsonRequest struct {
+	// location identifies the request in the error messages, empty if the body holds a single request.
+	location string
+	buf      []byte
+}
+
+// splitJSONArray returns the elements of a JSON array of requests, or the body itself if it is not
+// an array.
+func splitJSONArray(buf []byte) ([]jsonRequest, error) {
+	if trimmed := bytes.TrimSpace(buf); len(trimmed) == 0 || trimmed[0] != '[' {
+		return []jsonRequest{{buf: buf}}, nil
+	}
+	var elems []json.RawMessage
+	if err := json.Unmarshal(buf, &elems); err != nil {
+		return nil, fmt.Errorf("invalid array of requests: %w", err)
+	}
+	reqs := make([]jsonRequest, len(elems))
+	for i, elem := range elems {
+		reqs[i] = jsonRequest{location: fmt.Sprintf("array element %d", i), buf: elem}
+	}
+	return reqs, nil
+}
+
+// splitNDJSON returns the lines of a newline-delimited body, skipping the blank lines.
+func splitNDJSON(buf []byte) []jsonRequest {
+	var reqs []jsonRequest
+	for i, line := range bytes.Split(buf, []byte("\n")) {
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			reqs = append(reqs, jsonRequest{location: fmt.Sprintf("line %d", i+1), buf: line})
+		}
+	}
+	return reqs
+}
+
+// unmarshalJSONRequests unmarshals the requests and merges them into the first one, so that they are
+// consumed with a single call.
+func unmarshalJSONRequests[T any](reqs []jsonRequest, newRequest func() T, unmarshal func(T, []byte) error, merge func(dest, src T)) (T, error) {
+	dest := newRequest()
+	for i, r := range reqs {
+		req := dest
+		if i > 0 {
+			req = newRequest()
+		}
+		if err := unmarshal(req, r.buf); err != nil {
+			if r.location != "" {
+				err = fmt.Errorf("%s: %w", r.location, err)
+			}
+			return dest, err
+		}
+		if i > 0 {
+			merge(dest, req)
+		}
+	}
+	return dest, nil
+}
+
+func mergeTracesRequests(dest, src ptraceotlp.ExportRequest) {
+	src.Traces().ResourceSpans().MoveAndAppendTo(dest.Traces().ResourceSpans())
+}
+
+func mergeMetricsRequests(dest, src pmetricotlp.ExportRequest) {
+	src.Metrics().ResourceMetrics().MoveAndAppendTo(dest.Metrics().ResourceMetrics())
+}
+
+func mergeLogsRequests(dest, src plogotlp.ExportRequest) {
+	src.Logs().ResourceLogs().MoveAndAppendTo(dest.Logs().ResourceLogs())
+}
+
+func mergeProfilesRequests(dest, src pprofileotlp.ExportRequest) {
+	src.Profiles().ResourceProfiles().MoveAndAppendTo(dest.Profiles().ResourceProfiles())
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestJSONEncoderArray(t *testing.T) {
+	first, err := ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(2)).MarshalJSON()
+	require.NoError(t, err)
+	second, err := ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(3)).MarshalJSON()
+	require.NoError(t, err)
+
+	req, err := jsEncoder.unmarshalTracesRequest(bytes.Join([][]byte{[]byte(" ["), first, []byte(","), second, []byte("]")}, nil))
+	require.NoError(t, err)
+	assert.Equal(t, 2, req.Traces().ResourceSpans().Len())
+	assert.Equal(t, 5, req.Traces().SpanCount())
+
+	// A single request is still accepted.
+	req, err = jsEncoder.unmarshalTracesRequest(first)
+	require.NoError(t, err)
+	assert.Equal(t, 2, req.Traces().SpanCount())
+
+	_, err = jsEncoder.unmarshalTracesRequest([]byte("[{}, {"))
+	assert.ErrorContains(t, err, "invalid array of requests")
+	_, err = jsEncoder.unmarshalTracesRequest([]byte(`[{}, {"resourceSpans": 1}]`))
+	assert.ErrorContains(t, err, "array element 1: ")
+}
+
+func TestNDJSONEncoder(t *testing.T) {
+	traces, err := ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(2)).MarshalJSON()
+	require.NoError(t, err)
+	metrics, err := pmetricotlp.NewExportRequestFromMetrics(testdata.GenerateMetrics(2)).MarshalJSON()
+	require.NoError(t, err)
+	logs, err := plogotlp.NewExportRequestFromLogs(testdata.GenerateLogs(2)).MarshalJSON()
+	require.NoError(t, err)
+	ndjson := func(lines ...[]byte) []byte {
+		return bytes.Join(lines, []byte("\n"))
+	}
+
+	tr, err := ndjsEncoder.unmarshalTracesRequest(ndjson(traces, nil, traces, traces, nil))
+	require.NoError(t, err)
+	assert.Equal(t, 3, tr.Traces().ResourceSpans().Len())
+	assert.Equal(t, 6, tr.Traces().SpanCount())
+
+	mr, err := ndjsEncoder.unmarshalMetricsRequest(ndjson(metrics, metrics))
+	require.NoError(t, err)
+	assert.Equal(t, 4, mr.Metrics().MetricCount())
+
+	lr, err := ndjsEncoder.unmarshalLogsRequest(ndjson(logs, []byte("\r"), logs))
+	require.NoError(t, err)
+	assert.Equal(t, 4, lr.Logs().LogRecordCount())
+
+	// The errors report the line of the invalid request.
+	_, err = ndjsEncoder.unmarshalLogsRequest(ndjson(logs, nil, []byte("{")))
+	assert.ErrorContains(t, err, "line 3: ")
+
+	// The responses are JSON.
+	assert.Equal(t, jsonContentType, ndjsEncoder.contentType())
+}
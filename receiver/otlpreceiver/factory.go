@@ -25,6 +25,12 @@ const (
 	defaultMetricsURLPath  = "/v1/metrics"
 	defaultLogsURLPath     = "/v1/logs"
 	defaultProfilesURLPath = "/v1experimental/profiles"
+
+	defaultMaxDictionarySize  = 16384
+	defaultMaxInFlightBatches = 16
+
+	defaultWALMaxEntries   = 10000
+	defaultWALNumConsumers = 10
 )
 
 // NewFactory creates a new OTLP receiver factory.
@@ -43,13 +49,19 @@ func NewFactory() receiver.Factory {
 func createDefaultConfig() component.Config {
 	return &Config{
 		Protocols: Protocols{
-			GRPC: &configgrpc.ServerConfig{
-				NetAddr: confignet.AddrConfig{
-					Endpoint:  localhostgate.EndpointForPort(grpcPort),
-					Transport: confignet.TransportTypeTCP,
+			GRPC: &GRPCConfig{
+				ServerConfig: &configgrpc.ServerConfig{
+					NetAddr: confignet.AddrConfig{
+						Endpoint:  localhostgate.EndpointForPort(grpcPort),
+						Transport: confignet.TransportTypeTCP,
+					},
+					// We almost write 0 bytes, so no need to tune WriteBufferSize.
+					ReadBufferSize: 512 * 1024,
+				},
+				Streaming: StreamingConfig{
+					MaxDictionarySize:  defaultMaxDictionarySize,
+					MaxInFlightBatches: defaultMaxInFlightBatches,
 				},
-				// We almost write 0 bytes, so no need to tune WriteBufferSize.
-				ReadBufferSize: 512 * 1024,
 			},
 			HTTP: &HTTPConfig{
 				ServerConfig: &confighttp.ServerConfig{
@@ -61,6 +73,10 @@ func createDefaultConfig() component.Config {
 				ProfilesURLPath: defaultProfilesURLPath,
 			},
 		},
+		WAL: WALConfig{
+			MaxEntries:   defaultWALMaxEntries,
+			NumConsumers: defaultWALNumConsumers,
+		},
 	}
 }
 
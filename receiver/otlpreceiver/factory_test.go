@@ -52,10 +52,16 @@ func TestCreateSameReceiver(t *testing.T) {
 
 func TestCreateTracesReceiver(t *testing.T) {
 	factory := NewFactory()
-	defaultGRPCSettings := &configgrpc.ServerConfig{
-		NetAddr: confignet.AddrConfig{
-			Endpoint:  testutil.GetAvailableLocalAddress(t),
-			Transport: confignet.TransportTypeTCP,
+	defaultGRPCSettings := &GRPCConfig{
+		ServerConfig: &configgrpc.ServerConfig{
+			NetAddr: confignet.AddrConfig{
+				Endpoint:  testutil.GetAvailableLocalAddress(t),
+				Transport: confignet.TransportTypeTCP,
+			},
+		},
+		Streaming: StreamingConfig{
+			MaxDictionarySize:  defaultMaxDictionarySize,
+			MaxInFlightBatches: defaultMaxInFlightBatches,
 		},
 	}
 	defaultHTTPSettings := &HTTPConfig{
@@ -89,10 +95,12 @@ func TestCreateTracesReceiver(t *testing.T) {
 			name: "invalid_grpc_port",
 			cfg: &Config{
 				Protocols: Protocols{
-					GRPC: &configgrpc.ServerConfig{
-						NetAddr: confignet.AddrConfig{
-							Endpoint:  "localhost:112233",
-							Transport: confignet.TransportTypeTCP,
+					GRPC: &GRPCConfig{
+						ServerConfig: &configgrpc.ServerConfig{
+							NetAddr: confignet.AddrConfig{
+								Endpoint:  "localhost:112233",
+								Transport: confignet.TransportTypeTCP,
+							},
 						},
 					},
 					HTTP: defaultHTTPSettings,
@@ -147,10 +155,16 @@ func TestCreateTracesReceiver(t *testing.T) {
 
 func TestCreateMetricReceiver(t *testing.T) {
 	factory := NewFactory()
-	defaultGRPCSettings := &configgrpc.ServerConfig{
-		NetAddr: confignet.AddrConfig{
-			Endpoint:  testutil.GetAvailableLocalAddress(t),
-			Transport: confignet.TransportTypeTCP,
+	defaultGRPCSettings := &GRPCConfig{
+		ServerConfig: &configgrpc.ServerConfig{
+			NetAddr: confignet.AddrConfig{
+				Endpoint:  testutil.GetAvailableLocalAddress(t),
+				Transport: confignet.TransportTypeTCP,
+			},
+		},
+		Streaming: StreamingConfig{
+			MaxDictionarySize:  defaultMaxDictionarySize,
+			MaxInFlightBatches: defaultMaxInFlightBatches,
 		},
 	}
 	defaultHTTPSettings := &HTTPConfig{
@@ -184,10 +198,12 @@ func TestCreateMetricReceiver(t *testing.T) {
 			name: "invalid_grpc_address",
 			cfg: &Config{
 				Protocols: Protocols{
-					GRPC: &configgrpc.ServerConfig{
-						NetAddr: confignet.AddrConfig{
-							Endpoint:  "327.0.0.1:1122",
-							Transport: confignet.TransportTypeTCP,
+					GRPC: &GRPCConfig{
+						ServerConfig: &configgrpc.ServerConfig{
+							NetAddr: confignet.AddrConfig{
+								Endpoint:  "327.0.0.1:1122",
+								Transport: confignet.TransportTypeTCP,
+							},
 						},
 					},
 					HTTP: defaultHTTPSettings,
@@ -242,10 +258,16 @@ func TestCreateMetricReceiver(t *testing.T) {
 
 func TestCreateLogReceiver(t *testing.T) {
 	factory := NewFactory()
-	defaultGRPCSettings := &configgrpc.ServerConfig{
-		NetAddr: confignet.AddrConfig{
-			Endpoint:  testutil.GetAvailableLocalAddress(t),
-			Transport: confignet.TransportTypeTCP,
+	defaultGRPCSettings := &GRPCConfig{
+		ServerConfig: &configgrpc.ServerConfig{
+			NetAddr: confignet.AddrConfig{
+				Endpoint:  testutil.GetAvailableLocalAddress(t),
+				Transport: confignet.TransportTypeTCP,
+			},
+		},
+		Streaming: StreamingConfig{
+			MaxDictionarySize:  defaultMaxDictionarySize,
+			MaxInFlightBatches: defaultMaxInFlightBatches,
 		},
 	}
 	defaultHTTPSettings := &HTTPConfig{
@@ -279,10 +301,12 @@ func TestCreateLogReceiver(t *testing.T) {
 			name: "invalid_grpc_address",
 			cfg: &Config{
 				Protocols: Protocols{
-					GRPC: &configgrpc.ServerConfig{
-						NetAddr: confignet.AddrConfig{
-							Endpoint:  "327.0.0.1:1122",
-							Transport: confignet.TransportTypeTCP,
+					GRPC: &GRPCConfig{
+						ServerConfig: &configgrpc.ServerConfig{
+							NetAddr: confignet.AddrConfig{
+								Endpoint:  "327.0.0.1:1122",
+								Transport: confignet.TransportTypeTCP,
+							},
 						},
 					},
 					HTTP: defaultHTTPSettings,
@@ -337,10 +361,16 @@ func TestCreateLogReceiver(t *testing.T) {
 
 func TestCreateProfileReceiver(t *testing.T) {
 	factory := NewFactory()
-	defaultGRPCSettings := &configgrpc.ServerConfig{
-		NetAddr: confignet.AddrConfig{
-			Endpoint:  testutil.GetAvailableLocalAddress(t),
-			Transport: confignet.TransportTypeTCP,
+	defaultGRPCSettings := &GRPCConfig{
+		ServerConfig: &configgrpc.ServerConfig{
+			NetAddr: confignet.AddrConfig{
+				Endpoint:  testutil.GetAvailableLocalAddress(t),
+				Transport: confignet.TransportTypeTCP,
+			},
+		},
+		Streaming: StreamingConfig{
+			MaxDictionarySize:  defaultMaxDictionarySize,
+			MaxInFlightBatches: defaultMaxInFlightBatches,
 		},
 	}
 	defaultHTTPSettings := &HTTPConfig{
@@ -374,10 +404,12 @@ func TestCreateProfileReceiver(t *testing.T) {
 			name: "invalid_grpc_address",
 			cfg: &Config{
 				Protocols: Protocols{
-					GRPC: &configgrpc.ServerConfig{
-						NetAddr: confignet.AddrConfig{
-							Endpoint:  "327.0.0.1:1122",
-							Transport: confignet.TransportTypeTCP,
+					GRPC: &GRPCConfig{
+						ServerConfig: &configgrpc.ServerConfig{
+							NetAddr: confignet.AddrConfig{
+								Endpoint:  "327.0.0.1:1122",
+								Transport: confignet.TransportTypeTCP,
+							},
 						},
 					},
 					HTTP: defaultHTTPSettings,
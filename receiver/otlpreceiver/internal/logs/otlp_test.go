@@ -72,6 +72,16 @@ func TestExport_PermanentErrorConsumer(t *testing.T) {
 	assert.Equal(t, plogotlp.ExportResponse{}, resp)
 }
 
+func TestExport_PartialErrorConsumer(t *testing.T) {
+	req := plogotlp.NewExportRequestFromLogs(testdata.GenerateLogs(2))
+
+	logClient := makeLogsServiceClient(t, consumertest.NewErr(consumererror.NewPartial(errors.New("my error"), 1)))
+	resp, err := logClient.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.PartialSuccess().RejectedLogRecords())
+	assert.Equal(t, "Partial error: my error", resp.PartialSuccess().ErrorMessage())
+}
+
 func makeLogsServiceClient(t *testing.T, lc consumer.Logs) plogotlp.GRPCClient {
 	addr := otlpReceiverOnGRPCServer(t, lc)
 	cc, err := grpc.NewClient(addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
@@ -40,5 +41,12 @@ func (r *Receiver) Export(ctx context.Context, req pprofileotlp.ExportRequest) (
 	err := r.nextConsumer.ConsumeProfiles(ctx, ld)
 	r.obsreport.EndProfilesOp(ctx, dataFormatProtobuf, numProfiles, err)
 
+	if consumererror.IsPartial(err) {
+		resp := pprofileotlp.NewExportResponse()
+		resp.PartialSuccess().SetRejectedProfiles(int64(consumererror.RejectedCount(err)))
+		resp.PartialSuccess().SetErrorMessage(err.Error())
+		return resp, nil
+	}
+
 	return pprofileotlp.NewExportResponse(), err
 }
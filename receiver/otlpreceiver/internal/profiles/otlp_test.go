@@ -16,6 +16,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
 	"go.opentelemetry.io/collector/pdata/testdata"
@@ -57,6 +58,16 @@ func TestExport_ErrorConsumer(t *testing.T) {
 	assert.Equal(t, pprofileotlp.ExportResponse{}, resp)
 }
 
+func TestExport_PartialErrorConsumer(t *testing.T) {
+	req := pprofileotlp.NewExportRequestFromProfiles(testdata.GenerateProfiles(2))
+
+	profileClient := makeProfilesServiceClient(t, consumertest.NewErr(consumererror.NewPartial(errors.New("my error"), 1)))
+	resp, err := profileClient.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.PartialSuccess().RejectedProfiles())
+	assert.Equal(t, "Partial error: my error", resp.PartialSuccess().ErrorMessage())
+}
+
 func makeProfilesServiceClient(t *testing.T, lc consumer.Profiles) pprofileotlp.GRPCClient {
 	addr := otlpReceiverOnGRPCServer(t, lc)
 	cc, err := grpc.Dial(addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
@@ -69,6 +69,25 @@ func TestExport_PermanentErrorConsumer(t *testing.T) {
 	assert.Equal(t, ptraceotlp.ExportResponse{}, resp)
 }
 
+func TestExport_PartialErrorConsumer(t *testing.T) {
+	req := ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(2))
+
+	traceClient := makeTraceServiceClient(t, consumertest.NewErr(consumererror.NewPartial(errors.New("my error"), 1)))
+	resp, err := traceClient.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.PartialSuccess().RejectedSpans())
+	assert.Equal(t, "Partial error: my error", resp.PartialSuccess().ErrorMessage())
+}
+
+func TestExport_JoinedPartialAndPermanentErrorConsumer(t *testing.T) {
+	req := ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(2))
+
+	err := errors.Join(consumererror.NewPartial(errors.New("my error"), 1), consumererror.NewPermanent(errors.New("other error")))
+	traceClient := makeTraceServiceClient(t, consumertest.NewErr(err))
+	_, err = traceClient.Export(context.Background(), req)
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
 func makeTraceServiceClient(t *testing.T, tc consumer.Traces) ptraceotlp.GRPCClient {
 	addr := otlpReceiverOnGRPCServer(t, tc)
 	cc, err := grpc.NewClient(addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
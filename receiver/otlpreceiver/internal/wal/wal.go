@@ -0,0 +1,454 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package wal implements the write-ahead log of the OTLP receiver: the received data is persisted
+// before the requests are acknowledged, and passed to the next consumers from the log.
+package wal // import "go.opentelemetry.io/collector/receiver/otlpreceiver/internal/wal"
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	// The indexes of the first entry not consumed yet and of the next entry to write.
+	readIndexKey  = "ri"
+	writeIndexKey = "wi"
+	// The total size of the entries.
+	sizeKey = "sz"
+)
+
+// errFull is returned when the data cannot be written because the WAL is full.
+var errFull = errors.New("WAL is full")
+
+// The signal of an entry, stored in its first byte.
+const (
+	signalTraces byte = iota + 1
+	signalMetrics
+	signalLogs
+)
+
+var (
+	tracesMarshaler    = &ptrace.ProtoMarshaler{}
+	tracesUnmarshaler  = &ptrace.ProtoUnmarshaler{}
+	metricsMarshaler   = &pmetric.ProtoMarshaler{}
+	metricsUnmarshaler = &pmetric.ProtoUnmarshaler{}
+	logsMarshaler      = &plog.ProtoMarshaler{}
+	logsUnmarshaler    = &plog.ProtoUnmarshaler{}
+)
+
+// Settings configures the bounds of a WAL.
+type Settings struct {
+	// MaxEntries is the maximum number of entries of the WAL. There is no limit if zero.
+	MaxEntries int64
+	// MaxSize is the maximum total size of the entries of the WAL, in bytes. There is no limit
+	// if zero.
+	MaxSize int64
+	// NumConsumers is the number of entries consumed concurrently.
+	NumConsumers int
+}
+
+// WAL persists the received data as the entries of a storage client, and consumes them in the
+// order they were written, with up to Settings.NumConsumers entries consumed concurrently. The
+// entries are deleted once consumed, or rejected with a permanent or partial error; the other
+// errors are retried until the WAL is shut down, the entries left being replayed when the WAL is
+// started again. The data is refused with a retryable error while the WAL is full.
+//
+// The entries keep the metadata of the clients, which the next consumers read with
+// client.FromContext. The addresses and the authentication data of the clients are not kept.
+type WAL struct {
+	client      storage.Client
+	logger      *zap.Logger
+	settings    Settings
+	nextTraces  consumer.Traces
+	nextMetrics consumer.Metrics
+	nextLogs    consumer.Logs
+
+	// retryInterval is the initial interval between the attempts to consume an entry.
+	retryInterval time.Duration
+
+	mu         sync.Mutex
+	readIndex  uint64
+	writeIndex uint64
+	size       int64
+	// consumed has the sizes of the entries consumed but not deleted yet: the entries are deleted
+	// in order, together with the update of the read index, so that no entry is left below it.
+	consumed map[uint64]int64
+
+	// written is notified when an entry is written.
+	written chan struct{}
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// New returns a WAL storing its entries with the client. The consumers of the signals not
+// received may be nil.
+func New(client storage.Client, logger *zap.Logger, settings Settings, nextTraces consumer.Traces, nextMetrics consumer.Metrics, nextLogs consumer.Logs) *WAL {
+	return &WAL{
+		client:        client,
+		logger:        logger,
+		settings:      settings,
+		nextTraces:    nextTraces,
+		nextMetrics:   nextMetrics,
+		nextLogs:      nextLogs,
+		retryInterval: time.Second,
+		consumed:      map[uint64]int64{},
+		written:       make(chan struct{}, 1),
+		done:          make(chan struct{}),
+	}
+}
+
+// Start loads the indexes of the entries and starts consuming them, beginning with the entries
+// left by the previous run.
+func (w *WAL) Start(ctx context.Context) error {
+	readOp, writeOp, sizeOp := storage.GetOperation(readIndexKey), storage.GetOperation(writeIndexKey), storage.GetOperation(sizeKey)
+	if err := w.client.Batch(ctx, readOp, writeOp, sizeOp); err != nil {
+		return fmt.Errorf("failed to read the WAL indexes: %w", err)
+	}
+	var err error
+	if w.readIndex, err = bytesToIndex(readOp.Value); err != nil {
+		return err
+	}
+	if w.writeIndex, err = bytesToIndex(writeOp.Value); err != nil {
+		return err
+	}
+	size, err := bytesToIndex(sizeOp.Value)
+	if err != nil {
+		return err
+	}
+	w.size = int64(size)
+	if pending := w.writeIndex - w.readIndex; pending > 0 {
+		w.logger.Info("Replaying the entries of the WAL", zap.Uint64("entries", pending))
+	}
+
+	consumeCtx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	go w.consumeLoop(consumeCtx)
+	return nil
+}
+
+// Shutdown stops consuming the entries, deletes the entries consumed, and closes the storage client.
+func (w *WAL) Shutdown(ctx context.Context) error {
+	if w.cancel != nil {
+		w.cancel()
+		<-w.done
+		w.mu.Lock()
+		w.deleteConsumed(ctx)
+		w.mu.Unlock()
+	}
+	return w.client.Close(ctx)
+}
+
+// Traces returns the consumer writing the traces to the WAL.
+func (w *WAL) Traces() consumer.Traces {
+	return walTraces{w}
+}
+
+// Metrics returns the consumer writing the metrics to the WAL.
+func (w *WAL) Metrics() consumer.Metrics {
+	return walMetrics{w}
+}
+
+// Logs returns the consumer writing the logs to the WAL.
+func (w *WAL) Logs() consumer.Logs {
+	return walLogs{w}
+}
+
+// write persists an entry, the data being acknowledged once written.
+func (w *WAL) write(ctx context.Context, signal byte, data []byte) error {
+	entry, err := encodeEntry(signal, client.FromContext(ctx).Metadata, data)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+
+	entrySize := int64(len(entry))
+	if w.settings.MaxSize > 0 && entrySize > w.settings.MaxSize {
+		return consumererror.NewPermanent(fmt.Errorf("%w: entry of %d bytes exceeds max_size %d", errFull, entrySize, w.settings.MaxSize))
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.settings.MaxEntries > 0 && w.writeIndex-w.readIndex >= uint64(w.settings.MaxEntries) {
+		return fmt.Errorf("%w: max_entries %d", errFull, w.settings.MaxEntries)
+	}
+	if w.settings.MaxSize > 0 && w.size+entrySize > w.settings.MaxSize {
+		return fmt.Errorf("%w: max_size %d", errFull, w.settings.MaxSize)
+	}
+	index := w.writeIndex
+	if err := w.client.Batch(ctx,
+		storage.SetOperation(entryKey(index), entry),
+		storage.SetOperation(writeIndexKey, indexToBytes(index+1)),
+		storage.SetOperation(sizeKey, indexToBytes(uint64(w.size+entrySize)))); err != nil {
+		return fmt.Errorf("failed to write the WAL entry: %w", err)
+	}
+	w.writeIndex++
+	w.size += entrySize
+
+	select {
+	case w.written <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// consumeLoop consumes the entries in order, with up to Settings.NumConsumers entries consumed
+// concurrently, so that an entry retried for long does not block the next ones.
+func (w *WAL) consumeLoop(ctx context.Context) {
+	defer close(w.done)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	consumers := make(chan struct{}, max(w.settings.NumConsumers, 1))
+
+	w.mu.Lock()
+	next := w.readIndex
+	w.mu.Unlock()
+	for {
+		w.mu.Lock()
+		empty := next == w.writeIndex
+		w.mu.Unlock()
+		if empty {
+			select {
+			case <-w.written:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case consumers <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		wg.Add(1)
+		go func(index uint64) {
+			defer func() {
+				<-consumers
+				wg.Done()
+			}()
+			if size, ok := w.consumeEntry(ctx, index); ok {
+				w.mu.Lock()
+				w.consumed[index] = size
+				w.deleteConsumed(ctx)
+				w.mu.Unlock()
+			}
+		}(next)
+		next++
+	}
+}
+
+// deleteConsumed deletes the consumed entries following the read index, and moves the read index
+// past them. If the deletion fails, it is retried with the next consumed entry, or on shutdown: the
+// entries are consumed again if the WAL is restarted before. It must be called with mu held.
+func (w *WAL) deleteConsumed(ctx context.Context) {
+	var ops []storage.Operation
+	readIndex, size := w.readIndex, w.size
+	for {
+		entrySize, ok := w.consumed[readIndex]
+		if !ok {
+			break
+		}
+		ops = append(ops, storage.DeleteOperation(entryKey(readIndex)))
+		readIndex++
+		size = max(size-entrySize, 0)
+	}
+	if len(ops) == 0 {
+		return
+	}
+	ops = append(ops, storage.SetOperation(readIndexKey, indexToBytes(readIndex)), storage.SetOperation(sizeKey, indexToBytes(uint64(size))))
+	if err := w.client.Batch(ctx, ops...); err != nil {
+		w.logger.Warn("Failed to delete the consumed WAL entries, will retry", zap.Uint64("index", w.readIndex), zap.Int("entries", len(ops)-2), zap.Error(err))
+		return
+	}
+	for index := w.readIndex; index < readIndex; index++ {
+		delete(w.consumed, index)
+	}
+	w.readIndex, w.size = readIndex, size
+}
+
+// consumeEntry consumes the entry, retrying the errors that are neither permanent nor partial, as
+// the data rejected would be rejected again. It returns the size of the entry, and false if the WAL
+// is shut down before the entry is consumed.
+func (w *WAL) consumeEntry(ctx context.Context, index uint64) (int64, bool) {
+	value, err := w.client.Get(ctx, entryKey(index))
+	size := int64(len(value))
+	var signal byte
+	var metadata client.Metadata
+	var data []byte
+	if err == nil {
+		signal, metadata, data, err = decodeEntry(value)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return 0, false
+		}
+		w.logger.Error("Dropping the WAL entry that cannot be read", zap.Uint64("index", index), zap.Error(err))
+		return size, true
+	}
+
+	retry := backoff.NewExponentialBackOff()
+	retry.InitialInterval = w.retryInterval
+	retry.MaxElapsedTime = 0
+	consumeCtx := client.NewContext(ctx, client.Info{Metadata: metadata})
+	for {
+		err = w.consume(consumeCtx, signal, data)
+		if err == nil {
+			return size, true
+		}
+		if consumererror.IsPartial(err) {
+			w.logger.Error("Dropping the WAL entry partially rejected by the next consumer", zap.Uint64("index", index), zap.Int("rejected", consumererror.RejectedCount(err)), zap.Error(err))
+			return size, true
+		}
+		if consumererror.IsPermanent(err) {
+			w.logger.Error("Dropping the WAL entry rejected by the next consumer", zap.Uint64("index", index), zap.Error(err))
+			return size, true
+		}
+		delay := retry.NextBackOff()
+		w.logger.Warn("Failed to consume the WAL entry, will retry", zap.Uint64("index", index), zap.Duration("interval", delay), zap.Error(err))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return 0, false
+		}
+	}
+}
+
+func (w *WAL) consume(ctx context.Context, signal byte, data []byte) error {
+	switch {
+	case signal == signalTraces && w.nextTraces != nil:
+		td, err := tracesUnmarshaler.UnmarshalTraces(data)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		return w.nextTraces.ConsumeTraces(ctx, td)
+	case signal == signalMetrics && w.nextMetrics != nil:
+		md, err := metricsUnmarshaler.UnmarshalMetrics(data)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		return w.nextMetrics.ConsumeMetrics(ctx, md)
+	case signal == signalLogs && w.nextLogs != nil:
+		ld, err := logsUnmarshaler.UnmarshalLogs(data)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		return w.nextLogs.ConsumeLogs(ctx, ld)
+	}
+	return consumererror.NewPermanent(fmt.Errorf("no consumer for the entries of signal %d", signal))
+}
+
+type walTraces struct {
+	wal *WAL
+}
+
+func (walTraces) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c walTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	data, err := tracesMarshaler.MarshalTraces(td)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return c.wal.write(ctx, signalTraces, data)
+}
+
+type walMetrics struct {
+	wal *WAL
+}
+
+func (walMetrics) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c walMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	data, err := metricsMarshaler.MarshalMetrics(md)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return c.wal.write(ctx, signalMetrics, data)
+}
+
+type walLogs struct {
+	wal *WAL
+}
+
+func (walLogs) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c walLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	data, err := logsMarshaler.MarshalLogs(ld)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return c.wal.write(ctx, signalLogs, data)
+}
+
+// encodeEntry returns an entry made of the signal, the length of the JSON encoded metadata of the
+// client, the metadata and the data.
+func encodeEntry(signal byte, metadata client.Metadata, data []byte) ([]byte, error) {
+	md := make(map[string][]string)
+	for _, key := range metadata.Keys() {
+		md[key] = metadata.Get(key)
+	}
+	mdData, err := json.Marshal(md)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the client metadata: %w", err)
+	}
+	entry := binary.AppendUvarint([]byte{signal}, uint64(len(mdData)))
+	entry = append(entry, mdData...)
+	return append(entry, data...), nil
+}
+
+func decodeEntry(entry []byte) (byte, client.Metadata, []byte, error) {
+	if len(entry) == 0 {
+		return 0, client.Metadata{}, nil, errors.New("entry not found")
+	}
+	mdLen, n := binary.Uvarint(entry[1:])
+	if n <= 0 || uint64(len(entry)-1-n) < mdLen {
+		return 0, client.Metadata{}, nil, errors.New("invalid entry")
+	}
+	rest := entry[1+n:]
+	var md map[string][]string
+	if err := json.Unmarshal(rest[:mdLen], &md); err != nil {
+		return 0, client.Metadata{}, nil, fmt.Errorf("invalid entry metadata: %w", err)
+	}
+	return entry[0], client.NewMetadata(md), rest[mdLen:], nil
+}
+
+func entryKey(index uint64) string {
+	return strconv.FormatUint(index, 10)
+}
+
+func indexToBytes(index uint64) []byte {
+	return binary.LittleEndian.AppendUint64(nil, index)
+}
+
+// bytesToIndex returns the index stored in the value, or 0 if the index is not stored yet.
+func bytesToIndex(value []byte) (uint64, error) {
+	if value == nil {
+		return 0, nil
+	}
+	if len(value) != 8 {
+		return 0, fmt.Errorf("invalid WAL index of %d bytes", len(value))
+	}
+	return binary.LittleEndian.Uint64(value), nil
+}
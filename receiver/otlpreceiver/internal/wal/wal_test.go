@@ -0,0 +1,372 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package wal
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+// memoryClient is a storage client keeping the entries in memory, across the WALs using it.
+type memoryClient struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+	// failDeletes fails the batches deleting entries.
+	failDeletes bool
+}
+
+func newMemoryClient() *memoryClient {
+	return &memoryClient{entries: map[string][]byte{}}
+}
+
+func (c *memoryClient) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	err := c.Batch(ctx, op)
+	return op.Value, err
+}
+
+func (c *memoryClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+func (c *memoryClient) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+func (c *memoryClient) Close(context.Context) error {
+	return nil
+}
+
+func (c *memoryClient) Batch(_ context.Context, ops ...storage.Operation) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, op := range ops {
+		if op.Type == storage.Delete && c.failDeletes {
+			return errors.New("delete failed")
+		}
+	}
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = c.entries[op.Key]
+		case storage.Set:
+			c.entries[op.Key] = op.Value
+		case storage.Delete:
+			delete(c.entries, op.Key)
+		default:
+			return errors.New("wrong operation type")
+		}
+	}
+	return nil
+}
+
+func (c *memoryClient) setFailDeletes(fail bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failDeletes = fail
+}
+
+func (c *memoryClient) get(key string) []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries[key]
+}
+
+func (c *memoryClient) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+func TestWAL(t *testing.T) {
+	client := newMemoryClient()
+	traces, logs := new(consumertest.TracesSink), new(consumertest.LogsSink)
+	w := New(client, zap.NewNop(), Settings{NumConsumers: 1}, traces, nil, logs)
+	require.NoError(t, w.Start(context.Background()))
+
+	td, ld := testdata.GenerateTraces(2), testdata.GenerateLogs(3)
+	require.NoError(t, w.Traces().ConsumeTraces(context.Background(), td))
+	require.NoError(t, w.Logs().ConsumeLogs(context.Background(), ld))
+	assert.Eventually(t, func() bool {
+		return len(traces.AllTraces()) == 1 && len(logs.AllLogs()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, td, traces.AllTraces()[0])
+	assert.Equal(t, ld, logs.AllLogs()[0])
+	require.NoError(t, w.Shutdown(context.Background()))
+
+	// Only the indexes and the size are left once the entries are consumed.
+	assert.Equal(t, 3, client.len())
+	assert.Equal(t, indexToBytes(2), client.entries[readIndexKey])
+	assert.Equal(t, indexToBytes(2), client.entries[writeIndexKey])
+	assert.Equal(t, indexToBytes(0), client.entries[sizeKey])
+}
+
+func TestWALReplay(t *testing.T) {
+	client := newMemoryClient()
+	failing := consumertest.NewErr(errors.New("unavailable"))
+	w := New(client, zap.NewNop(), Settings{NumConsumers: 1}, failing, nil, nil)
+	w.retryInterval = time.Millisecond
+	require.NoError(t, w.Start(context.Background()))
+	td := testdata.GenerateTraces(1)
+	for i := 0; i < 3; i++ {
+		// The data is acknowledged once written, even though the next consumer fails.
+		require.NoError(t, w.Traces().ConsumeTraces(context.Background(), td))
+	}
+	require.NoError(t, w.Shutdown(context.Background()))
+
+	// The entries left are consumed in order by the next WAL.
+	sink := new(consumertest.TracesSink)
+	w = New(client, zap.NewNop(), Settings{NumConsumers: 1}, sink, nil, nil)
+	require.NoError(t, w.Start(context.Background()))
+	assert.Eventually(t, func() bool {
+		return sink.SpanCount() == 3
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, w.Traces().ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.Eventually(t, func() bool {
+		return sink.SpanCount() == 5
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, w.Shutdown(context.Background()))
+	assert.Len(t, sink.AllTraces(), 4)
+	assert.Equal(t, 2, sink.AllTraces()[3].SpanCount())
+	assert.Equal(t, 3, client.len())
+}
+
+func TestWALFull(t *testing.T) {
+	storageClient := newMemoryClient()
+	td := testdata.GenerateTraces(1)
+	entry, err := encodeEntry(signalTraces, client.Metadata{}, mustMarshalTraces(t, td))
+	require.NoError(t, err)
+	entrySize := int64(len(entry))
+	w := New(storageClient, zap.NewNop(), Settings{MaxEntries: 2, NumConsumers: 1}, consumertest.NewErr(errors.New("unavailable")), nil, nil)
+	require.NoError(t, w.Start(context.Background()))
+
+	// The data is refused with a retryable error once the WAL is full.
+	require.NoError(t, w.Traces().ConsumeTraces(context.Background(), td))
+	require.NoError(t, w.Traces().ConsumeTraces(context.Background(), td))
+	err = w.Traces().ConsumeTraces(context.Background(), td)
+	require.ErrorIs(t, err, errFull)
+	assert.False(t, consumererror.IsPermanent(err))
+	require.NoError(t, w.Shutdown(context.Background()))
+
+	w = New(storageClient, zap.NewNop(), Settings{MaxSize: 3 * entrySize, NumConsumers: 1}, consumertest.NewErr(errors.New("unavailable")), nil, nil)
+	require.NoError(t, w.Start(context.Background()))
+	require.NoError(t, w.Traces().ConsumeTraces(context.Background(), td))
+	err = w.Traces().ConsumeTraces(context.Background(), td)
+	require.ErrorIs(t, err, errFull)
+	assert.False(t, consumererror.IsPermanent(err))
+	require.NoError(t, w.Shutdown(context.Background()))
+
+	// The data that would never fit is refused with a permanent error.
+	w = New(newMemoryClient(), zap.NewNop(), Settings{MaxSize: entrySize - 1, NumConsumers: 1}, consumertest.NewNop(), nil, nil)
+	require.NoError(t, w.Start(context.Background()))
+	err = w.Traces().ConsumeTraces(context.Background(), td)
+	require.ErrorIs(t, err, errFull)
+	assert.True(t, consumererror.IsPermanent(err))
+	require.NoError(t, w.Shutdown(context.Background()))
+}
+
+func TestWALConcurrentConsumers(t *testing.T) {
+	client := newMemoryClient()
+	blocked := make(chan struct{})
+	sink := new(consumertest.TracesSink)
+	blocking, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		// The first entry, with two spans, fails until unblocked.
+		if td.SpanCount() == 2 {
+			select {
+			case <-blocked:
+			default:
+				<-blocked
+				return errors.New("unavailable")
+			}
+		}
+		return sink.ConsumeTraces(ctx, td)
+	})
+	require.NoError(t, err)
+	w := New(client, zap.NewNop(), Settings{NumConsumers: 2}, blocking, nil, nil)
+	w.retryInterval = time.Millisecond
+	require.NoError(t, w.Start(context.Background()))
+
+	// The entries following an entry being retried are consumed, but not deleted before it.
+	require.NoError(t, w.Traces().ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	for i := 0; i < 2; i++ {
+		require.NoError(t, w.Traces().ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	}
+	assert.Eventually(t, func() bool {
+		return sink.SpanCount() == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 5, client.len())
+	assert.Nil(t, client.get(readIndexKey))
+
+	close(blocked)
+	assert.Eventually(t, func() bool {
+		return client.len() == 3
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, w.Shutdown(context.Background()))
+	assert.Equal(t, 4, sink.SpanCount())
+	assert.Equal(t, indexToBytes(3), client.get(readIndexKey))
+}
+
+func TestWALDeleteError(t *testing.T) {
+	client := newMemoryClient()
+	client.setFailDeletes(true)
+	sink := new(consumertest.TracesSink)
+	w := New(client, zap.NewNop(), Settings{NumConsumers: 1}, sink, nil, nil)
+	require.NoError(t, w.Start(context.Background()))
+
+	// The entries failing to be deleted are kept below the read index until deleted.
+	require.NoError(t, w.Traces().ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Eventually(t, func() bool {
+		return sink.SpanCount() == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 3, client.len())
+	assert.Nil(t, client.get(readIndexKey))
+
+	// The deletion is retried with the next entry.
+	client.setFailDeletes(false)
+	require.NoError(t, w.Traces().ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Eventually(t, func() bool {
+		return client.len() == 3
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, indexToBytes(2), client.get(readIndexKey))
+
+	// The deletion is retried on shutdown.
+	client.setFailDeletes(true)
+	require.NoError(t, w.Traces().ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Eventually(t, func() bool {
+		return sink.SpanCount() == 3
+	}, time.Second, 10*time.Millisecond)
+	client.setFailDeletes(false)
+	require.NoError(t, w.Shutdown(context.Background()))
+	assert.Equal(t, 3, client.len())
+	assert.Equal(t, indexToBytes(3), client.get(readIndexKey))
+	assert.Equal(t, indexToBytes(0), client.get(sizeKey))
+}
+
+func mustMarshalTraces(t *testing.T, td ptrace.Traces) []byte {
+	data, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	return data
+}
+
+func TestWALPermanentError(t *testing.T) {
+	client := newMemoryClient()
+	var calls int
+	var mu sync.Mutex
+	rejecting := consumertest.NewErr(consumererror.NewPermanent(errors.New("invalid")))
+	counting, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		return rejecting.ConsumeTraces(ctx, td)
+	})
+	require.NoError(t, err)
+	w := New(client, zap.NewNop(), Settings{NumConsumers: 1}, counting, nil, nil)
+	require.NoError(t, w.Start(context.Background()))
+
+	// The entries rejected with a permanent error are dropped, and so are the entries of the signals
+	// without a consumer.
+	require.NoError(t, w.Traces().ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	require.NoError(t, w.Logs().ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.Eventually(t, func() bool {
+		return client.len() == 3
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, w.Shutdown(context.Background()))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, indexToBytes(2), client.entries[readIndexKey])
+}
+
+func TestWALPartialError(t *testing.T) {
+	storageClient := newMemoryClient()
+	var calls int
+	var mu sync.Mutex
+	counting, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		return consumererror.NewPartial(errors.New("invalid span"), 1)
+	})
+	require.NoError(t, err)
+	w := New(storageClient, zap.NewNop(), Settings{NumConsumers: 1}, counting, nil, nil)
+	w.retryInterval = time.Millisecond
+	require.NoError(t, w.Start(context.Background()))
+
+	// The entries partially rejected are dropped instead of being retried.
+	require.NoError(t, w.Traces().ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.Eventually(t, func() bool {
+		return storageClient.len() == 3
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, w.Shutdown(context.Background()))
+	assert.Equal(t, 1, calls)
+}
+
+func TestWALClientMetadata(t *testing.T) {
+	storageClient := newMemoryClient()
+	w := New(storageClient, zap.NewNop(), Settings{NumConsumers: 1}, consumertest.NewErr(errors.New("unavailable")), nil, nil)
+	w.retryInterval = time.Millisecond
+	require.NoError(t, w.Start(context.Background()))
+	ctx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"X-Tenant": {"a", "b"}}),
+	})
+	require.NoError(t, w.Traces().ConsumeTraces(ctx, testdata.GenerateTraces(1)))
+	require.NoError(t, w.Shutdown(context.Background()))
+
+	// The metadata of the client is replayed with the entry.
+	var metadata []client.Metadata
+	var mu sync.Mutex
+	recording, err := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error {
+		mu.Lock()
+		defer mu.Unlock()
+		metadata = append(metadata, client.FromContext(ctx).Metadata)
+		return nil
+	})
+	require.NoError(t, err)
+	w = New(storageClient, zap.NewNop(), Settings{NumConsumers: 1}, recording, nil, nil)
+	require.NoError(t, w.Start(context.Background()))
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(metadata) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, w.Shutdown(context.Background()))
+	assert.Equal(t, []string{"a", "b"}, metadata[0].Get("x-tenant"))
+}
+
+func TestDecodeEntry(t *testing.T) {
+	entry, err := encodeEntry(signalLogs, client.NewMetadata(map[string][]string{"k": {"v"}}), []byte("data"))
+	require.NoError(t, err)
+	signal, metadata, data, err := decodeEntry(entry)
+	require.NoError(t, err)
+	assert.Equal(t, signalLogs, signal)
+	assert.Equal(t, []string{"v"}, metadata.Get("k"))
+	assert.Equal(t, []byte("data"), data)
+
+	_, _, _, err = decodeEntry(nil)
+	assert.EqualError(t, err, "entry not found")
+	_, _, _, err = decodeEntry([]byte{signalLogs, 10, '{'})
+	assert.EqualError(t, err, "invalid entry")
+}
+
+func TestWALInvalidIndex(t *testing.T) {
+	client := newMemoryClient()
+	require.NoError(t, client.Set(context.Background(), writeIndexKey, []byte{1}))
+	w := New(client, zap.NewNop(), Settings{NumConsumers: 1}, nil, nil, nil)
+	assert.EqualError(t, w.Start(context.Background()), "invalid WAL index of 1 bytes")
+	require.NoError(t, w.Shutdown(context.Background()))
+}
@@ -6,6 +6,7 @@ package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlprecei
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net"
 	"net/http"
 	"sync"
@@ -16,6 +17,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/internal/otlpstream"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
@@ -24,6 +27,7 @@ import (
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/profiles"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/wal"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
@@ -32,6 +36,9 @@ type otlpReceiver struct {
 	cfg        *Config
 	serverGRPC *grpc.Server
 	serverHTTP *http.Server
+	webSocket  *webSocketHandler
+	admission  *admission
+	wal        *wal.WAL
 
 	nextTraces   consumer.Traces
 	nextMetrics  consumer.Metrics
@@ -85,20 +92,41 @@ func (r *otlpReceiver) startGRPCServer(host component.Host) error {
 	}
 
 	var err error
-	if r.serverGRPC, err = r.cfg.GRPC.ToServer(context.Background(), host, r.settings.TelemetrySettings); err != nil {
+	if r.serverGRPC, err = r.cfg.GRPC.ToServer(context.Background(), host, r.settings.TelemetrySettings, r.admission.serverOptions()...); err != nil {
 		return err
 	}
 
+	// The streams export the batches of each signal with the same receiver as the unary requests,
+	// a nil server rejecting the batches of the signals without a consumer.
+	var (
+		tracesServer  ptraceotlp.GRPCServer
+		metricsServer pmetricotlp.GRPCServer
+		logsServer    plogotlp.GRPCServer
+	)
 	if r.nextTraces != nil {
-		ptraceotlp.RegisterGRPCServer(r.serverGRPC, trace.New(r.nextTraces, r.obsrepGRPC))
+		tracesServer = trace.New(r.tracesConsumer(), r.obsrepGRPC)
+		ptraceotlp.RegisterGRPCServer(r.serverGRPC, tracesServer)
 	}
 
 	if r.nextMetrics != nil {
-		pmetricotlp.RegisterGRPCServer(r.serverGRPC, metrics.New(r.nextMetrics, r.obsrepGRPC))
+		metricsServer = metrics.New(r.metricsConsumer(), r.obsrepGRPC)
+		pmetricotlp.RegisterGRPCServer(r.serverGRPC, metricsServer)
 	}
 
 	if r.nextLogs != nil {
-		plogotlp.RegisterGRPCServer(r.serverGRPC, logs.New(r.nextLogs, r.obsrepGRPC))
+		logsServer = logs.New(r.logsConsumer(), r.obsrepGRPC)
+		plogotlp.RegisterGRPCServer(r.serverGRPC, logsServer)
+	}
+
+	if r.cfg.GRPC.Streaming.Enabled {
+		otlpstream.NewServer(otlpstream.ServerSettings{
+			MaxDictionarySize:  r.cfg.GRPC.Streaming.MaxDictionarySize,
+			MaxInFlightBatches: r.cfg.GRPC.Streaming.MaxInFlightBatches,
+			Admit:              r.admission.admitBatch,
+			Traces:             tracesServer,
+			Metrics:            metricsServer,
+			Logs:               logsServer,
+		}).Register(r.serverGRPC)
 	}
 
 	r.settings.Logger.Info("Starting GRPC server", zap.String("endpoint", r.cfg.GRPC.NetAddr.Endpoint))
@@ -125,25 +153,29 @@ func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host)
 	}
 
 	httpMux := http.NewServeMux()
+	webSocket := newWebSocketHandler(r.cfg.HTTP, r.admission)
 	if r.nextTraces != nil {
-		httpTracesReceiver := trace.New(r.nextTraces, r.obsrepHTTP)
+		httpTracesReceiver := trace.New(r.tracesConsumer(), r.obsrepHTTP)
 		httpMux.HandleFunc(r.cfg.HTTP.TracesURLPath, func(resp http.ResponseWriter, req *http.Request) {
 			handleTraces(resp, req, httpTracesReceiver)
 		})
+		webSocket.traces = httpTracesReceiver
 	}
 
 	if r.nextMetrics != nil {
-		httpMetricsReceiver := metrics.New(r.nextMetrics, r.obsrepHTTP)
+		httpMetricsReceiver := metrics.New(r.metricsConsumer(), r.obsrepHTTP)
 		httpMux.HandleFunc(r.cfg.HTTP.MetricsURLPath, func(resp http.ResponseWriter, req *http.Request) {
 			handleMetrics(resp, req, httpMetricsReceiver)
 		})
+		webSocket.metrics = httpMetricsReceiver
 	}
 
 	if r.nextLogs != nil {
-		httpLogsReceiver := logs.New(r.nextLogs, r.obsrepHTTP)
+		httpLogsReceiver := logs.New(r.logsConsumer(), r.obsrepHTTP)
 		httpMux.HandleFunc(r.cfg.HTTP.LogsURLPath, func(resp http.ResponseWriter, req *http.Request) {
 			handleLogs(resp, req, httpLogsReceiver)
 		})
+		webSocket.logs = httpLogsReceiver
 	}
 
 	if r.nextProfiles != nil {
@@ -151,10 +183,16 @@ func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host)
 		httpMux.HandleFunc(r.cfg.HTTP.ProfilesURLPath, func(resp http.ResponseWriter, req *http.Request) {
 			handleProfiles(resp, req, httpProfilesReceiver)
 		})
+		webSocket.profiles = httpProfilesReceiver
+	}
+
+	if r.cfg.HTTP.WebSocketURLPath != "" {
+		r.webSocket = webSocket
+		httpMux.Handle(r.cfg.HTTP.WebSocketURLPath, webSocket)
 	}
 
 	var err error
-	if r.serverHTTP, err = r.cfg.HTTP.ToServer(ctx, host, r.settings.TelemetrySettings, httpMux, confighttp.WithErrorHandler(errorHandler)); err != nil {
+	if r.serverHTTP, err = r.cfg.HTTP.ToServer(ctx, host, r.settings.TelemetrySettings, r.admission.httpHandler(httpMux), confighttp.WithErrorHandler(errorHandler)); err != nil {
 		return err
 	}
 
@@ -178,10 +216,19 @@ func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host)
 // Start runs the trace receiver on the gRPC server. Currently
 // it also enables the metrics receiver too.
 func (r *otlpReceiver) Start(ctx context.Context, host component.Host) error {
-	if err := r.startGRPCServer(host); err != nil {
+	var err error
+	if r.admission, err = newAdmission(r.cfg.Admission, host); err != nil {
 		return err
 	}
-	if err := r.startHTTPServer(ctx, host); err != nil {
+	if r.cfg.WAL.Storage != nil {
+		if err = r.startWAL(ctx, host); err != nil {
+			return err
+		}
+	}
+	if err = r.startGRPCServer(host); err != nil {
+		return errors.Join(err, r.Shutdown(ctx))
+	}
+	if err = r.startHTTPServer(ctx, host); err != nil {
 		// It's possible that a valid GRPC server configuration was specified,
 		// but an invalid HTTP configuration. If that's the case, the successfully
 		// started GRPC server must be shutdown to ensure no goroutines are leaked.
@@ -199,14 +246,74 @@ func (r *otlpReceiver) Shutdown(ctx context.Context) error {
 		err = r.serverHTTP.Shutdown(ctx)
 	}
 
+	if r.webSocket != nil {
+		r.webSocket.shutdown()
+	}
+
 	if r.serverGRPC != nil {
 		r.serverGRPC.GracefulStop()
 	}
 
 	r.shutdownWG.Wait()
+
+	if r.wal != nil {
+		err = errors.Join(err, r.wal.Shutdown(ctx))
+		r.wal = nil
+	}
 	return err
 }
 
+// startWAL starts the write-ahead log persisting the received data with the storage extension,
+// before the data is passed to the next consumers.
+func (r *otlpReceiver) startWAL(ctx context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[*r.cfg.WAL.Storage]
+	if !ok {
+		return fmt.Errorf("storage extension %q not found", r.cfg.WAL.Storage)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return fmt.Errorf("extension %q is not a storage extension", r.cfg.WAL.Storage)
+	}
+	client, err := storageExt.GetClient(ctx, component.KindReceiver, r.settings.ID, "wal")
+	if err != nil {
+		return err
+	}
+	w := wal.New(client, r.settings.Logger, wal.Settings{
+		MaxEntries:   r.cfg.WAL.MaxEntries,
+		MaxSize:      r.cfg.WAL.MaxSize,
+		NumConsumers: r.cfg.WAL.NumConsumers,
+	}, r.nextTraces, r.nextMetrics, r.nextLogs)
+	if err = w.Start(ctx); err != nil {
+		return errors.Join(err, client.Close(ctx))
+	}
+	r.wal = w
+	return nil
+}
+
+// tracesConsumer returns the consumer of the received traces, writing them to the WAL if enabled.
+func (r *otlpReceiver) tracesConsumer() consumer.Traces {
+	if r.wal != nil {
+		return r.wal.Traces()
+	}
+	return r.nextTraces
+}
+
+// metricsConsumer returns the consumer of the received metrics, writing them to the WAL if enabled.
+func (r *otlpReceiver) metricsConsumer() consumer.Metrics {
+	if r.wal != nil {
+		return r.wal.Metrics()
+	}
+	return r.nextMetrics
+}
+
+// logsConsumer returns the consumer of the received logs, writing them to the WAL if enabled.
+func (r *otlpReceiver) logsConsumer() consumer.Logs {
+	if r.wal != nil {
+		return r.wal.Logs()
+	}
+	return r.nextLogs
+}
+
 func (r *otlpReceiver) registerTraceConsumer(tc consumer.Traces) {
 	r.nextTraces = tc
 }
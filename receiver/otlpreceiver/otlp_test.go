@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -21,6 +22,7 @@ import (
 	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
 	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -37,9 +39,13 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/internal/otlpstream"
 	"go.opentelemetry.io/collector/internal/testutil"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 	"go.opentelemetry.io/collector/pdata/testdata"
@@ -151,6 +157,42 @@ func TestJsonHttp(t *testing.T) {
 	}
 }
 
+func TestNDJSONHttp(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	sink := newErrOrSinkConsumer()
+	recv := newHTTPReceiver(t, componenttest.NewNopTelemetrySettings(), addr, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	dr := generateTracesRequest(t)
+	url := "http://" + addr + dr.path
+	body := bytes.Join([][]byte{dr.jsonBytes, dr.jsonBytes, dr.jsonBytes}, []byte("\n"))
+	resp, err := http.Post(url, ndjsonContentType, bytes.NewReader(body))
+	require.NoError(t, err)
+	respBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	// The responses are JSON.
+	assert.Equal(t, jsonContentType, resp.Header.Get("Content-Type"))
+	assert.NoError(t, ptraceotlp.NewExportResponse().UnmarshalJSON(respBytes))
+	// The requests are consumed with a single call.
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, 3*dr.data.(ptrace.Traces).SpanCount(), sink.AllTraces()[0].SpanCount())
+
+	body = bytes.Join([][]byte{dr.jsonBytes, []byte("{")}, []byte("\n"))
+	resp, err = http.Post(url, ndjsonContentType, bytes.NewReader(body))
+	require.NoError(t, err)
+	respBytes, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	errStatus := &spb.Status{}
+	require.NoError(t, json.Unmarshal(respBytes, errStatus))
+	assert.Contains(t, errStatus.Message, "line 2: ")
+	require.Len(t, sink.AllTraces(), 1)
+}
+
 func TestHandleInvalidRequests(t *testing.T) {
 	addr := testutil.GetAvailableLocalAddress(t)
 	sink := newErrOrSinkConsumer()
@@ -174,7 +216,7 @@ func TestHandleInvalidRequests(t *testing.T) {
 			contentType: "",
 
 			expectedStatus:       http.StatusUnsupportedMediaType,
-			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf]",
+			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-ndjson, application/x-protobuf]",
 		},
 		{
 			name:        "invalid content type",
@@ -183,7 +225,7 @@ func TestHandleInvalidRequests(t *testing.T) {
 			contentType: "invalid",
 
 			expectedStatus:       http.StatusUnsupportedMediaType,
-			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf]",
+			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-ndjson, application/x-protobuf]",
 		},
 		{
 			name:        "invalid request",
@@ -216,7 +258,7 @@ func TestHandleInvalidRequests(t *testing.T) {
 			contentType: "",
 
 			expectedStatus:       http.StatusUnsupportedMediaType,
-			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf]",
+			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-ndjson, application/x-protobuf]",
 		},
 		{
 			name:        "invalid content type",
@@ -225,7 +267,7 @@ func TestHandleInvalidRequests(t *testing.T) {
 			contentType: "invalid",
 
 			expectedStatus:       http.StatusUnsupportedMediaType,
-			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf]",
+			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-ndjson, application/x-protobuf]",
 		},
 		{
 			name:        "invalid request",
@@ -258,7 +300,7 @@ func TestHandleInvalidRequests(t *testing.T) {
 			contentType: "",
 
 			expectedStatus:       http.StatusUnsupportedMediaType,
-			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf]",
+			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-ndjson, application/x-protobuf]",
 		},
 		{
 			name:        "invalid content type",
@@ -267,7 +309,7 @@ func TestHandleInvalidRequests(t *testing.T) {
 			contentType: "invalid",
 
 			expectedStatus:       http.StatusUnsupportedMediaType,
-			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-protobuf]",
+			expectedResponseBody: "415 unsupported media type, supported: [application/json, application/x-ndjson, application/x-protobuf]",
 		},
 		{
 			name:        "invalid request",
@@ -594,6 +636,72 @@ func TestOTLPReceiverGRPCTracesIngestTest(t *testing.T) {
 	require.NoError(t, tt.CheckReceiverTraces("grpc", int64(expectedReceivedBatches), int64(expectedIngestionBlockedRPCs)))
 }
 
+func TestOTLPReceiverGRPCStreaming(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	tt, err := componenttest.SetupTelemetry(otlpReceiverID)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	sink := newErrOrSinkConsumer()
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = addr
+	cfg.GRPC.Streaming.Enabled = true
+	cfg.HTTP = nil
+	recv := newReceiver(t, tt.TelemetrySettings(), cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	cc, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+	client := otlpstream.NewClient(cc, otlpstream.ClientSettings{MaxDictionarySize: 100})
+	defer client.Close()
+
+	for i := 0; i < 2; i++ {
+		_, err = client.ExportTraces(context.Background(), ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(2)))
+		require.NoError(t, err)
+		_, err = client.ExportMetrics(context.Background(), pmetricotlp.NewExportRequestFromMetrics(testdata.GenerateMetrics(2)))
+		require.NoError(t, err)
+		_, err = client.ExportLogs(context.Background(), plogotlp.NewExportRequestFromLogs(testdata.GenerateLogs(2)))
+		require.NoError(t, err)
+	}
+	require.Len(t, sink.AllTraces(), 2)
+	assert.Equal(t, 2, sink.AllTraces()[1].SpanCount())
+	require.Len(t, sink.AllMetrics(), 2)
+	assert.Equal(t, 2, sink.AllMetrics()[1].MetricCount())
+	require.Len(t, sink.AllLogs(), 2)
+	assert.Equal(t, 2, sink.AllLogs()[1].LogRecordCount())
+	require.NoError(t, tt.CheckReceiverTraces("grpc", 4, 0))
+
+	sink.SetConsumeError(errors.New("consumer error"))
+	_, err = client.ExportTraces(context.Background(), ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(2)))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestOTLPReceiverGRPCStreamingDisabled(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = addr
+	// The streams are disabled by default.
+	cfg.HTTP = nil
+	recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, newErrOrSinkConsumer())
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	cc, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+	client := otlpstream.NewClient(cc, otlpstream.ClientSettings{MaxDictionarySize: 100})
+	defer client.Close()
+
+	_, err = client.ExportTraces(context.Background(), ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(2)))
+	assert.ErrorIs(t, err, otlpstream.ErrUnsupported)
+}
+
 // TestOTLPReceiverHTTPTracesIngestTest checks that the HTTP trace receiver
 // is returning the proper response (return and metrics) when the next consumer
 // in the pipeline reports error. The test changes the responses returned by the
@@ -685,14 +793,16 @@ func TestOTLPReceiverHTTPTracesIngestTest(t *testing.T) {
 func TestGRPCInvalidTLSCredentials(t *testing.T) {
 	cfg := &Config{
 		Protocols: Protocols{
-			GRPC: &configgrpc.ServerConfig{
-				NetAddr: confignet.AddrConfig{
-					Endpoint:  testutil.GetAvailableLocalAddress(t),
-					Transport: confignet.TransportTypeTCP,
-				},
-				TLSSetting: &configtls.ServerConfig{
-					Config: configtls.Config{
-						CertFile: "willfail",
+			GRPC: &GRPCConfig{
+				ServerConfig: &configgrpc.ServerConfig{
+					NetAddr: confignet.AddrConfig{
+						Endpoint:  testutil.GetAvailableLocalAddress(t),
+						Transport: confignet.TransportTypeTCP,
+					},
+					TLSSetting: &configtls.ServerConfig{
+						Config: configtls.Config{
+							CertFile: "willfail",
+						},
 					},
 				},
 			},
@@ -820,6 +930,133 @@ func TestHTTPMaxRequestBodySize(t *testing.T) {
 	}
 }
 
+func TestOTLPReceiverWebSocket(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	tt, err := componenttest.SetupTelemetry(otlpReceiverID)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC = nil
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.WebSocketURLPath = "/v1/websocket"
+	cfg.HTTP.CORS = &confighttp.CORSConfig{AllowedOrigins: []string{"https://*.example.com"}}
+	sink := newErrOrSinkConsumer()
+	recv := newReceiver(t, tt.TelemetrySettings(), cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	url := "ws://" + addr + "/v1/websocket"
+
+	t.Run("proto", func(t *testing.T) {
+		sink.Reset()
+		ws, err := websocket.Dial(url, "", "http://"+addr)
+		require.NoError(t, err)
+		defer ws.Close()
+
+		td := testdata.GenerateTraces(2)
+		body, err := ptraceotlp.NewExportRequestFromTraces(td).MarshalProto()
+		require.NoError(t, err)
+		for i := 0; i < 2; i++ {
+			writeWebSocketRequest(t, ws, webSocketTraces, body)
+			typ, payload := readWebSocketAck(t, ws)
+			require.Equal(t, webSocketResponse, typ)
+			resp := ptraceotlp.NewExportResponse()
+			require.NoError(t, resp.UnmarshalProto(payload))
+			assert.Equal(t, int64(0), resp.PartialSuccess().RejectedSpans())
+		}
+		require.Len(t, sink.AllTraces(), 2)
+		assert.Equal(t, td, sink.AllTraces()[1])
+		require.NoError(t, tt.CheckReceiverTraces("http", 4, 0))
+
+		sink.SetConsumeError(consumererror.NewPermanent(errors.New("consumer error")))
+		defer sink.SetConsumeError(nil)
+		writeWebSocketRequest(t, ws, webSocketTraces, body)
+		typ, payload := readWebSocketAck(t, ws)
+		require.Equal(t, webSocketStatus, typ)
+		st := &spb.Status{}
+		require.NoError(t, proto.Unmarshal(payload, st))
+		assert.Equal(t, int32(codes.Internal), st.Code)
+
+		// Profiles are not consumed by the receiver.
+		writeWebSocketRequest(t, ws, webSocketProfiles, nil)
+		typ, payload = readWebSocketAck(t, ws)
+		require.Equal(t, webSocketStatus, typ)
+		require.NoError(t, proto.Unmarshal(payload, st))
+		assert.Equal(t, int32(codes.Unimplemented), st.Code)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		sink.Reset()
+		ws, err := websocket.Dial(url, webSocketJSONProtocol, "https://app.example.com")
+		require.NoError(t, err)
+		defer ws.Close()
+
+		ld := testdata.GenerateLogs(2)
+		body, err := plogotlp.NewExportRequestFromLogs(ld).MarshalJSON()
+		require.NoError(t, err)
+		writeWebSocketRequest(t, ws, webSocketLogs, body)
+		typ, payload := readWebSocketAck(t, ws)
+		require.Equal(t, webSocketResponse, typ)
+		resp := plogotlp.NewExportResponse()
+		require.NoError(t, resp.UnmarshalJSON(payload))
+		require.Len(t, sink.AllLogs(), 1)
+		assert.Equal(t, ld, sink.AllLogs()[0])
+
+		writeWebSocketRequest(t, ws, webSocketMetrics, []byte("{"))
+		typ, _ = readWebSocketAck(t, ws)
+		assert.Equal(t, webSocketStatus, typ)
+	})
+
+	t.Run("origin_not_allowed", func(t *testing.T) {
+		_, err := websocket.Dial(url, "", "https://example.org")
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported_protocol", func(t *testing.T) {
+		_, err := websocket.Dial(url, "otlp.xml", "http://"+addr)
+		assert.Error(t, err)
+	})
+}
+
+func TestOTLPReceiverWebSocketShutdown(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC = nil
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.WebSocketURLPath = "/v1/websocket"
+	recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, newErrOrSinkConsumer())
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+
+	ws, err := websocket.Dial("ws://"+addr+"/v1/websocket", "", "http://"+addr)
+	require.NoError(t, err)
+	defer ws.Close()
+
+	// The hijacked connections are closed on shutdown.
+	require.NoError(t, recv.Shutdown(context.Background()))
+	_, err = ws.Read(make([]byte, 1))
+	assert.Error(t, err)
+}
+
+func writeWebSocketRequest(t *testing.T, ws *websocket.Conn, typ byte, body []byte) {
+	msg := make([]byte, webSocketHeaderSize+len(body))
+	msg[0] = typ
+	binary.BigEndian.PutUint32(msg[1:], uint32(len(body)))
+	copy(msg[webSocketHeaderSize:], body)
+	_, err := ws.Write(msg)
+	require.NoError(t, err)
+}
+
+func readWebSocketAck(t *testing.T, ws *websocket.Conn) (byte, []byte) {
+	header := make([]byte, webSocketHeaderSize)
+	_, err := io.ReadFull(ws, header)
+	require.NoError(t, err)
+	payload := make([]byte, binary.BigEndian.Uint32(header[1:]))
+	_, err = io.ReadFull(ws, payload)
+	require.NoError(t, err)
+	return header[0], payload
+}
+
 func newGRPCReceiver(t *testing.T, settings component.TelemetrySettings, endpoint string, c consumertest.Consumer) component.Component {
 	cfg := createDefaultConfig().(*Config)
 	cfg.GRPC.NetAddr.Endpoint = endpoint
@@ -1193,3 +1430,95 @@ func (esc *errOrSinkConsumer) checkData(t *testing.T, data any, len int) {
 		}
 	}
 }
+
+// memoryStorage is a storage extension whose clients keep their entries in a shared map, surviving
+// the shutdown of the receivers.
+type memoryStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+	mu      sync.Mutex
+	entries map[string][]byte
+}
+
+func (s *memoryStorage) GetClient(context.Context, component.Kind, component.ID, string) (storage.Client, error) {
+	return s, nil
+}
+
+func (s *memoryStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	err := s.Batch(ctx, op)
+	return op.Value, err
+}
+
+func (s *memoryStorage) Set(ctx context.Context, key string, value []byte) error {
+	return s.Batch(ctx, storage.SetOperation(key, value))
+}
+
+func (s *memoryStorage) Delete(ctx context.Context, key string) error {
+	return s.Batch(ctx, storage.DeleteOperation(key))
+}
+
+func (s *memoryStorage) Close(context.Context) error {
+	return nil
+}
+
+func (s *memoryStorage) Batch(_ context.Context, ops ...storage.Operation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = s.entries[op.Key]
+		case storage.Set:
+			s.entries[op.Key] = op.Value
+		case storage.Delete:
+			delete(s.entries, op.Key)
+		}
+	}
+	return nil
+}
+
+func TestOTLPReceiverWAL(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+	host := &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{storageID: &memoryStorage{entries: map[string][]byte{}}},
+	}
+	addr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = addr
+	cfg.HTTP = nil
+	cfg.WAL.Storage = &storageID
+
+	// The requests are acknowledged once written to the WAL, while the next consumer fails.
+	sink := newErrOrSinkConsumer()
+	sink.SetConsumeError(errors.New("unavailable"))
+	recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), host))
+
+	cc, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, cc.Close())
+	}()
+	td := testdata.GenerateTraces(2)
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(context.Background(), ptraceotlp.NewExportRequestFromTraces(td))
+	require.NoError(t, err)
+	require.NoError(t, recv.Shutdown(context.Background()))
+	assert.Empty(t, sink.AllTraces())
+
+	// The data is replayed when the receiver is started again.
+	sink = newErrOrSinkConsumer()
+	recv = newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+	assert.Eventually(t, func() bool {
+		return sink.SpanCount() == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, td, sink.AllTraces()[0])
+
+	missing := component.MustNewID("missing")
+	cfg.WAL.Storage = &missing
+	invalid := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, sink)
+	assert.EqualError(t, invalid.Start(context.Background(), host), `storage extension "missing" not found`)
+}
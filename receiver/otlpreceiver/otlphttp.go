@@ -4,12 +4,19 @@
 package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
 
 import (
+	"context"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"mime"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 
+	"golang.org/x/net/websocket"
 	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/internal/httphelper"
@@ -160,6 +167,8 @@ func readContentType(resp http.ResponseWriter, req *http.Request) (encoder, bool
 		return pbEncoder, true
 	case jsonContentType:
 		return jsEncoder, true
+	case ndjsonContentType:
+		return ndjsEncoder, true
 	default:
 		handleUnmatchedContentType(resp)
 		return nil, false
@@ -198,7 +207,7 @@ func errorHandler(w http.ResponseWriter, r *http.Request, errMsg string, statusC
 	case pbContentType:
 		writeStatusResponse(w, pbEncoder, statusCode, s.Proto())
 		return
-	case jsonContentType:
+	case jsonContentType, ndjsonContentType:
 		writeStatusResponse(w, jsEncoder, statusCode, s.Proto())
 		return
 	}
@@ -237,5 +246,263 @@ func handleUnmatchedMethod(resp http.ResponseWriter) {
 
 func handleUnmatchedContentType(resp http.ResponseWriter) {
 	status := http.StatusUnsupportedMediaType
-	writeResponse(resp, "text/plain", status, []byte(fmt.Sprintf("%v unsupported media type, supported: [%s, %s]", status, jsonContentType, pbContentType)))
+	writeResponse(resp, "text/plain", status, []byte(fmt.Sprintf("%v unsupported media type, supported: [%s, %s, %s]", status, jsonContentType, ndjsonContentType, pbContentType)))
+}
+
+// The websocket subprotocols selecting the encoding of the messages. The protobuf encoding is used
+// if the client does not request a subprotocol.
+const (
+	webSocketProtobufProtocol = "otlp.proto"
+	webSocketJSONProtocol     = "otlp.json"
+)
+
+// The messages sent on the websockets, in both directions, start with a header made of a type byte
+// followed by the big-endian uint32 length of the payload.
+const webSocketHeaderSize = 5
+
+// defaultMaxWebSocketMessageSize is the maximum payload length of the messages if the HTTP server
+// does not set a max_request_body_size.
+const defaultMaxWebSocketMessageSize = 20 << 20
+
+// The types of the export requests, identifying their signal.
+const (
+	webSocketTraces byte = iota + 1
+	webSocketMetrics
+	webSocketLogs
+	webSocketProfiles
+)
+
+// The types of the acknowledgements, sent in the order of the export requests.
+const (
+	// webSocketResponse is followed by the export response of the signal.
+	webSocketResponse byte = iota
+	// webSocketStatus is followed by the rpc.Status of the failed export.
+	webSocketStatus
+)
+
+// webSocketHandler accepts the websocket connections on which the clients send a sequence of
+// export requests, each acknowledged with the export response or the error status.
+type webSocketHandler struct {
+	traces   *trace.Receiver
+	metrics  *metrics.Receiver
+	logs     *logs.Receiver
+	profiles *profiles.Receiver
+
+	admission      *admission
+	maxMessageSize int64
+	allowedOrigins []string
+
+	// The connections are hijacked from the HTTP server and must be closed on shutdown.
+	mu     sync.Mutex
+	conns  map[*websocket.Conn]struct{}
+	closed bool
+	connWG sync.WaitGroup
+}
+
+func newWebSocketHandler(cfg *HTTPConfig, admission *admission) *webSocketHandler {
+	h := &webSocketHandler{
+		admission:      admission,
+		maxMessageSize: cfg.MaxRequestBodySize,
+		conns:          map[*websocket.Conn]struct{}{},
+	}
+	if h.maxMessageSize <= 0 {
+		h.maxMessageSize = defaultMaxWebSocketMessageSize
+	}
+	if cfg.CORS != nil {
+		h.allowedOrigins = cfg.CORS.AllowedOrigins
+	}
+	return h
+}
+
+func (h *webSocketHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	websocket.Server{Handshake: h.handshake, Handler: h.serve}.ServeHTTP(resp, req)
+}
+
+// handshake checks the origin of the browsers and selects the encoding of the messages.
+func (h *webSocketHandler) handshake(config *websocket.Config, req *http.Request) error {
+	if origin := req.Header.Get("Origin"); origin != "" && !h.allowOrigin(origin, req.Host) {
+		return fmt.Errorf("origin %q not allowed", origin)
+	}
+	if len(config.Protocol) == 0 {
+		return nil
+	}
+	for _, protocol := range config.Protocol {
+		if protocol == webSocketProtobufProtocol || protocol == webSocketJSONProtocol {
+			config.Protocol = []string{protocol}
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported subprotocols %v, supported: [%s, %s]", config.Protocol, webSocketProtobufProtocol, webSocketJSONProtocol)
+}
+
+// allowOrigin returns whether the origin is the host itself or one of the CORS allowed origins,
+// which may contain a wildcard.
+func (h *webSocketHandler) allowOrigin(origin, host string) bool {
+	if u, err := url.Parse(origin); err == nil && u.Host == host {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	for _, allowed := range h.allowedOrigins {
+		allowed = strings.ToLower(allowed)
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+		if prefix, suffix, ok := strings.Cut(allowed, "*"); ok &&
+			len(origin) >= len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *webSocketHandler) serve(ws *websocket.Conn) {
+	if !h.track(ws) {
+		_ = ws.Close()
+		return
+	}
+	defer h.untrack(ws)
+
+	enc := encoder(pbEncoder)
+	if protocol := ws.Config().Protocol; len(protocol) == 1 && protocol[0] == webSocketJSONProtocol {
+		enc = jsEncoder
+	}
+	ws.PayloadType = websocket.BinaryFrame
+	ctx := ws.Request().Context()
+
+	header := make([]byte, webSocketHeaderSize)
+	for {
+		if _, err := io.ReadFull(ws, header); err != nil {
+			return
+		}
+		size := int64(binary.BigEndian.Uint32(header[1:]))
+		if size > h.maxMessageSize {
+			// The connection cannot be resynchronized without reading the payload.
+			_ = writeWebSocketAck(ws, enc, nil, status.Errorf(codes.InvalidArgument, "message of %d bytes exceeds the maximum of %d bytes", size, h.maxMessageSize))
+			return
+		}
+		if admitErr := h.admit(size); admitErr != nil {
+			// The payload is discarded without being decoded.
+			if _, err := io.CopyN(io.Discard, ws, size); err != nil {
+				return
+			}
+			if err := writeWebSocketAck(ws, enc, nil, admitErr); err != nil {
+				return
+			}
+			continue
+		}
+		body := make([]byte, size)
+		_, err := io.ReadFull(ws, body)
+		if err == nil {
+			var msg []byte
+			msg, err = h.export(ctx, enc, header[0], body)
+			err = writeWebSocketAck(ws, enc, msg, err)
+		}
+		h.admission.release(size)
+		if err != nil {
+			return
+		}
+	}
+}
+
+// admit checks the memory limiter and reserves the bytes of a message before it is read.
+func (h *webSocketHandler) admit(size int64) error {
+	if err := h.admission.admit(); err != nil {
+		return err
+	}
+	return h.admission.acquire(size)
+}
+
+// export exports the request of the given type, returning the encoded export response.
+func (h *webSocketHandler) export(ctx context.Context, enc encoder, typ byte, body []byte) ([]byte, error) {
+	switch {
+	case typ == webSocketTraces && h.traces != nil:
+		otlpReq, err := enc.unmarshalTracesRequest(body)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		otlpResp, err := h.traces.Export(ctx, otlpReq)
+		if err != nil {
+			return nil, err
+		}
+		return enc.marshalTracesResponse(otlpResp)
+	case typ == webSocketMetrics && h.metrics != nil:
+		otlpReq, err := enc.unmarshalMetricsRequest(body)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		otlpResp, err := h.metrics.Export(ctx, otlpReq)
+		if err != nil {
+			return nil, err
+		}
+		return enc.marshalMetricsResponse(otlpResp)
+	case typ == webSocketLogs && h.logs != nil:
+		otlpReq, err := enc.unmarshalLogsRequest(body)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		otlpResp, err := h.logs.Export(ctx, otlpReq)
+		if err != nil {
+			return nil, err
+		}
+		return enc.marshalLogsResponse(otlpResp)
+	case typ == webSocketProfiles && h.profiles != nil:
+		otlpReq, err := enc.unmarshalProfilesRequest(body)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		otlpResp, err := h.profiles.Export(ctx, otlpReq)
+		if err != nil {
+			return nil, err
+		}
+		return enc.marshalProfilesResponse(otlpResp)
+	}
+	return nil, status.Errorf(codes.Unimplemented, "unsupported message type %d", typ)
+}
+
+// writeWebSocketAck writes the export response, or the status of the error if exportErr is set.
+func writeWebSocketAck(ws *websocket.Conn, enc encoder, msg []byte, exportErr error) error {
+	typ := webSocketResponse
+	if exportErr != nil {
+		typ = webSocketStatus
+		var err error
+		if msg, err = enc.marshalStatus(status.Convert(exportErr).Proto()); err != nil {
+			return err
+		}
+	}
+	frame := make([]byte, webSocketHeaderSize+len(msg))
+	frame[0] = typ
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+	copy(frame[webSocketHeaderSize:], msg)
+	_, err := ws.Write(frame)
+	return err
+}
+
+func (h *webSocketHandler) track(ws *websocket.Conn) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return false
+	}
+	h.conns[ws] = struct{}{}
+	h.connWG.Add(1)
+	return true
+}
+
+func (h *webSocketHandler) untrack(ws *websocket.Conn) {
+	_ = ws.Close()
+	h.mu.Lock()
+	delete(h.conns, ws)
+	h.mu.Unlock()
+	h.connWG.Done()
+}
+
+// shutdown closes the connections and waits for their handlers to return.
+func (h *webSocketHandler) shutdown() {
+	h.mu.Lock()
+	h.closed = true
+	for ws := range h.conns {
+		_ = ws.Close()
+	}
+	h.mu.Unlock()
+	h.connWG.Wait()
 }
@@ -16,6 +16,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper/internal/metadata"
@@ -170,7 +171,8 @@ func (rec *ObsReport) startOp(receiverCtx context.Context, operationSuffix strin
 	return ctx
 }
 
-// endOp records the observability signals at the end of an operation.
+// endOp records the observability signals at the end of an operation. Only the items
+// rejected by a partial error (see consumererror.NewPartial) are recorded as refused.
 func (rec *ObsReport) endOp(
 	receiverCtx context.Context,
 	format string,
@@ -180,7 +182,10 @@ func (rec *ObsReport) endOp(
 ) {
 	numAccepted := numReceivedItems
 	numRefused := 0
-	if err != nil {
+	if consumererror.IsPartial(err) {
+		numRefused = min(consumererror.RejectedCount(err), numReceivedItems)
+		numAccepted = numReceivedItems - numRefused
+	} else if err != nil {
 		numAccepted = 0
 		numRefused = numReceivedItems
 	}
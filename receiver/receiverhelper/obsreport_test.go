@@ -15,6 +15,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/receiver"
 )
@@ -254,6 +255,32 @@ func TestCheckReceiverTracesViews(t *testing.T) {
 	assert.Error(t, tt.CheckReceiverTraces(transport, 0, 7))
 }
 
+func TestReceivePartialTracesOp(t *testing.T) {
+	tt, err := componenttest.SetupTelemetry(receiverID)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	rec, err := NewObsReport(ObsReportSettings{
+		ReceiverID:             receiverID,
+		Transport:              transport,
+		ReceiverCreateSettings: receiver.CreateSettings{ID: receiverID, TelemetrySettings: tt.TelemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()},
+	})
+	require.NoError(t, err)
+	ctx := rec.StartTracesOp(context.Background())
+	rec.EndTracesOp(ctx, format, 7, consumererror.NewPartial(errFake, 3))
+	// The rejected count is capped to the number of received items.
+	ctx = rec.StartTracesOp(context.Background())
+	rec.EndTracesOp(ctx, format, 2, consumererror.NewPartial(errFake, 3))
+
+	require.NoError(t, tt.CheckReceiverTraces(transport, 4, 5))
+
+	spans := tt.SpanRecorder.Ended()
+	require.Len(t, spans, 2)
+	require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedSpansKey, Value: attribute.Int64Value(4)})
+	require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedSpansKey, Value: attribute.Int64Value(3)})
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
 func TestCheckReceiverMetricsViews(t *testing.T) {
 	tt, err := componenttest.SetupTelemetry(receiverID)
 	require.NoError(t, err)
@@ -4,8 +4,11 @@
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/service/extensions"
 	"go.opentelemetry.io/collector/service/pipelines"
 	"go.opentelemetry.io/collector/service/telemetry"
@@ -21,6 +24,25 @@ type Config struct {
 
 	// Pipelines are the set of data pipelines configured for the service.
 	Pipelines pipelines.Config `mapstructure:"pipelines"`
+
+	// Shutdown is the configuration of the shutdown of the service.
+	Shutdown ShutdownConfig `mapstructure:"shutdown"`
+}
+
+// ShutdownConfig defines the configuration of the shutdown of the Service.
+type ShutdownConfig struct {
+	// DrainTimeout is the maximum duration to wait on shutdown, after stopping the receivers, for the
+	// data buffered by the pipelines, e.g. by the batch processor and the exporter queues, to be sent.
+	// The pipelines are not drained if zero, the default.
+	DrainTimeout time.Duration `mapstructure:"drain_timeout"`
+}
+
+// Validate checks if the ShutdownConfig configuration is valid.
+func (cfg *ShutdownConfig) Validate() error {
+	if cfg.DrainTimeout < 0 {
+		return errors.New("drain_timeout must not be negative")
+	}
+	return nil
 }
 
 func (cfg *Config) Validate() error {
@@ -28,9 +50,33 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("service::pipelines config validation failed: %w", err)
 	}
 
+	if err := cfg.Shutdown.Validate(); err != nil {
+		return fmt.Errorf("service::shutdown config validation failed: %w", err)
+	}
+
 	if err := cfg.Telemetry.Validate(); err != nil {
 		fmt.Printf("service::telemetry config validation failed: %v\n", err)
 	}
 
+	if err := cfg.validateTelemetryPipeline("traces", cfg.Telemetry.Traces.Pipeline, component.DataTypeTraces); err != nil {
+		return err
+	}
+	if err := cfg.validateTelemetryPipeline("metrics", cfg.Telemetry.Metrics.Pipeline, component.DataTypeMetrics); err != nil {
+		return err
+	}
+	return cfg.validateTelemetryPipeline("logs", cfg.Telemetry.Logs.Pipeline, component.DataTypeLogs)
+}
+
+// validateTelemetryPipeline validates that the pipeline the telemetry of the collector is routed into is configured.
+func (cfg *Config) validateTelemetryPipeline(signal string, pipelineID component.ID, dt component.DataType) error {
+	if pipelineID == (component.ID{}) {
+		return nil
+	}
+	if _, ok := cfg.Pipelines[pipelineID]; !ok {
+		return fmt.Errorf("service::telemetry::%s::pipeline: references pipeline %q which is not configured", signal, pipelineID)
+	}
+	if pipelineID.Type() != dt {
+		return fmt.Errorf("service::telemetry::%s::pipeline: references pipeline %q which is not a %s pipeline", signal, pipelineID, dt)
+	}
 	return nil
 }
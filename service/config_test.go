@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap/zapcore"
@@ -71,6 +72,51 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: nil,
 		},
+		{
+			name: "telemetry-pipeline",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Telemetry.Traces.Pipeline = component.MustNewID("traces")
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "telemetry-pipeline-not-configured",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Telemetry.Logs.Pipeline = component.MustNewID("logs")
+				return cfg
+			},
+			expected: errors.New(`service::telemetry::logs::pipeline: references pipeline "logs" which is not configured`),
+		},
+		{
+			name: "telemetry-pipeline-wrong-type",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Telemetry.Metrics.Pipeline = component.MustNewID("traces")
+				return cfg
+			},
+			expected: errors.New(`service::telemetry::metrics::pipeline: references pipeline "traces" which is not a metrics pipeline`),
+		},
+		{
+			name: "valid-drain-timeout",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Shutdown.DrainTimeout = 30 * time.Second
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "negative-drain-timeout",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Shutdown.DrainTimeout = -time.Second
+				return cfg
+			},
+			expected: fmt.Errorf(`service::shutdown config validation failed: %w`, errors.New(`drain_timeout must not be negative`)),
+		},
 	}
 
 	for _, test := range testCases {
@@ -4,7 +4,10 @@
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
+	"context"
+
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/extension"
@@ -34,8 +37,14 @@ type serviceHost struct {
 
 	pipelines         *graph.Graph
 	serviceExtensions *extensions.Extensions
+
+	// statusAggregator aggregates the status events of the components for the status zPage and
+	// the extensions.
+	statusAggregator *componentstatus.Aggregator
 }
 
+var _ componentstatus.Host = (*serviceHost)(nil)
+
 func (host *serviceHost) GetFactory(kind component.Kind, componentType component.Type) component.Factory {
 	switch kind {
 	case component.KindReceiver:
@@ -66,9 +75,80 @@ func (host *serviceHost) GetExporters() map[component.DataType]map[component.ID]
 	return host.pipelines.GetExporters()
 }
 
+// StatusAggregator implements componentstatus.Host.
+func (host *serviceHost) StatusAggregator() *componentstatus.Aggregator {
+	return host.statusAggregator
+}
+
 func (host *serviceHost) notifyComponentStatusChange(source *component.InstanceID, event *component.StatusEvent) {
+	host.statusAggregator.RecordStatus(source, event)
 	host.serviceExtensions.NotifyComponentStatusChange(source, event)
 	if event.Status() == component.StatusFatalError {
 		host.asyncErrorChannel <- event.Err()
 	}
 }
+
+// SetPipelinePaused pauses or resumes a pipeline at runtime. A paused pipeline refuses all data
+// with a non-permanent error until it is resumed.
+func (host *serviceHost) SetPipelinePaused(pipelineID component.ID, paused bool) error {
+	return host.pipelines.SetPipelinePaused(pipelineID, paused)
+}
+
+// SetProcessorBypassed bypasses or restores a processor of a pipeline at runtime. The data of the
+// pipeline is forwarded directly to the next consumer of a bypassed processor.
+func (host *serviceHost) SetProcessorBypassed(pipelineID component.ID, processorID component.ID, bypassed bool) error {
+	return host.pipelines.SetProcessorBypassed(pipelineID, processorID, bypassed)
+}
+
+// PausedPipelines returns the IDs of the paused pipelines.
+func (host *serviceHost) PausedPipelines() []component.ID {
+	return host.pipelines.PausedPipelines()
+}
+
+// BypassedProcessors returns the IDs of the bypassed processors of each pipeline.
+func (host *serviceHost) BypassedProcessors() map[component.ID][]component.ID {
+	return host.pipelines.BypassedProcessors()
+}
+
+// ValidateTapNode returns the error TapNode returns for the same node and settings, without
+// tapping the node.
+func (host *serviceHost) ValidateTapNode(pipelineID component.ID, kind component.Kind, componentID component.ID,
+	samplingRatio float64, rateLimit float64) error {
+	return host.pipelines.ValidateTap(graph.TapConfig{
+		PipelineID:    pipelineID,
+		Kind:          kind,
+		ComponentID:   componentID,
+		SamplingRatio: samplingRatio,
+		RateLimit:     rateLimit,
+	})
+}
+
+// TapNode streams a sample of the data flowing out of a receiver, processor or connector of a
+// pipeline, until the context is done or emit fails. Each message is an OTLP JSON encoded export
+// request, passed to emit. At most rateLimit messages are emitted per second, and the values of the
+// redacted attributes are replaced.
+func (host *serviceHost) TapNode(ctx context.Context, pipelineID component.ID, kind component.Kind, componentID component.ID,
+	samplingRatio float64, rateLimit float64, redactedAttributes []string, emit func([]byte) error) error {
+	tap, err := host.pipelines.Tap(graph.TapConfig{
+		PipelineID:         pipelineID,
+		Kind:               kind,
+		ComponentID:        componentID,
+		SamplingRatio:      samplingRatio,
+		RateLimit:          rateLimit,
+		RedactedAttributes: redactedAttributes,
+	})
+	if err != nil {
+		return err
+	}
+	defer tap.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg := <-tap.Data():
+			if err := emit(msg); err != nil {
+				return err
+			}
+		}
+	}
+}
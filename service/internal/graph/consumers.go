@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+// TracesConsumer returns the consumer feeding the processors and exporters of the traces pipeline,
+// as its receivers do.
+func (g *Graph) TracesConsumer(pipelineID component.ID) (consumer.Traces, error) {
+	pipe, err := g.pipelineOfType(pipelineID, component.DataTypeTraces)
+	if err != nil {
+		return nil, err
+	}
+	return pipe.capabilitiesNode, nil
+}
+
+// MetricsConsumer returns the consumer feeding the processors and exporters of the metrics
+// pipeline, as its receivers do.
+func (g *Graph) MetricsConsumer(pipelineID component.ID) (consumer.Metrics, error) {
+	pipe, err := g.pipelineOfType(pipelineID, component.DataTypeMetrics)
+	if err != nil {
+		return nil, err
+	}
+	return pipe.capabilitiesNode, nil
+}
+
+// LogsConsumer returns the consumer feeding the processors and exporters of the logs pipeline,
+// as its receivers do.
+func (g *Graph) LogsConsumer(pipelineID component.ID) (consumer.Logs, error) {
+	pipe, err := g.pipelineOfType(pipelineID, component.DataTypeLogs)
+	if err != nil {
+		return nil, err
+	}
+	return pipe.capabilitiesNode, nil
+}
+
+// PipelineComponents returns the instance IDs of the receivers, processors and exporters of the
+// pipeline, including the connectors.
+func (g *Graph) PipelineComponents(pipelineID component.ID) ([]*component.InstanceID, error) {
+	pipe, ok := g.pipelines[pipelineID]
+	if !ok {
+		return nil, fmt.Errorf("pipeline %q not found", pipelineID)
+	}
+	ret := make([]*component.InstanceID, 0, len(pipe.receivers)+len(pipe.processors)+len(pipe.exporters))
+	for _, n := range pipe.receivers {
+		ret = append(ret, g.instanceIDs[n.ID()])
+	}
+	for _, n := range pipe.processors {
+		ret = append(ret, g.instanceIDs[n.ID()])
+	}
+	for _, n := range pipe.exporters {
+		ret = append(ret, g.instanceIDs[n.ID()])
+	}
+	return ret, nil
+}
+
+func (g *Graph) pipelineOfType(pipelineID component.ID, dataType component.DataType) (*pipelineNodes, error) {
+	pipe, ok := g.pipelines[pipelineID]
+	if !ok {
+		return nil, fmt.Errorf("pipeline %q not found", pipelineID)
+	}
+	if pipelineID.Type() != dataType {
+		return nil, fmt.Errorf("pipeline %q is not a %s pipeline", pipelineID, dataType)
+	}
+	return pipe, nil
+}
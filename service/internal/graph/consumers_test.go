@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+)
+
+func TestGraphPipelineConsumers(t *testing.T) {
+	g, _ := buildControlTestGraph(t)
+	tracesID := component.MustNewID("traces")
+	exp := g.GetExporters()[component.DataTypeTraces][component.MustNewID("exampleexporter")].(*testcomponents.ExampleExporter)
+
+	tc, err := g.TracesConsumer(tracesID)
+	require.NoError(t, err)
+	require.NoError(t, tc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, exp.Traces, 1)
+
+	_, err = g.MetricsConsumer(tracesID)
+	assert.EqualError(t, err, `pipeline "traces" is not a metrics pipeline`)
+	_, err = g.LogsConsumer(component.MustNewID("logs"))
+	assert.EqualError(t, err, `pipeline "logs" not found`)
+}
+
+func TestGraphPipelineComponents(t *testing.T) {
+	g, _ := buildControlTestGraph(t)
+
+	instanceIDs, err := g.PipelineComponents(component.MustNewIDWithName("traces", "drop"))
+	require.NoError(t, err)
+	var ids []string
+	for _, instanceID := range instanceIDs {
+		ids = append(ids, instanceID.Kind.String()+":"+instanceID.ID.String())
+	}
+	assert.Equal(t, []string{
+		"Receiver:examplereceiver",
+		"Processor:exampleprocessor",
+		"Processor:drop",
+		"Exporter:exampleexporter",
+	}, ids)
+
+	_, err = g.PipelineComponents(component.MustNewID("logs"))
+	assert.EqualError(t, err, `pipeline "logs" not found`)
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+var errPipelinePaused = errors.New("pipeline is paused")
+
+const (
+	// pipelinePausedAttribute is set on the status events of the processors of a paused pipeline.
+	pipelinePausedAttribute = "pipeline.paused"
+	// processorBypassedAttribute is set on the status events of a bypassed processor.
+	processorBypassedAttribute = "processor.bypassed"
+)
+
+// SetPipelinePaused pauses or resumes the pipeline. A paused pipeline refuses all data with a
+// non-permanent error, so that receivers apply backpressure or ask their clients to retry.
+// The processors of a paused pipeline report a StatusOK with the pipeline.paused attribute, so
+// that pausing a pipeline does not make the collector unhealthy.
+func (g *Graph) SetPipelinePaused(pipelineID component.ID, paused bool) error {
+	g.controlMu.Lock()
+	defer g.controlMu.Unlock()
+
+	pipe, ok := g.pipelines[pipelineID]
+	if !ok {
+		return fmt.Errorf("pipeline %q not found", pipelineID)
+	}
+	if pipe.capabilitiesNode.paused.Swap(paused) != paused {
+		g.reportControlStatus(pipe)
+	}
+	return nil
+}
+
+// SetProcessorBypassed bypasses or restores the processor in the pipeline. The data of the
+// pipeline is forwarded directly to the next consumer of a bypassed processor, which reports a
+// StatusOK with the processor.bypassed attribute.
+func (g *Graph) SetProcessorBypassed(pipelineID component.ID, processorID component.ID, bypassed bool) error {
+	g.controlMu.Lock()
+	defer g.controlMu.Unlock()
+
+	pipe, ok := g.pipelines[pipelineID]
+	if !ok {
+		return fmt.Errorf("pipeline %q not found", pipelineID)
+	}
+	for _, proc := range pipe.processors {
+		if proc.componentID != processorID {
+			continue
+		}
+		if proc.bypassed.Swap(bypassed) != bypassed {
+			g.reportControlStatus(pipe)
+		}
+		return nil
+	}
+	return fmt.Errorf("processor %q not found in pipeline %q", processorID, pipelineID)
+}
+
+// PausedPipelines returns the IDs of the paused pipelines, sorted.
+func (g *Graph) PausedPipelines() []component.ID {
+	var ret []component.ID
+	for pipelineID, pipe := range g.pipelines {
+		if pipe.capabilitiesNode.paused.Load() {
+			ret = append(ret, pipelineID)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].String() < ret[j].String() })
+	return ret
+}
+
+// BypassedProcessors returns the IDs of the bypassed processors of each pipeline, in the order
+// of the pipeline.
+func (g *Graph) BypassedProcessors() map[component.ID][]component.ID {
+	ret := map[component.ID][]component.ID{}
+	for pipelineID, pipe := range g.pipelines {
+		for _, proc := range pipe.processors {
+			if proc.bypassed.Load() {
+				ret[pipelineID] = append(ret[pipelineID], proc.componentID)
+			}
+		}
+	}
+	return ret
+}
+
+// reportControlStatus reports the attributes of the processors of the pipeline after it was paused,
+// resumed, or one of its processors bypassed or restored. The processors reporting an error keep it.
+func (g *Graph) reportControlStatus(pipe *pipelineNodes) {
+	for _, proc := range pipe.processors {
+		attrs := pcommon.NewMap()
+		if pipe.capabilitiesNode.paused.Load() {
+			attrs.PutBool(pipelinePausedAttribute, true)
+		}
+		if proc.bypassed.Load() {
+			attrs.PutBool(processorBypassedAttribute, true)
+		}
+		g.telemetry.Status.ReportOKAttributes(g.instanceIDs[proc.ID()], attrs)
+	}
+}
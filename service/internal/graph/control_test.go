@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/servicetelemetry"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+var dropProcessorFactory = processor.NewFactory(
+	component.MustNewType("drop"),
+	func() component.Config { return &struct{}{} },
+	processor.WithTraces(func(ctx context.Context, set processor.CreateSettings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+		return processorhelper.NewTracesProcessor(ctx, set, cfg, next, func(context.Context, ptrace.Traces) (ptrace.Traces, error) {
+			return ptrace.Traces{}, processorhelper.ErrSkipProcessingData
+		})
+	}, component.StabilityLevelDevelopment),
+)
+
+func buildControlTestGraph(t *testing.T) (*Graph, map[string][]*component.StatusEvent) {
+	tracesID := component.MustNewID("traces")
+	tracesDropID := component.MustNewIDWithName("traces", "drop")
+	set := Settings{
+		Telemetry: servicetelemetry.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: receiver.NewBuilder(
+			map[component.ID]component.Config{
+				component.MustNewID("examplereceiver"): testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			}),
+		ProcessorBuilder: processor.NewBuilder(
+			map[component.ID]component.Config{
+				component.MustNewID("exampleprocessor"): testcomponents.ExampleProcessorFactory.CreateDefaultConfig(),
+				component.MustNewID("drop"):             dropProcessorFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]processor.Factory{
+				testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory,
+				dropProcessorFactory.Type():                   dropProcessorFactory,
+			}),
+		ExporterBuilder: exporter.NewBuilder(
+			map[component.ID]component.Config{
+				component.MustNewID("exampleexporter"): testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			}),
+		ConnectorBuilder: connector.NewBuilder(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			tracesID: {
+				Receivers:  []component.ID{component.MustNewID("examplereceiver")},
+				Processors: []component.ID{component.MustNewID("exampleprocessor")},
+				Exporters:  []component.ID{component.MustNewID("exampleexporter")},
+			},
+			tracesDropID: {
+				Receivers:  []component.ID{component.MustNewID("examplereceiver")},
+				Processors: []component.ID{component.MustNewID("exampleprocessor"), component.MustNewID("drop")},
+				Exporters:  []component.ID{component.MustNewID("exampleexporter")},
+			},
+		},
+	}
+
+	statuses := map[string][]*component.StatusEvent{}
+	rep := status.NewReporter(func(id *component.InstanceID, ev *component.StatusEvent) {
+		if id.Kind != component.KindProcessor {
+			return
+		}
+		for pipelineID := range id.PipelineIDs {
+			key := pipelineID.String() + ":" + id.ID.String()
+			statuses[key] = append(statuses[key], ev)
+		}
+	}, func(error) {})
+	set.Telemetry.Status = rep
+	rep.Ready()
+
+	g, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, g.StartAll(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, g.ShutdownAll(context.Background())) })
+	return g, statuses
+}
+
+func TestGraphSetProcessorBypassed(t *testing.T) {
+	g, statuses := buildControlTestGraph(t)
+	tracesDropID := component.MustNewIDWithName("traces", "drop")
+	dropID := component.MustNewID("drop")
+	pipe := g.pipelines[tracesDropID]
+	exp := g.GetExporters()[component.DataTypeTraces][component.MustNewID("exampleexporter")].(*testcomponents.ExampleExporter)
+
+	require.NoError(t, pipe.capabilitiesNode.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Empty(t, exp.Traces)
+
+	require.NoError(t, g.SetProcessorBypassed(tracesDropID, dropID, true))
+	assert.Equal(t, map[component.ID][]component.ID{tracesDropID: {dropID}}, g.BypassedProcessors())
+	require.NoError(t, pipe.capabilitiesNode.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, exp.Traces, 1)
+
+	require.NoError(t, g.SetProcessorBypassed(tracesDropID, dropID, false))
+	assert.Empty(t, g.BypassedProcessors())
+	require.NoError(t, pipe.capabilitiesNode.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, exp.Traces, 1)
+
+	// The runtime controls are reported as attributes of a StatusOK.
+	dropStatuses := statuses["traces/drop:drop"]
+	require.Len(t, dropStatuses, 4)
+	assert.Equal(t, component.StatusOK, dropStatuses[2].Status())
+	assert.Equal(t, map[string]any{processorBypassedAttribute: true}, dropStatuses[2].Attributes().AsRaw())
+	assert.Equal(t, component.StatusOK, dropStatuses[3].Status())
+	assert.Equal(t, 0, dropStatuses[3].Attributes().Len())
+	assert.Len(t, statuses["traces/drop:exampleprocessor"], 2)
+
+	assert.EqualError(t, g.SetProcessorBypassed(component.MustNewID("logs"), dropID, true), `pipeline "logs" not found`)
+	assert.EqualError(t, g.SetProcessorBypassed(component.MustNewID("traces"), dropID, true), `processor "drop" not found in pipeline "traces"`)
+}
+
+func TestGraphSetPipelinePaused(t *testing.T) {
+	g, statuses := buildControlTestGraph(t)
+	tracesID := component.MustNewID("traces")
+	pipe := g.pipelines[tracesID]
+	exp := g.GetExporters()[component.DataTypeTraces][component.MustNewID("exampleexporter")].(*testcomponents.ExampleExporter)
+
+	require.NoError(t, g.SetPipelinePaused(tracesID, true))
+	// Pausing twice does not report the status again.
+	require.NoError(t, g.SetPipelinePaused(tracesID, true))
+	assert.Equal(t, []component.ID{tracesID}, g.PausedPipelines())
+	assert.ErrorIs(t, pipe.capabilitiesNode.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)), errPipelinePaused)
+	assert.Empty(t, exp.Traces)
+
+	require.NoError(t, g.SetPipelinePaused(tracesID, false))
+	assert.Empty(t, g.PausedPipelines())
+	require.NoError(t, pipe.capabilitiesNode.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, exp.Traces, 1)
+
+	// The runtime controls are reported as attributes of a StatusOK.
+	procStatuses := statuses["traces:exampleprocessor"]
+	require.Len(t, procStatuses, 4)
+	assert.Equal(t, component.StatusOK, procStatuses[2].Status())
+	assert.Equal(t, map[string]any{pipelinePausedAttribute: true}, procStatuses[2].Attributes().AsRaw())
+	assert.Equal(t, component.StatusOK, procStatuses[3].Status())
+	assert.Equal(t, 0, procStatuses[3].Attributes().Len())
+
+	assert.EqualError(t, g.SetPipelinePaused(component.MustNewID("logs"), true), `pipeline "logs" not found`)
+}
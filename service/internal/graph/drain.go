@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"gonum.org/v1/gonum/graph/topo"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service/internal/components"
+)
+
+// drainer is implemented by the components buffering data, e.g. the batch processor and the
+// exporters with a sending queue, to send the buffered data before shutting down.
+type drainer interface {
+	// Drain sends the buffered data and waits until it is sent, or the context is done. It returns
+	// the number of items still buffered when the context is done.
+	Drain(ctx context.Context) int
+}
+
+// DrainAll shuts down the receivers, so that the pipelines stop accepting data, and waits until the
+// components buffering data have sent it downstream, or the context is done. The other components
+// report StatusStopping until they are shut down by ShutdownAll.
+//
+// It returns the number of items still buffered by the components when the context is done, which
+// may be lost on shutdown, and logs them per component.
+func (g *Graph) DrainAll(ctx context.Context) (int, error) {
+	nodes, err := topo.Sort(g.componentGraph)
+	if err != nil {
+		return 0, err
+	}
+	g.drained = true
+
+	var errs error
+	for _, node := range nodes {
+		comp, ok := node.(component.Component)
+		if !ok {
+			// Skip capabilities/fanout nodes
+			continue
+		}
+		instanceID := g.instanceIDs[node.ID()]
+		g.telemetry.Status.ReportStatus(instanceID, component.NewStatusEvent(component.StatusStopping))
+		if _, ok = node.(*receiverNode); !ok {
+			continue
+		}
+		if compErr := comp.Shutdown(ctx); compErr != nil {
+			errs = multierr.Append(errs, compErr)
+			g.telemetry.Status.ReportStatus(instanceID, component.NewPermanentErrorEvent(compErr))
+			continue
+		}
+		g.telemetry.Status.ReportStatus(instanceID, component.NewStatusEvent(component.StatusStopped))
+	}
+
+	// Drain in topological order so that the data drained by upstream components can be drained
+	// by the downstream components.
+	lost := 0
+	for _, node := range nodes {
+		var (
+			comp   component.Component
+			logger *zap.Logger
+		)
+		switch n := node.(type) {
+		case *processorNode:
+			comp, logger = n.Component, components.ProcessorLogger(g.telemetry.Logger, n.componentID, n.pipelineID)
+		case *exporterNode:
+			comp, logger = n.Component, components.ExporterLogger(g.telemetry.Logger, n.componentID, n.pipelineType)
+		case *connectorNode:
+			comp, logger = n.Component, components.ConnectorLogger(g.telemetry.Logger, n.componentID, n.exprPipelineType, n.rcvrPipelineType)
+		default:
+			continue
+		}
+		d, ok := comp.(drainer)
+		if !ok {
+			continue
+		}
+		if items := d.Drain(ctx); items > 0 {
+			logger.Warn("Drain timeout expired before the buffered data was sent, the data may be lost.", zap.Int("lost_items", items))
+			lost += items
+		}
+	}
+	return lost, errs
+}
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/servicetelemetry"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+// bufferExporter buffers the spans until drained, the stuck exporters never send them.
+type bufferExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	stuck    bool
+	buffered int
+	sent     int
+}
+
+func (e *bufferExporter) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
+	e.buffered += td.SpanCount()
+	return nil
+}
+
+func (e *bufferExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+func (e *bufferExporter) Drain(ctx context.Context) int {
+	if e.stuck {
+		<-ctx.Done()
+		return e.buffered
+	}
+	e.sent += e.buffered
+	e.buffered = 0
+	return 0
+}
+
+var bufferExporterFactory = exporter.NewFactory(
+	component.MustNewType("buffer"),
+	func() component.Config { return &struct{}{} },
+	exporter.WithTraces(func(_ context.Context, set exporter.CreateSettings, _ component.Config) (exporter.Traces, error) {
+		return &bufferExporter{stuck: set.ID.Name() == "stuck"}, nil
+	}, component.StabilityLevelDevelopment),
+)
+
+func TestGraphDrainAll(t *testing.T) {
+	bufferID := component.MustNewID("buffer")
+	stuckID := component.MustNewIDWithName("buffer", "stuck")
+	set := Settings{
+		Telemetry: servicetelemetry.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: receiver.NewBuilder(
+			map[component.ID]component.Config{
+				component.MustNewID("examplereceiver"): testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			}),
+		ProcessorBuilder: processor.NewBuilder(map[component.ID]component.Config{}, map[component.Type]processor.Factory{}),
+		ExporterBuilder: exporter.NewBuilder(
+			map[component.ID]component.Config{
+				bufferID: bufferExporterFactory.CreateDefaultConfig(),
+				stuckID:  bufferExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				bufferExporterFactory.Type(): bufferExporterFactory,
+			}),
+		ConnectorBuilder: connector.NewBuilder(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			component.MustNewID("traces"): {
+				Receivers: []component.ID{component.MustNewID("examplereceiver")},
+				Exporters: []component.ID{bufferID, stuckID},
+			},
+		},
+	}
+
+	statuses := map[string][]component.Status{}
+	rep := status.NewReporter(func(id *component.InstanceID, ev *component.StatusEvent) {
+		key := id.Kind.String() + ":" + id.ID.String()
+		statuses[key] = append(statuses[key], ev.Status())
+	}, func(err error) { require.NoError(t, err) })
+	set.Telemetry.Status = rep
+	rep.Ready()
+
+	g, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, g.StartAll(context.Background(), componenttest.NewNopHost()))
+
+	rcvNode := g.pipelines[component.MustNewID("traces")].receivers
+	var rcv *testcomponents.ExampleReceiver
+	for _, n := range rcvNode {
+		rcv = n.(*receiverNode).Component.(*testcomponents.ExampleReceiver)
+	}
+	require.NoError(t, rcv.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	lost, err := g.DrainAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, lost)
+	assert.True(t, rcv.Stopped())
+
+	exporters := g.GetExporters()[component.DataTypeTraces]
+	assert.Equal(t, 3, exporters[bufferID].(*bufferExporter).sent)
+	assert.Equal(t, 3, exporters[stuckID].(*bufferExporter).buffered)
+	assert.Equal(t, []component.Status{component.StatusStarting, component.StatusOK, component.StatusStopping, component.StatusStopped},
+		statuses["Receiver:examplereceiver"])
+	assert.Equal(t, []component.Status{component.StatusStarting, component.StatusOK, component.StatusStopping},
+		statuses["Exporter:buffer/stuck"])
+
+	require.NoError(t, g.ShutdownAll(context.Background()))
+	assert.Equal(t, []component.Status{component.StatusStarting, component.StatusOK, component.StatusStopping, component.StatusStopped},
+		statuses["Receiver:examplereceiver"])
+	assert.Equal(t, []component.Status{component.StatusStarting, component.StatusOK, component.StatusStopping, component.StatusStopped},
+		statuses["Exporter:buffer/stuck"])
+}
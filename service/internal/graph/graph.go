@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"go.uber.org/multierr"
 	"gonum.org/v1/gonum/graph"
@@ -19,6 +20,10 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/internal/fanoutconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service/internal/capabilityconsumer"
@@ -51,6 +56,12 @@ type Graph struct {
 	instanceIDs map[int64]*component.InstanceID
 
 	telemetry servicetelemetry.TelemetrySettings
+
+	// controlMu serializes the runtime changes of the pipelines, see SetPipelinePaused.
+	controlMu sync.Mutex
+
+	// drained is true once DrainAll has shut down the receivers.
+	drained bool
 }
 
 // Build builds a full pipeline graph.
@@ -100,7 +111,7 @@ func (g *Graph) createNodes(set Settings) error {
 			pipe.receivers[rcvrNode.ID()] = rcvrNode
 		}
 
-		pipe.capabilitiesNode = newCapabilitiesNode(pipelineID)
+		pipe.capabilitiesNode = newCapabilitiesNode(pipelineID, pipelineCfg.Limits)
 
 		for _, procID := range pipelineCfg.Processors {
 			procNode := g.createProcessor(pipelineID, procID)
@@ -316,19 +327,62 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 			case component.DataTypeTraces:
 				cc := capabilityconsumer.NewTraces(next.(consumer.Traces), capability)
 				n.baseConsumer = cc
-				n.ConsumeTracesFunc = cc.ConsumeTraces
+				n.ConsumeTracesFunc = func(ctx context.Context, td ptrace.Traces) error {
+					if n.paused.Load() {
+						return errPipelinePaused
+					}
+					release, err := n.limiter.acquire(td.SpanCount(), func() int { return tracesSizer.TracesSize(td) })
+					if err != nil {
+						return err
+					}
+					defer release()
+					n.record(ctx, td)
+					return cc.ConsumeTraces(ctx, td)
+				}
 			case component.DataTypeMetrics:
 				cc := capabilityconsumer.NewMetrics(next.(consumer.Metrics), capability)
 				n.baseConsumer = cc
-				n.ConsumeMetricsFunc = cc.ConsumeMetrics
+				n.ConsumeMetricsFunc = func(ctx context.Context, md pmetric.Metrics) error {
+					if n.paused.Load() {
+						return errPipelinePaused
+					}
+					release, err := n.limiter.acquire(md.DataPointCount(), func() int { return metricsSizer.MetricsSize(md) })
+					if err != nil {
+						return err
+					}
+					defer release()
+					n.record(ctx, md)
+					return cc.ConsumeMetrics(ctx, md)
+				}
 			case component.DataTypeLogs:
 				cc := capabilityconsumer.NewLogs(next.(consumer.Logs), capability)
 				n.baseConsumer = cc
-				n.ConsumeLogsFunc = cc.ConsumeLogs
+				n.ConsumeLogsFunc = func(ctx context.Context, ld plog.Logs) error {
+					if n.paused.Load() {
+						return errPipelinePaused
+					}
+					release, err := n.limiter.acquire(ld.LogRecordCount(), func() int { return logsSizer.LogsSize(ld) })
+					if err != nil {
+						return err
+					}
+					defer release()
+					n.record(ctx, ld)
+					return cc.ConsumeLogs(ctx, ld)
+				}
 			case component.DataTypeProfiles:
 				cc := capabilityconsumer.NewProfiles(next.(consumer.Profiles), capability)
 				n.baseConsumer = cc
-				n.ConsumeProfilesFunc = cc.ConsumeProfiles
+				n.ConsumeProfilesFunc = func(ctx context.Context, pd pprofile.Profiles) error {
+					if n.paused.Load() {
+						return errPipelinePaused
+					}
+					release, err := n.limiter.acquire(pd.ProfileCount(), func() int { return profilesSizer.ProfilesSize(pd) })
+					if err != nil {
+						return err
+					}
+					defer release()
+					return cc.ConsumeProfiles(ctx, pd)
+				}
 			}
 		case *fanOutNode:
 			nexts := g.nextConsumers(n.ID())
@@ -453,10 +507,17 @@ func (g *Graph) ShutdownAll(ctx context.Context) error {
 		}
 
 		instanceID := g.instanceIDs[node.ID()]
-		g.telemetry.Status.ReportStatus(
-			instanceID,
-			component.NewStatusEvent(component.StatusStopping),
-		)
+		if g.drained {
+			// The receivers are already shut down, and the other components are stopping.
+			if _, ok = node.(*receiverNode); ok {
+				continue
+			}
+		} else {
+			g.telemetry.Status.ReportStatus(
+				instanceID,
+				component.NewStatusEvent(component.StatusStopping),
+			)
+		}
 
 		if compErr := comp.Shutdown(ctx); compErr != nil {
 			errs = multierr.Append(errs, compErr)
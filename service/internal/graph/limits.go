@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+// errPipelineLimitExceeded is returned when the data is refused by a pipeline because one of its limits is exceeded.
+var errPipelineLimitExceeded = errors.New("pipeline limit exceeded")
+
+// Sizers of the data entering the pipelines whose bytes are limited.
+var (
+	tracesSizer   = &ptrace.ProtoMarshaler{}
+	metricsSizer  = &pmetric.ProtoMarshaler{}
+	logsSizer     = &plog.ProtoMarshaler{}
+	profilesSizer = &pprofile.ProtoMarshaler{}
+)
+
+// pipelineLimiter enforces the limits of a pipeline on the data entering it, see pipelines.LimitsConfig.
+// The data is released when the call consuming it returns, even if a component buffers it.
+// A nil pipelineLimiter enforces no limit.
+type pipelineLimiter struct {
+	cfg pipelines.LimitsConfig
+
+	items atomic.Int64
+	bytes atomic.Int64
+	calls atomic.Int64
+}
+
+func newPipelineLimiter(cfg pipelines.LimitsConfig) *pipelineLimiter {
+	if cfg == (pipelines.LimitsConfig{}) {
+		return nil
+	}
+	return &pipelineLimiter{cfg: cfg}
+}
+
+// acquire admits a call consuming the given number of items, and the number of bytes returned by
+// size, computed only if the bytes are limited. The returned function must be called once the
+// call returns.
+func (l *pipelineLimiter) acquire(items int, size func() int) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	var bytes int64
+	if l.cfg.MaxInFlightBytes > 0 {
+		bytes = int64(size())
+	}
+
+	// Data larger than the limits can never be admitted, retrying it is pointless.
+	if l.cfg.MaxInFlightItems > 0 && int64(items) > l.cfg.MaxInFlightItems {
+		return nil, consumererror.NewPermanent(fmt.Errorf("%w: %d items exceed max_in_flight_items %d", errPipelineLimitExceeded, items, l.cfg.MaxInFlightItems))
+	}
+	if l.cfg.MaxInFlightBytes > 0 && bytes > l.cfg.MaxInFlightBytes {
+		return nil, consumererror.NewPermanent(fmt.Errorf("%w: %d bytes exceed max_in_flight_bytes %d", errPipelineLimitExceeded, bytes, l.cfg.MaxInFlightBytes))
+	}
+
+	if l.cfg.MaxConcurrentCalls > 0 && l.calls.Add(1) > l.cfg.MaxConcurrentCalls {
+		l.calls.Add(-1)
+		return nil, fmt.Errorf("%w: max_concurrent_calls %d", errPipelineLimitExceeded, l.cfg.MaxConcurrentCalls)
+	}
+	if l.cfg.MaxInFlightItems > 0 && l.items.Add(int64(items)) > l.cfg.MaxInFlightItems {
+		l.items.Add(-int64(items))
+		l.release(0, 0)
+		return nil, fmt.Errorf("%w: max_in_flight_items %d", errPipelineLimitExceeded, l.cfg.MaxInFlightItems)
+	}
+	if l.cfg.MaxInFlightBytes > 0 && l.bytes.Add(bytes) > l.cfg.MaxInFlightBytes {
+		l.bytes.Add(-bytes)
+		l.release(int64(items), 0)
+		return nil, fmt.Errorf("%w: max_in_flight_bytes %d", errPipelineLimitExceeded, l.cfg.MaxInFlightBytes)
+	}
+	return func() { l.release(int64(items), bytes) }, nil
+}
+
+func (l *pipelineLimiter) release(items, bytes int64) {
+	if l.cfg.MaxConcurrentCalls > 0 {
+		l.calls.Add(-1)
+	}
+	if l.cfg.MaxInFlightItems > 0 {
+		l.items.Add(-items)
+	}
+	if l.cfg.MaxInFlightBytes > 0 {
+		l.bytes.Add(-bytes)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+func TestPipelineLimiterNoLimits(t *testing.T) {
+	l := newPipelineLimiter(pipelines.LimitsConfig{})
+	assert.Nil(t, l)
+	release, err := l.acquire(1000, func() int { panic("size must not be computed") })
+	require.NoError(t, err)
+	release()
+}
+
+func TestPipelineLimiterConcurrentCalls(t *testing.T) {
+	l := newPipelineLimiter(pipelines.LimitsConfig{MaxConcurrentCalls: 2})
+
+	release1, err := l.acquire(1, nil)
+	require.NoError(t, err)
+	release2, err := l.acquire(1, nil)
+	require.NoError(t, err)
+	_, err = l.acquire(1, nil)
+	assert.ErrorIs(t, err, errPipelineLimitExceeded)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	release1()
+	release3, err := l.acquire(1, nil)
+	require.NoError(t, err)
+	release2()
+	release3()
+	assert.Zero(t, l.calls.Load())
+}
+
+func TestPipelineLimiterInFlightItems(t *testing.T) {
+	l := newPipelineLimiter(pipelines.LimitsConfig{MaxInFlightItems: 10, MaxConcurrentCalls: 5})
+
+	release, err := l.acquire(6, nil)
+	require.NoError(t, err)
+	_, err = l.acquire(5, nil)
+	assert.ErrorIs(t, err, errPipelineLimitExceeded)
+	assert.False(t, consumererror.IsPermanent(err))
+	assert.EqualValues(t, 1, l.calls.Load())
+
+	_, err = l.acquire(11, nil)
+	assert.ErrorIs(t, err, errPipelineLimitExceeded)
+	assert.True(t, consumererror.IsPermanent(err))
+
+	release()
+	release, err = l.acquire(10, nil)
+	require.NoError(t, err)
+	release()
+	assert.Zero(t, l.items.Load())
+	assert.Zero(t, l.calls.Load())
+}
+
+func TestPipelineLimiterInFlightBytes(t *testing.T) {
+	l := newPipelineLimiter(pipelines.LimitsConfig{MaxInFlightBytes: 100, MaxInFlightItems: 10})
+
+	release, err := l.acquire(1, func() int { return 60 })
+	require.NoError(t, err)
+	_, err = l.acquire(1, func() int { return 60 })
+	assert.ErrorIs(t, err, errPipelineLimitExceeded)
+	assert.EqualValues(t, 1, l.items.Load())
+
+	_, err = l.acquire(1, func() int { return 101 })
+	assert.True(t, consumererror.IsPermanent(err))
+
+	release()
+	assert.Zero(t, l.bytes.Load())
+	assert.Zero(t, l.items.Load())
+}
+
+func TestGraphPipelineLimits(t *testing.T) {
+	g, _ := buildControlTestGraph(t)
+	// Limit the traces pipeline only, after the graph is built.
+	limited := g.pipelines[component.MustNewID("traces")].capabilitiesNode
+	limited.limiter = newPipelineLimiter(pipelines.LimitsConfig{MaxInFlightItems: 1})
+	exp := g.GetExporters()[component.DataTypeTraces][component.MustNewID("exampleexporter")].(*testcomponents.ExampleExporter)
+
+	err := limited.ConsumeTraces(context.Background(), testdata.GenerateTraces(2))
+	assert.ErrorIs(t, err, errPipelineLimitExceeded)
+	assert.Empty(t, exp.Traces)
+
+	require.NoError(t, limited.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, exp.Traces, 1)
+	assert.Zero(t, limited.limiter.items.Load())
+}
@@ -8,16 +8,22 @@ import (
 	"fmt"
 	"hash/fnv"
 	"strings"
+	"sync/atomic"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/internal/fanoutconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service/internal/capabilityconsumer"
 	"go.opentelemetry.io/collector/service/internal/components"
+	"go.opentelemetry.io/collector/service/pipelines"
 )
 
 const (
@@ -57,6 +63,8 @@ type receiverNode struct {
 	componentID  component.ID
 	pipelineType component.DataType
 	component.Component
+	// tap is the output of the receiver, see Graph.Tap.
+	tap *tapPoint
 }
 
 func newReceiverNode(pipelineType component.DataType, recvID component.ID) *receiverNode {
@@ -64,6 +72,7 @@ func newReceiverNode(pipelineType component.DataType, recvID component.ID) *rece
 		nodeID:       newNodeID(receiverSeed, pipelineType.String(), recvID.String()),
 		componentID:  recvID,
 		pipelineType: pipelineType,
+		tap:          &tapPoint{},
 	}
 }
 
@@ -82,19 +91,19 @@ func (n *receiverNode) buildComponent(ctx context.Context,
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Traces))
 		}
-		n.Component, err = builder.CreateTraces(ctx, set, fanoutconsumer.NewTraces(consumers))
+		n.Component, err = builder.CreateTraces(ctx, set, n.tap.wrapTraces(fanoutconsumer.NewTraces(consumers)))
 	case component.DataTypeMetrics:
 		var consumers []consumer.Metrics
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Metrics))
 		}
-		n.Component, err = builder.CreateMetrics(ctx, set, fanoutconsumer.NewMetrics(consumers))
+		n.Component, err = builder.CreateMetrics(ctx, set, n.tap.wrapMetrics(fanoutconsumer.NewMetrics(consumers)))
 	case component.DataTypeLogs:
 		var consumers []consumer.Logs
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Logs))
 		}
-		n.Component, err = builder.CreateLogs(ctx, set, fanoutconsumer.NewLogs(consumers))
+		n.Component, err = builder.CreateLogs(ctx, set, n.tap.wrapLogs(fanoutconsumer.NewLogs(consumers)))
 	case component.DataTypeProfiles:
 		var consumers []consumer.Profiles
 		for _, next := range nexts {
@@ -119,6 +128,12 @@ type processorNode struct {
 	componentID component.ID
 	pipelineID  component.ID
 	component.Component
+
+	// consumer consumes with the processor, or directly with the next consumer while bypassed.
+	consumer baseConsumer
+	bypassed atomic.Bool
+	// tap is the output of the processor, see Graph.Tap.
+	tap *tapPoint
 }
 
 func newProcessorNode(pipelineID, procID component.ID) *processorNode {
@@ -126,11 +141,12 @@ func newProcessorNode(pipelineID, procID component.ID) *processorNode {
 		nodeID:      newNodeID(processorSeed, pipelineID.String(), procID.String()),
 		componentID: procID,
 		pipelineID:  pipelineID,
+		tap:         &tapPoint{},
 	}
 }
 
 func (n *processorNode) getConsumer() baseConsumer {
-	return n.Component.(baseConsumer)
+	return n.consumer
 }
 
 func (n *processorNode) buildComponent(ctx context.Context,
@@ -141,6 +157,7 @@ func (n *processorNode) buildComponent(ctx context.Context,
 ) error {
 	set := processor.CreateSettings{ID: n.componentID, TelemetrySettings: tel, BuildInfo: info}
 	set.TelemetrySettings.Logger = components.ProcessorLogger(set.TelemetrySettings.Logger, n.componentID, n.pipelineID)
+	next = n.tap.wrap(next, n.pipelineID.Type())
 	var err error
 	switch n.pipelineID.Type() {
 	case component.DataTypeTraces:
@@ -157,7 +174,50 @@ func (n *processorNode) buildComponent(ctx context.Context,
 	if err != nil {
 		return fmt.Errorf("failed to create %q processor, in pipeline %q: %w", set.ID, n.pipelineID, err)
 	}
-	return nil
+	n.consumer, err = n.newBypassableConsumer(next)
+	return err
+}
+
+// newBypassableConsumer returns a consumer forwarding the data to the processor, or directly to
+// next while the processor is bypassed. It keeps the capabilities of the processor, so that the
+// capabilities of the pipeline do not change when the processor is bypassed.
+func (n *processorNode) newBypassableConsumer(next baseConsumer) (baseConsumer, error) {
+	capabilities := consumer.WithCapabilities(n.Component.(baseConsumer).Capabilities())
+	switch n.pipelineID.Type() {
+	case component.DataTypeTraces:
+		proc, nextTraces := n.Component.(consumer.Traces), next.(consumer.Traces)
+		return consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+			if n.bypassed.Load() {
+				return nextTraces.ConsumeTraces(ctx, td)
+			}
+			return proc.ConsumeTraces(ctx, td)
+		}, capabilities)
+	case component.DataTypeMetrics:
+		proc, nextMetrics := n.Component.(consumer.Metrics), next.(consumer.Metrics)
+		return consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+			if n.bypassed.Load() {
+				return nextMetrics.ConsumeMetrics(ctx, md)
+			}
+			return proc.ConsumeMetrics(ctx, md)
+		}, capabilities)
+	case component.DataTypeLogs:
+		proc, nextLogs := n.Component.(consumer.Logs), next.(consumer.Logs)
+		return consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+			if n.bypassed.Load() {
+				return nextLogs.ConsumeLogs(ctx, ld)
+			}
+			return proc.ConsumeLogs(ctx, ld)
+		}, capabilities)
+	case component.DataTypeProfiles:
+		proc, nextProfiles := n.Component.(consumer.Profiles), next.(consumer.Profiles)
+		return consumer.NewProfiles(func(ctx context.Context, pd pprofile.Profiles) error {
+			if n.bypassed.Load() {
+				return nextProfiles.ConsumeProfiles(ctx, pd)
+			}
+			return proc.ConsumeProfiles(ctx, pd)
+		}, capabilities)
+	}
+	return n.Component.(baseConsumer), nil
 }
 
 var _ consumerNode = &exporterNode{}
@@ -221,6 +281,8 @@ type connectorNode struct {
 	rcvrPipelineType component.DataType
 	component.Component
 	baseConsumer
+	// taps are the outputs of the connector to each pipeline, see Graph.Tap.
+	taps map[component.ID]*tapPoint
 }
 
 func newConnectorNode(exprPipelineType, rcvrPipelineType component.DataType, connID component.ID) *connectorNode {
@@ -229,6 +291,7 @@ func newConnectorNode(exprPipelineType, rcvrPipelineType component.DataType, con
 		componentID:      connID,
 		exprPipelineType: exprPipelineType,
 		rcvrPipelineType: rcvrPipelineType,
+		taps:             make(map[component.ID]*tapPoint),
 	}
 }
 
@@ -251,7 +314,9 @@ func (n *connectorNode) buildComponent(
 		capability := consumer.Capabilities{MutatesData: false}
 		consumers := make(map[component.ID]consumer.Traces, len(nexts))
 		for _, next := range nexts {
-			consumers[next.(*capabilitiesNode).pipelineID] = next.(consumer.Traces)
+			pipelineID := next.(*capabilitiesNode).pipelineID
+			n.taps[pipelineID] = &tapPoint{}
+			consumers[pipelineID] = n.taps[pipelineID].wrapTraces(next.(consumer.Traces))
 			capability.MutatesData = capability.MutatesData || next.Capabilities().MutatesData
 		}
 		next := connector.NewTracesRouter(consumers)
@@ -293,7 +358,9 @@ func (n *connectorNode) buildComponent(
 		capability := consumer.Capabilities{MutatesData: false}
 		consumers := make(map[component.ID]consumer.Metrics, len(nexts))
 		for _, next := range nexts {
-			consumers[next.(*capabilitiesNode).pipelineID] = next.(consumer.Metrics)
+			pipelineID := next.(*capabilitiesNode).pipelineID
+			n.taps[pipelineID] = &tapPoint{}
+			consumers[pipelineID] = n.taps[pipelineID].wrapMetrics(next.(consumer.Metrics))
 			capability.MutatesData = capability.MutatesData || next.Capabilities().MutatesData
 		}
 		next := connector.NewMetricsRouter(consumers)
@@ -334,7 +401,9 @@ func (n *connectorNode) buildComponent(
 		capability := consumer.Capabilities{MutatesData: false}
 		consumers := make(map[component.ID]consumer.Logs, len(nexts))
 		for _, next := range nexts {
-			consumers[next.(*capabilitiesNode).pipelineID] = next.(consumer.Logs)
+			pipelineID := next.(*capabilitiesNode).pipelineID
+			n.taps[pipelineID] = &tapPoint{}
+			consumers[pipelineID] = n.taps[pipelineID].wrapLogs(next.(consumer.Logs))
 			capability.MutatesData = capability.MutatesData || next.Capabilities().MutatesData
 		}
 		next := connector.NewLogsRouter(consumers)
@@ -425,6 +494,12 @@ var _ consumerNode = &capabilitiesNode{}
 type capabilitiesNode struct {
 	nodeID
 	pipelineID component.ID
+	// paused makes the pipeline refuse all data, see Graph.SetPipelinePaused.
+	paused atomic.Bool
+	// limiter enforces the limits of the pipeline on the data entering it.
+	limiter *pipelineLimiter
+	// recorder receives the data entering the pipeline, see Graph.RecordPipeline.
+	recorder atomic.Pointer[RecordFunc]
 	baseConsumer
 	consumer.ConsumeTracesFunc
 	consumer.ConsumeMetricsFunc
@@ -432,10 +507,11 @@ type capabilitiesNode struct {
 	consumer.ConsumeProfilesFunc
 }
 
-func newCapabilitiesNode(pipelineID component.ID) *capabilitiesNode {
+func newCapabilitiesNode(pipelineID component.ID, limits pipelines.LimitsConfig) *capabilitiesNode {
 	return &capabilitiesNode{
 		nodeID:     newNodeID(capabilitiesSeed, pipelineID.String()),
 		pipelineID: pipelineID,
+		limiter:    newPipelineLimiter(limits),
 	}
 }
 
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// RecordFunc receives the context and the data of a batch entering a pipeline, a ptrace.Traces,
+// pmetric.Metrics or plog.Logs. It is called concurrently, and must not modify nor retain the data.
+type RecordFunc func(ctx context.Context, data any)
+
+// RecordPipeline calls record with each batch of data entering the pipeline, once accepted by the
+// limits of the pipeline, until the returned stop function is called. A pipeline is recorded by a
+// single recorder at a time.
+func (g *Graph) RecordPipeline(pipelineID component.ID, record RecordFunc) (func(), error) {
+	pipe, ok := g.pipelines[pipelineID]
+	if !ok {
+		return nil, fmt.Errorf("pipeline %q not found", pipelineID)
+	}
+	if pipelineID.Type() == component.DataTypeProfiles {
+		return nil, errors.New("profiles pipelines cannot be recorded")
+	}
+	n := pipe.capabilitiesNode
+	if !n.recorder.CompareAndSwap(nil, &record) {
+		return nil, fmt.Errorf("pipeline %q is already recorded", pipelineID)
+	}
+	return func() { n.recorder.CompareAndSwap(&record, nil) }, nil
+}
+
+// ConsumePipeline passes the data, a ptrace.Traces, pmetric.Metrics or plog.Logs, to the pipeline
+// as if it was received by the receivers of the pipeline.
+func (g *Graph) ConsumePipeline(ctx context.Context, pipelineID component.ID, data any) error {
+	pipe, ok := g.pipelines[pipelineID]
+	if !ok {
+		return fmt.Errorf("pipeline %q not found", pipelineID)
+	}
+	n := pipe.capabilitiesNode
+	switch d := data.(type) {
+	case ptrace.Traces:
+		if pipelineID.Type() == component.DataTypeTraces {
+			return n.ConsumeTraces(ctx, d)
+		}
+	case pmetric.Metrics:
+		if pipelineID.Type() == component.DataTypeMetrics {
+			return n.ConsumeMetrics(ctx, d)
+		}
+	case plog.Logs:
+		if pipelineID.Type() == component.DataTypeLogs {
+			return n.ConsumeLogs(ctx, d)
+		}
+	}
+	return fmt.Errorf("pipeline %q does not consume %T", pipelineID, data)
+}
+
+func (n *capabilitiesNode) record(ctx context.Context, data any) {
+	if record := n.recorder.Load(); record != nil {
+		(*record)(ctx, data)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+)
+
+func TestGraphRecordPipeline(t *testing.T) {
+	g, _ := buildControlTestGraph(t)
+	tracesID := component.MustNewID("traces")
+	exp := g.GetExporters()[component.DataTypeTraces][component.MustNewID("exampleexporter")].(*testcomponents.ExampleExporter)
+
+	var infos []client.Info
+	var recorded []any
+	stop, err := g.RecordPipeline(tracesID, func(ctx context.Context, data any) {
+		infos = append(infos, client.FromContext(ctx))
+		recorded = append(recorded, data)
+	})
+	require.NoError(t, err)
+	_, err = g.RecordPipeline(tracesID, func(context.Context, any) {})
+	assert.EqualError(t, err, `pipeline "traces" is already recorded`)
+
+	info := client.Info{Metadata: client.NewMetadata(map[string][]string{"tenant": {"acme"}})}
+	require.NoError(t, g.ConsumePipeline(client.NewContext(context.Background(), info), tracesID, testdata.GenerateTraces(1)))
+	assert.Equal(t, []any{testdata.GenerateTraces(1)}, recorded)
+	assert.Equal(t, []client.Info{info}, infos)
+	assert.Len(t, exp.Traces, 1)
+
+	// The data refused by a paused pipeline does not enter it.
+	require.NoError(t, g.SetPipelinePaused(tracesID, true))
+	assert.Error(t, g.ConsumePipeline(context.Background(), tracesID, testdata.GenerateTraces(1)))
+	require.NoError(t, g.SetPipelinePaused(tracesID, false))
+	assert.Len(t, recorded, 1)
+
+	stop()
+	require.NoError(t, g.ConsumePipeline(context.Background(), tracesID, testdata.GenerateTraces(1)))
+	assert.Len(t, recorded, 1)
+	assert.Len(t, exp.Traces, 2)
+
+	// The pipeline can be recorded again once stopped.
+	stop, err = g.RecordPipeline(tracesID, func(context.Context, any) {})
+	require.NoError(t, err)
+	stop()
+}
+
+func TestGraphRecordPipelineErrors(t *testing.T) {
+	g, _ := buildControlTestGraph(t)
+	_, err := g.RecordPipeline(component.MustNewID("logs"), func(context.Context, any) {})
+	assert.EqualError(t, err, `pipeline "logs" not found`)
+	assert.EqualError(t, g.ConsumePipeline(context.Background(), component.MustNewID("logs"), plog.NewLogs()), `pipeline "logs" not found`)
+	assert.EqualError(t, g.ConsumePipeline(context.Background(), component.MustNewID("traces"), plog.NewLogs()), `pipeline "traces" does not consume plog.Logs`)
+}
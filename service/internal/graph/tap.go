@@ -0,0 +1,408 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// tapBufferSize is the number of tapped messages buffered before the new ones are dropped.
+const tapBufferSize = 64
+
+// redactedValue replaces the value of the redacted attributes.
+const redactedValue = "***"
+
+// TapConfig selects the node of the graph whose output data is tapped, see Graph.Tap.
+type TapConfig struct {
+	// PipelineID is the pipeline of the node. For a connector, it is the pipeline the connector emits to.
+	PipelineID component.ID
+	// Kind is the kind of the node: component.KindReceiver, component.KindProcessor or component.KindConnector.
+	Kind component.Kind
+	// ComponentID is the ID of the component of the node.
+	ComponentID component.ID
+	// SamplingRatio is the fraction of the batches of data that are tapped, in (0, 1].
+	SamplingRatio float64
+	// RateLimit is the maximum number of batches of data tapped per second.
+	RateLimit float64
+	// RedactedAttributes are the keys of the resource, scope and item attributes whose values are redacted.
+	RedactedAttributes []string
+}
+
+func (cfg *TapConfig) validate() error {
+	if cfg.SamplingRatio <= 0 || cfg.SamplingRatio > 1 {
+		return fmt.Errorf("sampling ratio %v must be in (0, 1]", cfg.SamplingRatio)
+	}
+	if cfg.RateLimit <= 0 {
+		return fmt.Errorf("rate limit %v must be positive", cfg.RateLimit)
+	}
+	return nil
+}
+
+// Tap streams a sample of the data flowing out of a node of the graph, encoded as OTLP JSON.
+type Tap struct {
+	cfg      TapConfig
+	point    *tapPoint
+	redacted map[string]struct{}
+	data     chan []byte
+
+	mu        sync.Mutex
+	tokens    float64
+	lastCheck time.Time
+
+	dropped atomic.Int64
+}
+
+// Tap starts tapping the data flowing out of the configured node. The tap must be closed once done.
+func (g *Graph) Tap(cfg TapConfig) (*Tap, error) {
+	point, err := g.tapPoint(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Tap{
+		cfg:       cfg,
+		point:     point,
+		redacted:  make(map[string]struct{}, len(cfg.RedactedAttributes)),
+		data:      make(chan []byte, tapBufferSize),
+		tokens:    1,
+		lastCheck: time.Now(),
+	}
+	for _, key := range cfg.RedactedAttributes {
+		t.redacted[key] = struct{}{}
+	}
+	point.add(t)
+	return t, nil
+}
+
+// ValidateTap returns the error Tap returns for the configuration, without tapping the node.
+func (g *Graph) ValidateTap(cfg TapConfig) error {
+	_, err := g.tapPoint(cfg)
+	return err
+}
+
+// tapPoint returns the tap point of the configured node.
+func (g *Graph) tapPoint(cfg TapConfig) (*tapPoint, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	pipe, ok := g.pipelines[cfg.PipelineID]
+	if !ok {
+		return nil, fmt.Errorf("pipeline %q not found", cfg.PipelineID)
+	}
+	if cfg.PipelineID.Type() == component.DataTypeProfiles {
+		return nil, errors.New("profiles pipelines cannot be tapped")
+	}
+	return pipe.tapPoint(cfg.PipelineID, cfg.Kind, cfg.ComponentID)
+}
+
+// tapPoint returns the tap point of the node of the pipeline.
+func (p *pipelineNodes) tapPoint(pipelineID component.ID, kind component.Kind, componentID component.ID) (*tapPoint, error) {
+	switch kind {
+	case component.KindReceiver:
+		for _, n := range p.receivers {
+			if rn, ok := n.(*receiverNode); ok && rn.componentID == componentID {
+				return rn.tap, nil
+			}
+		}
+	case component.KindProcessor:
+		for _, n := range p.processors {
+			if n.componentID == componentID {
+				return n.tap, nil
+			}
+		}
+	case component.KindConnector:
+		for _, n := range p.receivers {
+			if cn, ok := n.(*connectorNode); ok && cn.componentID == componentID {
+				return cn.taps[pipelineID], nil
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%s nodes cannot be tapped", strings.ToLower(kind.String()))
+	}
+	return nil, fmt.Errorf("%s %q not found in pipeline %q", strings.ToLower(kind.String()), componentID, pipelineID)
+}
+
+// Data returns the channel of the tapped data, each message being an OTLP JSON encoded export request.
+// Messages are dropped while the channel is full.
+func (t *Tap) Data() <-chan []byte {
+	return t.data
+}
+
+// Dropped returns the number of sampled messages dropped because the channel was full.
+func (t *Tap) Dropped() int64 {
+	return t.dropped.Load()
+}
+
+// Close stops tapping the data.
+func (t *Tap) Close() {
+	t.point.remove(t)
+}
+
+// allow returns true if the sampled data is allowed by the rate limit, using a token bucket
+// with a burst of one second.
+func (t *Tap) allow() bool {
+	if rand.Float64() >= t.cfg.SamplingRatio { //nolint:gosec
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	t.tokens = math.Min(math.Max(t.cfg.RateLimit, 1), t.tokens+now.Sub(t.lastCheck).Seconds()*t.cfg.RateLimit)
+	t.lastCheck = now
+	if t.tokens < 1 {
+		return false
+	}
+	t.tokens--
+	return true
+}
+
+func (t *Tap) send(msg []byte, err error) {
+	if err != nil {
+		return
+	}
+	select {
+	case t.data <- msg:
+	default:
+		t.dropped.Add(1)
+	}
+}
+
+func (t *Tap) tapTraces(td ptrace.Traces) {
+	if !t.allow() {
+		return
+	}
+	if len(t.redacted) > 0 {
+		cp := ptrace.NewTraces()
+		td.CopyTo(cp)
+		redactTraces(cp, t.redacted)
+		td = cp
+	}
+	t.send((&ptrace.JSONMarshaler{}).MarshalTraces(td))
+}
+
+func (t *Tap) tapMetrics(md pmetric.Metrics) {
+	if !t.allow() {
+		return
+	}
+	if len(t.redacted) > 0 {
+		cp := pmetric.NewMetrics()
+		md.CopyTo(cp)
+		redactMetrics(cp, t.redacted)
+		md = cp
+	}
+	t.send((&pmetric.JSONMarshaler{}).MarshalMetrics(md))
+}
+
+func (t *Tap) tapLogs(ld plog.Logs) {
+	if !t.allow() {
+		return
+	}
+	if len(t.redacted) > 0 {
+		cp := plog.NewLogs()
+		ld.CopyTo(cp)
+		redactLogs(cp, t.redacted)
+		ld = cp
+	}
+	t.send((&plog.JSONMarshaler{}).MarshalLogs(ld))
+}
+
+// tapPoint is the output of a node of the graph, where its data can be tapped.
+type tapPoint struct {
+	mu   sync.Mutex
+	taps atomic.Pointer[[]*Tap]
+}
+
+func (p *tapPoint) add(t *Tap) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var taps []*Tap
+	if current := p.taps.Load(); current != nil {
+		taps = append(taps, *current...)
+	}
+	taps = append(taps, t)
+	p.taps.Store(&taps)
+}
+
+func (p *tapPoint) remove(t *Tap) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	current := p.taps.Load()
+	if current == nil {
+		return
+	}
+	var taps []*Tap
+	for _, tap := range *current {
+		if tap != t {
+			taps = append(taps, tap)
+		}
+	}
+	if len(taps) == 0 {
+		p.taps.Store(nil)
+		return
+	}
+	p.taps.Store(&taps)
+}
+
+// wrap returns a consumer tapping the data before forwarding it to next.
+// The profiles are not tapped.
+func (p *tapPoint) wrap(next baseConsumer, dataType component.DataType) baseConsumer {
+	switch dataType {
+	case component.DataTypeTraces:
+		return p.wrapTraces(next.(consumer.Traces))
+	case component.DataTypeMetrics:
+		return p.wrapMetrics(next.(consumer.Metrics))
+	case component.DataTypeLogs:
+		return p.wrapLogs(next.(consumer.Logs))
+	}
+	return next
+}
+
+func (p *tapPoint) wrapTraces(next consumer.Traces) consumer.Traces {
+	return &tappedTraces{Traces: next, point: p}
+}
+
+func (p *tapPoint) wrapMetrics(next consumer.Metrics) consumer.Metrics {
+	return &tappedMetrics{Metrics: next, point: p}
+}
+
+func (p *tapPoint) wrapLogs(next consumer.Logs) consumer.Logs {
+	return &tappedLogs{Logs: next, point: p}
+}
+
+type tappedTraces struct {
+	consumer.Traces
+	point *tapPoint
+}
+
+func (c *tappedTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if taps := c.point.taps.Load(); taps != nil {
+		for _, t := range *taps {
+			t.tapTraces(td)
+		}
+	}
+	return c.Traces.ConsumeTraces(ctx, td)
+}
+
+type tappedMetrics struct {
+	consumer.Metrics
+	point *tapPoint
+}
+
+func (c *tappedMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if taps := c.point.taps.Load(); taps != nil {
+		for _, t := range *taps {
+			t.tapMetrics(md)
+		}
+	}
+	return c.Metrics.ConsumeMetrics(ctx, md)
+}
+
+type tappedLogs struct {
+	consumer.Logs
+	point *tapPoint
+}
+
+func (c *tappedLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if taps := c.point.taps.Load(); taps != nil {
+		for _, t := range *taps {
+			t.tapLogs(ld)
+		}
+	}
+	return c.Logs.ConsumeLogs(ctx, ld)
+}
+
+func redactAttributes(attrs pcommon.Map, redacted map[string]struct{}) {
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		if _, ok := redacted[k]; ok {
+			v.SetStr(redactedValue)
+		}
+		return true
+	})
+}
+
+func redactTraces(td ptrace.Traces, redacted map[string]struct{}) {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		redactAttributes(rs.Resource().Attributes(), redacted)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			redactAttributes(ss.Scope().Attributes(), redacted)
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+				redactAttributes(span.Attributes(), redacted)
+				for l := 0; l < span.Events().Len(); l++ {
+					redactAttributes(span.Events().At(l).Attributes(), redacted)
+				}
+				for l := 0; l < span.Links().Len(); l++ {
+					redactAttributes(span.Links().At(l).Attributes(), redacted)
+				}
+			}
+		}
+	}
+}
+
+func redactMetrics(md pmetric.Metrics, redacted map[string]struct{}) {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		redactAttributes(rm.Resource().Attributes(), redacted)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			redactAttributes(sm.Scope().Attributes(), redacted)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				m := sm.Metrics().At(k)
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					for l := 0; l < m.Gauge().DataPoints().Len(); l++ {
+						redactAttributes(m.Gauge().DataPoints().At(l).Attributes(), redacted)
+					}
+				case pmetric.MetricTypeSum:
+					for l := 0; l < m.Sum().DataPoints().Len(); l++ {
+						redactAttributes(m.Sum().DataPoints().At(l).Attributes(), redacted)
+					}
+				case pmetric.MetricTypeHistogram:
+					for l := 0; l < m.Histogram().DataPoints().Len(); l++ {
+						redactAttributes(m.Histogram().DataPoints().At(l).Attributes(), redacted)
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					for l := 0; l < m.ExponentialHistogram().DataPoints().Len(); l++ {
+						redactAttributes(m.ExponentialHistogram().DataPoints().At(l).Attributes(), redacted)
+					}
+				case pmetric.MetricTypeSummary:
+					for l := 0; l < m.Summary().DataPoints().Len(); l++ {
+						redactAttributes(m.Summary().DataPoints().At(l).Attributes(), redacted)
+					}
+				}
+			}
+		}
+	}
+}
+
+func redactLogs(ld plog.Logs, redacted map[string]struct{}) {
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		redactAttributes(rl.Resource().Attributes(), redacted)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			redactAttributes(sl.Scope().Attributes(), redacted)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				redactAttributes(sl.LogRecords().At(k).Attributes(), redacted)
+			}
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+)
+
+func receiveTapped(t *testing.T, tap *Tap) []byte {
+	select {
+	case msg := <-tap.Data():
+		return msg
+	case <-time.After(time.Second):
+		require.Fail(t, "no data tapped")
+		return nil
+	}
+}
+
+func TestGraphTapProcessor(t *testing.T) {
+	g, _ := buildControlTestGraph(t)
+	tracesID := component.MustNewID("traces")
+	tap, err := g.Tap(TapConfig{
+		PipelineID:         tracesID,
+		Kind:               component.KindProcessor,
+		ComponentID:        component.MustNewID("exampleprocessor"),
+		SamplingRatio:      1,
+		RateLimit:          1,
+		RedactedAttributes: []string{"resource-attr"},
+	})
+	require.NoError(t, err)
+
+	td := testdata.GenerateTraces(2)
+	require.NoError(t, g.pipelines[tracesID].capabilitiesNode.ConsumeTraces(context.Background(), td))
+	tapped, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(receiveTapped(t, tap))
+	require.NoError(t, err)
+	assert.Equal(t, 2, tapped.SpanCount())
+	v, ok := tapped.ResourceSpans().At(0).Resource().Attributes().Get("resource-attr")
+	require.True(t, ok)
+	assert.Equal(t, "***", v.Str())
+	// The data flowing through the pipeline is not redacted.
+	v, _ = td.ResourceSpans().At(0).Resource().Attributes().Get("resource-attr")
+	assert.Equal(t, "resource-attr-val-1", v.Str())
+
+	// The rate limit allows a single message per second.
+	require.NoError(t, g.pipelines[tracesID].capabilitiesNode.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Empty(t, tap.Data())
+
+	tap.Close()
+	assert.Nil(t, g.pipelines[tracesID].processors[0].tap.taps.Load())
+}
+
+func TestGraphTapReceiver(t *testing.T) {
+	g, _ := buildControlTestGraph(t)
+	tracesID := component.MustNewID("traces")
+	tap, err := g.Tap(TapConfig{
+		PipelineID:    tracesID,
+		Kind:          component.KindReceiver,
+		ComponentID:   component.MustNewID("examplereceiver"),
+		SamplingRatio: 1,
+		RateLimit:     10,
+	})
+	require.NoError(t, err)
+	defer tap.Close()
+
+	for _, n := range g.pipelines[tracesID].receivers {
+		rcv := n.(*receiverNode).Component.(*testcomponents.ExampleReceiver)
+		require.NoError(t, rcv.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	}
+	tapped, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(receiveTapped(t, tap))
+	require.NoError(t, err)
+	assert.Equal(t, 1, tapped.SpanCount())
+}
+
+func TestGraphTapConnector(t *testing.T) {
+	g := buildTopologyTestGraph(t)
+	tap, err := g.Tap(TapConfig{
+		PipelineID:    component.MustNewID("metrics"),
+		Kind:          component.KindConnector,
+		ComponentID:   component.MustNewID("exampleconnector"),
+		SamplingRatio: 1,
+		RateLimit:     10,
+	})
+	require.NoError(t, err)
+	defer tap.Close()
+
+	require.NoError(t, g.pipelines[component.MustNewID("traces")].capabilitiesNode.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	tapped, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(receiveTapped(t, tap))
+	require.NoError(t, err)
+	assert.Positive(t, tapped.DataPointCount())
+}
+
+func TestGraphTapErrors(t *testing.T) {
+	g, _ := buildControlTestGraph(t)
+	valid := TapConfig{
+		PipelineID:    component.MustNewID("traces"),
+		Kind:          component.KindProcessor,
+		ComponentID:   component.MustNewID("exampleprocessor"),
+		SamplingRatio: 1,
+		RateLimit:     1,
+	}
+
+	cfg := valid
+	cfg.SamplingRatio = 0
+	_, err := g.Tap(cfg)
+	assert.EqualError(t, err, "sampling ratio 0 must be in (0, 1]")
+
+	cfg = valid
+	cfg.RateLimit = 0
+	_, err = g.Tap(cfg)
+	assert.EqualError(t, err, "rate limit 0 must be positive")
+
+	cfg = valid
+	cfg.PipelineID = component.MustNewID("logs")
+	_, err = g.Tap(cfg)
+	assert.EqualError(t, err, `pipeline "logs" not found`)
+
+	cfg = valid
+	cfg.Kind = component.KindExporter
+	_, err = g.Tap(cfg)
+	assert.EqualError(t, err, "exporter nodes cannot be tapped")
+
+	cfg = valid
+	cfg.ComponentID = component.MustNewID("unknown")
+	_, err = g.Tap(cfg)
+	assert.EqualError(t, err, `processor "unknown" not found in pipeline "traces"`)
+	assert.EqualError(t, g.ValidateTap(cfg), `processor "unknown" not found in pipeline "traces"`)
+
+	// Validating a tap does not tap the node.
+	require.NoError(t, g.ValidateTap(valid))
+	assert.Nil(t, g.pipelines[valid.PipelineID].processors[0].tap.taps.Load())
+}
+
+func TestTapSamplingRatio(t *testing.T) {
+	tap := &Tap{cfg: TapConfig{SamplingRatio: 0.5, RateLimit: 1e9}, tokens: 1e9, lastCheck: time.Now()}
+	allowed := 0
+	for i := 0; i < 10000; i++ {
+		if tap.allow() {
+			allowed++
+		}
+	}
+	assert.InDelta(t, 5000, allowed, 500)
+}
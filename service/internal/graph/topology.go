@@ -0,0 +1,309 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Formats of the topology of the graph, see Topology.Write.
+const (
+	TopologyFormatDOT     = "dot"
+	TopologyFormatMermaid = "mermaid"
+	TopologyFormatJSON    = "json"
+)
+
+// Kinds of the nodes of the topology.
+const (
+	topologyKindReceiver     = "receiver"
+	topologyKindProcessor    = "processor"
+	topologyKindExporter     = "exporter"
+	topologyKindConnector    = "connector"
+	topologyKindCapabilities = "capabilities"
+	topologyKindFanOut       = "fanout"
+)
+
+// Topology is the representation of the graph of the pipelines, as rendered by Write.
+type Topology struct {
+	Pipelines []TopologyPipeline `json:"pipelines"`
+	Nodes     []TopologyNode     `json:"nodes"`
+	Edges     []TopologyEdge     `json:"edges"`
+}
+
+// TopologyPipeline lists the nodes owned by a pipeline: its capabilities node, processors and fan-out node.
+// The receivers, exporters and connectors can be shared by several pipelines.
+type TopologyPipeline struct {
+	ID    string   `json:"id"`
+	Nodes []string `json:"nodes"`
+}
+
+// TopologyNode is a node of the graph of the pipelines.
+type TopologyNode struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+	// ComponentID is the ID of the component, empty for the capabilities and fan-out nodes.
+	ComponentID string `json:"component_id,omitempty"`
+	// Pipeline is the pipeline owning the processor, capabilities and fan-out nodes.
+	Pipeline string `json:"pipeline,omitempty"`
+	// DataType is the type of the data consumed by the node, or emitted by the receivers.
+	DataType string `json:"data_type,omitempty"`
+	// ReceiverDataType is the type of the data emitted by the connectors.
+	ReceiverDataType string `json:"receiver_data_type,omitempty"`
+	// MutatesData is the MutatesData capability of the node.
+	MutatesData bool `json:"mutates_data"`
+}
+
+// TopologyEdge is a directed edge of the graph of the pipelines, in the direction of the data.
+type TopologyEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Topology returns the topology of the graph of the pipelines.
+func (g *Graph) Topology() *Topology {
+	t := &Topology{}
+	ids := make(map[int64]string)
+	nodes := g.componentGraph.Nodes()
+	for nodes.Next() {
+		n := topologyNode(nodes.Node())
+		ids[nodes.Node().ID()] = n.ID
+		t.Nodes = append(t.Nodes, n)
+	}
+	sort.Slice(t.Nodes, func(i, j int) bool { return t.Nodes[i].ID < t.Nodes[j].ID })
+
+	edges := g.componentGraph.Edges()
+	for edges.Next() {
+		e := edges.Edge()
+		t.Edges = append(t.Edges, TopologyEdge{From: ids[e.From().ID()], To: ids[e.To().ID()]})
+	}
+	sort.Slice(t.Edges, func(i, j int) bool {
+		if t.Edges[i].From != t.Edges[j].From {
+			return t.Edges[i].From < t.Edges[j].From
+		}
+		return t.Edges[i].To < t.Edges[j].To
+	})
+
+	for pipelineID, pipe := range g.pipelines {
+		p := TopologyPipeline{ID: pipelineID.String()}
+		p.Nodes = append(p.Nodes, ids[pipe.capabilitiesNode.ID()])
+		for _, proc := range pipe.processors {
+			p.Nodes = append(p.Nodes, ids[proc.ID()])
+		}
+		p.Nodes = append(p.Nodes, ids[pipe.fanOutNode.ID()])
+		t.Pipelines = append(t.Pipelines, p)
+	}
+	sort.Slice(t.Pipelines, func(i, j int) bool { return t.Pipelines[i].ID < t.Pipelines[j].ID })
+	return t
+}
+
+func topologyNode(node any) TopologyNode {
+	switch n := node.(type) {
+	case *receiverNode:
+		return TopologyNode{
+			ID:          topologyKindReceiver + ":" + n.pipelineType.String() + ":" + n.componentID.String(),
+			Kind:        topologyKindReceiver,
+			ComponentID: n.componentID.String(),
+			DataType:    n.pipelineType.String(),
+		}
+	case *processorNode:
+		return TopologyNode{
+			ID:          topologyKindProcessor + ":" + n.pipelineID.String() + ":" + n.componentID.String(),
+			Kind:        topologyKindProcessor,
+			ComponentID: n.componentID.String(),
+			Pipeline:    n.pipelineID.String(),
+			DataType:    n.pipelineID.Type().String(),
+			MutatesData: mutatesData(n.Component),
+		}
+	case *exporterNode:
+		return TopologyNode{
+			ID:          topologyKindExporter + ":" + n.pipelineType.String() + ":" + n.componentID.String(),
+			Kind:        topologyKindExporter,
+			ComponentID: n.componentID.String(),
+			DataType:    n.pipelineType.String(),
+			MutatesData: mutatesData(n.Component),
+		}
+	case *connectorNode:
+		return TopologyNode{
+			ID:               topologyKindConnector + ":" + n.exprPipelineType.String() + ":" + n.rcvrPipelineType.String() + ":" + n.componentID.String(),
+			Kind:             topologyKindConnector,
+			ComponentID:      n.componentID.String(),
+			DataType:         n.exprPipelineType.String(),
+			ReceiverDataType: n.rcvrPipelineType.String(),
+			MutatesData:      mutatesData(n.baseConsumer),
+		}
+	case *capabilitiesNode:
+		return TopologyNode{
+			ID:          topologyKindCapabilities + ":" + n.pipelineID.String(),
+			Kind:        topologyKindCapabilities,
+			Pipeline:    n.pipelineID.String(),
+			DataType:    n.pipelineID.Type().String(),
+			MutatesData: mutatesData(n.baseConsumer),
+		}
+	case *fanOutNode:
+		return TopologyNode{
+			ID:          topologyKindFanOut + ":" + n.pipelineID.String(),
+			Kind:        topologyKindFanOut,
+			Pipeline:    n.pipelineID.String(),
+			DataType:    n.pipelineID.Type().String(),
+			MutatesData: mutatesData(n.baseConsumer),
+		}
+	}
+	panic(fmt.Sprintf("unexpected node type %T", node))
+}
+
+// mutatesData returns the MutatesData capability of the consumer, false if not built.
+func mutatesData(c any) bool {
+	if bc, ok := c.(baseConsumer); ok {
+		return bc.Capabilities().MutatesData
+	}
+	return false
+}
+
+// Write writes the topology in the given format: TopologyFormatDOT, TopologyFormatMermaid or TopologyFormatJSON.
+func (t *Topology) Write(w io.Writer, format string) error {
+	switch format {
+	case TopologyFormatDOT:
+		return t.writeDOT(w)
+	case TopologyFormatMermaid:
+		return t.writeMermaid(w)
+	case TopologyFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(t)
+	}
+	return fmt.Errorf("unsupported topology format %q, must be one of %q, %q or %q", format, TopologyFormatDOT, TopologyFormatMermaid, TopologyFormatJSON)
+}
+
+// label returns the human readable label of the node, rendered on several lines.
+func (n *TopologyNode) label() []string {
+	var lines []string
+	switch n.Kind {
+	case topologyKindCapabilities, topologyKindFanOut:
+		lines = append(lines, n.Kind)
+	case topologyKindConnector:
+		lines = append(lines, n.Kind+" "+n.ComponentID, n.DataType+" -> "+n.ReceiverDataType)
+	case topologyKindProcessor:
+		lines = append(lines, n.Kind+" "+n.ComponentID)
+	default:
+		lines = append(lines, n.Kind+" "+n.ComponentID, n.DataType)
+	}
+	if n.MutatesData {
+		lines = append(lines, "mutates data")
+	}
+	return lines
+}
+
+// sharedNodes returns the nodes not owned by a pipeline.
+func (t *Topology) sharedNodes() []TopologyNode {
+	owned := make(map[string]struct{})
+	for _, p := range t.Pipelines {
+		for _, id := range p.Nodes {
+			owned[id] = struct{}{}
+		}
+	}
+	var shared []TopologyNode
+	for _, n := range t.Nodes {
+		if _, ok := owned[n.ID]; !ok {
+			shared = append(shared, n)
+		}
+	}
+	return shared
+}
+
+func (t *Topology) nodesByID() map[string]TopologyNode {
+	nodes := make(map[string]TopologyNode, len(t.Nodes))
+	for _, n := range t.Nodes {
+		nodes[n.ID] = n
+	}
+	return nodes
+}
+
+func (t *Topology) writeDOT(w io.Writer) error {
+	nodes := t.nodesByID()
+	shared := t.sharedNodes()
+	dotID := func(id string) string {
+		return `"` + strings.ReplaceAll(id, `"`, `\"`) + `"`
+	}
+	dotNode := func(indent string, n TopologyNode) string {
+		shape := "box"
+		switch n.Kind {
+		case topologyKindCapabilities, topologyKindFanOut:
+			shape = "point"
+		case topologyKindConnector:
+			shape = "hexagon"
+		}
+		label := strings.ReplaceAll(strings.Join(n.label(), `\n`), `"`, `\"`)
+		return fmt.Sprintf("%s%s [label=\"%s\", shape=%s];\n", indent, dotID(n.ID), label, shape)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("digraph pipelines {\n  rankdir=LR;\n")
+	for _, n := range shared {
+		sb.WriteString(dotNode("  ", n))
+	}
+	for i, p := range t.Pipelines {
+		fmt.Fprintf(&sb, "  subgraph cluster_%d {\n    label=%s;\n", i, dotID("pipeline "+p.ID))
+		for _, id := range p.Nodes {
+			sb.WriteString(dotNode("    ", nodes[id]))
+		}
+		sb.WriteString("  }\n")
+	}
+	for _, e := range t.Edges {
+		style := ""
+		if nodes[e.From].Kind == topologyKindConnector || nodes[e.To].Kind == topologyKindConnector {
+			style = " [style=dashed]"
+		}
+		fmt.Fprintf(&sb, "  %s -> %s%s;\n", dotID(e.From), dotID(e.To), style)
+	}
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func (t *Topology) writeMermaid(w io.Writer) error {
+	nodes := t.nodesByID()
+	shared := t.sharedNodes()
+	// The IDs of the nodes are not valid Mermaid IDs, the nodes are numbered instead.
+	mermaidIDs := make(map[string]string, len(t.Nodes))
+	for i, n := range t.Nodes {
+		mermaidIDs[n.ID] = fmt.Sprintf("n%d", i)
+	}
+	mermaidNode := func(indent string, n TopologyNode) string {
+		label := strings.ReplaceAll(strings.Join(n.label(), "<br/>"), `"`, "#quot;")
+		open, closing := "[", "]"
+		switch n.Kind {
+		case topologyKindCapabilities, topologyKindFanOut:
+			open, closing = "((", "))"
+		case topologyKindConnector:
+			open, closing = "{{", "}}"
+		}
+		return fmt.Sprintf("%s%s%s\"%s\"%s\n", indent, mermaidIDs[n.ID], open, label, closing)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	for _, n := range shared {
+		sb.WriteString(mermaidNode("  ", n))
+	}
+	for i, p := range t.Pipelines {
+		fmt.Fprintf(&sb, "  subgraph p%d[\"pipeline %s\"]\n", i, strings.ReplaceAll(p.ID, `"`, "#quot;"))
+		for _, id := range p.Nodes {
+			sb.WriteString(mermaidNode("    ", nodes[id]))
+		}
+		sb.WriteString("  end\n")
+	}
+	for _, e := range t.Edges {
+		arrow := "-->"
+		if nodes[e.From].Kind == topologyKindConnector || nodes[e.To].Kind == topologyKindConnector {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&sb, "  %s %s %s\n", mermaidIDs[e.From], arrow, mermaidIDs[e.To])
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/servicetelemetry"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+func buildTopologyTestGraph(t *testing.T) *Graph {
+	set := Settings{
+		Telemetry: servicetelemetry.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: receiver.NewBuilder(
+			map[component.ID]component.Config{
+				component.MustNewID("examplereceiver"): testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			}),
+		ProcessorBuilder: processor.NewBuilder(
+			map[component.ID]component.Config{
+				component.MustNewIDWithName("exampleprocessor", "mutate"): testcomponents.ExampleProcessorFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]processor.Factory{
+				testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory,
+			}),
+		ExporterBuilder: exporter.NewBuilder(
+			map[component.ID]component.Config{
+				component.MustNewID("exampleexporter"): testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			}),
+		ConnectorBuilder: connector.NewBuilder(
+			map[component.ID]component.Config{
+				component.MustNewID("exampleconnector"): testcomponents.ExampleConnectorFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]connector.Factory{
+				testcomponents.ExampleConnectorFactory.Type(): testcomponents.ExampleConnectorFactory,
+			}),
+		PipelineConfigs: pipelines.Config{
+			component.MustNewID("traces"): {
+				Receivers:  []component.ID{component.MustNewID("examplereceiver")},
+				Processors: []component.ID{component.MustNewIDWithName("exampleprocessor", "mutate")},
+				Exporters:  []component.ID{component.MustNewID("exampleconnector")},
+			},
+			component.MustNewID("metrics"): {
+				Receivers: []component.ID{component.MustNewID("exampleconnector")},
+				Exporters: []component.ID{component.MustNewID("exampleexporter")},
+			},
+		},
+	}
+	g, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	return g
+}
+
+func TestGraphTopology(t *testing.T) {
+	topology := buildTopologyTestGraph(t).Topology()
+
+	assert.Equal(t, []TopologyPipeline{
+		{ID: "metrics", Nodes: []string{"capabilities:metrics", "fanout:metrics"}},
+		{ID: "traces", Nodes: []string{"capabilities:traces", "processor:traces:exampleprocessor/mutate", "fanout:traces"}},
+	}, topology.Pipelines)
+	assert.Equal(t, []TopologyNode{
+		{ID: "capabilities:metrics", Kind: "capabilities", Pipeline: "metrics", DataType: "metrics"},
+		{ID: "capabilities:traces", Kind: "capabilities", Pipeline: "traces", DataType: "traces", MutatesData: true},
+		{ID: "connector:traces:metrics:exampleconnector", Kind: "connector", ComponentID: "exampleconnector", DataType: "traces", ReceiverDataType: "metrics"},
+		{ID: "exporter:metrics:exampleexporter", Kind: "exporter", ComponentID: "exampleexporter", DataType: "metrics"},
+		{ID: "fanout:metrics", Kind: "fanout", Pipeline: "metrics", DataType: "metrics"},
+		{ID: "fanout:traces", Kind: "fanout", Pipeline: "traces", DataType: "traces"},
+		{ID: "processor:traces:exampleprocessor/mutate", Kind: "processor", ComponentID: "exampleprocessor/mutate", Pipeline: "traces", DataType: "traces", MutatesData: true},
+		{ID: "receiver:traces:examplereceiver", Kind: "receiver", ComponentID: "examplereceiver", DataType: "traces"},
+	}, topology.Nodes)
+	assert.Equal(t, []TopologyEdge{
+		{From: "capabilities:metrics", To: "fanout:metrics"},
+		{From: "capabilities:traces", To: "processor:traces:exampleprocessor/mutate"},
+		{From: "connector:traces:metrics:exampleconnector", To: "capabilities:metrics"},
+		{From: "fanout:metrics", To: "exporter:metrics:exampleexporter"},
+		{From: "fanout:traces", To: "connector:traces:metrics:exampleconnector"},
+		{From: "processor:traces:exampleprocessor/mutate", To: "fanout:traces"},
+		{From: "receiver:traces:examplereceiver", To: "capabilities:traces"},
+	}, topology.Edges)
+}
+
+func TestTopologyWrite(t *testing.T) {
+	topology := buildTopologyTestGraph(t).Topology()
+
+	var buf bytes.Buffer
+	require.NoError(t, topology.Write(&buf, TopologyFormatJSON))
+	var decoded Topology
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, topology, &decoded)
+
+	buf.Reset()
+	require.NoError(t, topology.Write(&buf, TopologyFormatDOT))
+	dot := buf.String()
+	assert.Contains(t, dot, "digraph pipelines {")
+	assert.Contains(t, dot, `label="pipeline traces";`)
+	assert.Contains(t, dot, `"processor:traces:exampleprocessor/mutate" [label="processor exampleprocessor/mutate\nmutates data", shape=box];`)
+	assert.Contains(t, dot, `"connector:traces:metrics:exampleconnector" [label="connector exampleconnector\ntraces -> metrics", shape=hexagon];`)
+	assert.Contains(t, dot, `"fanout:traces" -> "connector:traces:metrics:exampleconnector" [style=dashed];`)
+	assert.Contains(t, dot, `"receiver:traces:examplereceiver" -> "capabilities:traces";`)
+
+	buf.Reset()
+	require.NoError(t, topology.Write(&buf, TopologyFormatMermaid))
+	mermaid := buf.String()
+	assert.Contains(t, mermaid, "flowchart LR\n")
+	assert.Contains(t, mermaid, `n2{{"connector exampleconnector<br/>traces -> metrics"}}`)
+	assert.Contains(t, mermaid, `subgraph p1["pipeline traces"]`)
+	assert.Contains(t, mermaid, "n5 -.-> n2\n")
+	assert.Contains(t, mermaid, "n7 --> n1\n")
+
+	assert.EqualError(t, topology.Write(&buf, "svg"), `unsupported topology format "svg", must be one of "dot", "mermaid" or "json"`)
+}
+
+func TestHandleTopologyZPages(t *testing.T) {
+	g := buildTopologyTestGraph(t)
+
+	rec := httptest.NewRecorder()
+	g.HandleTopologyZPages(rec, httptest.NewRequest(http.MethodGet, "/debug/topologyz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `"kind": "connector"`)
+
+	rec = httptest.NewRecorder()
+	g.HandleTopologyZPages(rec, httptest.NewRequest(http.MethodGet, "/debug/topologyz?format=dot", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "digraph pipelines {")
+
+	rec = httptest.NewRecorder()
+	g.HandleTopologyZPages(rec, httptest.NewRequest(http.MethodGet, "/debug/topologyz?format=svg", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
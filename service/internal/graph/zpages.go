@@ -4,6 +4,7 @@
 package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 
 import (
+	"bytes"
 	"net/http"
 	"sort"
 
@@ -41,6 +42,10 @@ func (g *Graph) HandleZPages(w http.ResponseWriter, r *http.Request) {
 		}
 		procIDs := make([]string, 0, len(p.processors))
 		for _, c := range p.processors {
+			if c.bypassed.Load() {
+				procIDs = append(procIDs, c.componentID.String()+" (bypassed)")
+				continue
+			}
 			procIDs = append(procIDs, c.componentID.String())
 		}
 		exprIDs := make([]string, 0, len(p.exporters))
@@ -57,6 +62,7 @@ func (g *Graph) HandleZPages(w http.ResponseWriter, r *http.Request) {
 			FullName:    c.String(),
 			InputType:   c.Type().String(),
 			MutatesData: p.capabilitiesNode.getConsumer().Capabilities().MutatesData,
+			Paused:      p.capabilitiesNode.paused.Load(),
 			Receivers:   recvIDs,
 			Processors:  procIDs,
 			Exporters:   exprIDs,
@@ -79,3 +85,23 @@ func (g *Graph) HandleZPages(w http.ResponseWriter, r *http.Request) {
 	}
 	zpages.WriteHTMLPageFooter(w)
 }
+
+// HandleTopologyZPages writes the topology of the graph in the format of the "format" URL parameter,
+// JSON by default.
+func (g *Graph) HandleTopologyZPages(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = TopologyFormatJSON
+	}
+	var buf bytes.Buffer
+	if err := g.Topology().Write(&buf, format); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if format == TopologyFormatJSON {
+		w.Header().Set("Content-Type", "application/json")
+	} else {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	_, _ = w.Write(buf.Bytes())
+}
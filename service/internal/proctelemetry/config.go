@@ -81,10 +81,20 @@ func InitMetricReader(ctx context.Context, reader config.MetricReader, asyncErro
 	return nil, nil, fmt.Errorf("unsupported metric reader type %v", reader)
 }
 
-func InitOpenTelemetry(res *resource.Resource, options []sdkmetric.Option, disableHighCardinality bool) (*sdkmetric.MeterProvider, error) {
+// InitOpenTelemetry returns the meter provider of the metrics of the collector. The configured views
+// take precedence over the default views of the collector, and the number of attribute sets of each
+// metric stream is limited to cardinalityLimit, if positive.
+func InitOpenTelemetry(res *resource.Resource, options []sdkmetric.Option, disableHighCardinality bool, views []config.View, cardinalityLimit int) (*sdkmetric.MeterProvider, error) {
+	sdkViews, err := ViewsFromConfig(views)
+	if err != nil {
+		return nil, err
+	}
+	if err = setCardinalityLimit(cardinalityLimit); err != nil {
+		return nil, err
+	}
 	opts := []sdkmetric.Option{
 		sdkmetric.WithResource(res),
-		sdkmetric.WithView(batchViews(disableHighCardinality)...),
+		sdkmetric.WithView(firstMatchView(append(sdkViews, batchViews(disableHighCardinality)...))),
 	}
 
 	opts = append(opts, options...)
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package proctelemetry // import "go.opentelemetry.io/collector/service/internal/proctelemetry"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/contrib/config"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+var errNoValidLogRecordExporter = errors.New("no valid log record exporter")
+
+// InitLogRecordProcessor creates the log record processor, and its exporter, from the configuration.
+func InitLogRecordProcessor(ctx context.Context, processor config.LogRecordProcessor) (sdklog.Processor, error) {
+	if processor.Batch != nil && processor.Simple != nil {
+		return nil, errors.New("must not specify multiple log record processor type")
+	}
+	if processor.Batch != nil {
+		exp, err := initLogRecordExporter(ctx, processor.Batch.Exporter)
+		if err != nil {
+			return nil, err
+		}
+		return initBatchLogRecordProcessor(processor.Batch, exp)
+	}
+	if processor.Simple != nil {
+		exp, err := initLogRecordExporter(ctx, processor.Simple.Exporter)
+		if err != nil {
+			return nil, err
+		}
+		return sdklog.NewSimpleProcessor(exp), nil
+	}
+	return nil, fmt.Errorf("unsupported log record processor type %v", processor)
+}
+
+func initLogRecordExporter(ctx context.Context, exporter config.LogRecordExporter) (sdklog.Exporter, error) {
+	if exporter.OTLP == nil {
+		return nil, errNoValidLogRecordExporter
+	}
+	switch exporter.OTLP.Protocol {
+	case protocolProtobufHTTP:
+		return initOTLPHTTPLogExporter(ctx, exporter.OTLP)
+	default:
+		// The OTLP gRPC log exporter is not available yet for the version of the SDK in use.
+		return nil, fmt.Errorf("unsupported protocol %q", exporter.OTLP.Protocol)
+	}
+}
+
+func initOTLPHTTPLogExporter(ctx context.Context, otlpConfig *config.OTLP) (sdklog.Exporter, error) {
+	opts := []otlploghttp.Option{}
+
+	if len(otlpConfig.Endpoint) > 0 {
+		u, err := url.ParseRequestURI(normalizeEndpoint(otlpConfig.Endpoint))
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, otlploghttp.WithEndpoint(u.Host))
+
+		if u.Scheme == "http" {
+			opts = append(opts, otlploghttp.WithInsecure())
+		}
+		if len(u.Path) > 0 {
+			opts = append(opts, otlploghttp.WithURLPath(u.Path))
+		}
+	}
+	if otlpConfig.Compression != nil {
+		switch *otlpConfig.Compression {
+		case "gzip":
+			opts = append(opts, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+		case "none":
+			opts = append(opts, otlploghttp.WithCompression(otlploghttp.NoCompression))
+		default:
+			return nil, fmt.Errorf("unsupported compression %q", *otlpConfig.Compression)
+		}
+	}
+	if otlpConfig.Timeout != nil {
+		opts = append(opts, otlploghttp.WithTimeout(time.Millisecond*time.Duration(*otlpConfig.Timeout)))
+	}
+	if len(otlpConfig.Headers) > 0 {
+		opts = append(opts, otlploghttp.WithHeaders(otlpConfig.Headers))
+	}
+
+	return otlploghttp.New(ctx, opts...)
+}
+
+func initBatchLogRecordProcessor(blp *config.BatchLogRecordProcessor, exp sdklog.Exporter) (sdklog.Processor, error) {
+	var opts []sdklog.BatchProcessorOption
+	if blp.ExportTimeout != nil {
+		if *blp.ExportTimeout < 0 {
+			return nil, fmt.Errorf("invalid export timeout %d", *blp.ExportTimeout)
+		}
+		opts = append(opts, sdklog.WithExportTimeout(time.Millisecond*time.Duration(*blp.ExportTimeout)))
+	}
+	if blp.MaxExportBatchSize != nil {
+		if *blp.MaxExportBatchSize < 0 {
+			return nil, fmt.Errorf("invalid batch size %d", *blp.MaxExportBatchSize)
+		}
+		opts = append(opts, sdklog.WithExportMaxBatchSize(*blp.MaxExportBatchSize))
+	}
+	if blp.MaxQueueSize != nil {
+		if *blp.MaxQueueSize < 0 {
+			return nil, fmt.Errorf("invalid queue size %d", *blp.MaxQueueSize)
+		}
+		opts = append(opts, sdklog.WithMaxQueueSize(*blp.MaxQueueSize))
+	}
+	if blp.ScheduleDelay != nil {
+		if *blp.ScheduleDelay < 0 {
+			return nil, fmt.Errorf("invalid schedule delay %d", *blp.ScheduleDelay)
+		}
+		opts = append(opts, sdklog.WithExportInterval(time.Millisecond*time.Duration(*blp.ScheduleDelay)))
+	}
+	return sdklog.NewBatchProcessor(exp, opts...), nil
+}
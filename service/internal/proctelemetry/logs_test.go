@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package proctelemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/contrib/config"
+)
+
+func TestLogRecordProcessor(t *testing.T) {
+	otlpHTTP := config.LogRecordExporter{
+		OTLP: &config.OTLP{
+			Protocol:    "http/protobuf",
+			Endpoint:    "http://localhost:4318/v1/logs",
+			Compression: strPtr("gzip"),
+			Timeout:     intPtr(1000),
+			Headers: map[string]string{
+				"test": "test1",
+			},
+		},
+	}
+	testCases := []struct {
+		name      string
+		processor config.LogRecordProcessor
+		err       error
+	}{
+		{
+			name: "noprocessor",
+			err:  errors.New("unsupported log record processor type {<nil> <nil>}"),
+		},
+		{
+			name: "multiple processor types",
+			processor: config.LogRecordProcessor{
+				Batch:  &config.BatchLogRecordProcessor{Exporter: otlpHTTP},
+				Simple: &config.SimpleLogRecordProcessor{Exporter: otlpHTTP},
+			},
+			err: errors.New("must not specify multiple log record processor type"),
+		},
+		{
+			name: "batch/otlp-http",
+			processor: config.LogRecordProcessor{
+				Batch: &config.BatchLogRecordProcessor{
+					ExportTimeout:      intPtr(1000),
+					MaxExportBatchSize: intPtr(512),
+					MaxQueueSize:       intPtr(2048),
+					ScheduleDelay:      intPtr(1000),
+					Exporter:           otlpHTTP,
+				},
+			},
+		},
+		{
+			name: "batch/invalid-queue-size",
+			processor: config.LogRecordProcessor{
+				Batch: &config.BatchLogRecordProcessor{
+					MaxQueueSize: intPtr(-1),
+					Exporter:     otlpHTTP,
+				},
+			},
+			err: errors.New("invalid queue size -1"),
+		},
+		{
+			name: "simple/otlp-http",
+			processor: config.LogRecordProcessor{
+				Simple: &config.SimpleLogRecordProcessor{Exporter: otlpHTTP},
+			},
+		},
+		{
+			name: "simple/no-exporter",
+			processor: config.LogRecordProcessor{
+				Simple: &config.SimpleLogRecordProcessor{},
+			},
+			err: errNoValidLogRecordExporter,
+		},
+		{
+			name: "simple/otlp-grpc",
+			processor: config.LogRecordProcessor{
+				Simple: &config.SimpleLogRecordProcessor{
+					Exporter: config.LogRecordExporter{
+						OTLP: &config.OTLP{
+							Protocol: "grpc/protobuf",
+							Endpoint: "localhost:4317",
+						},
+					},
+				},
+			},
+			err: errors.New("unsupported protocol \"grpc/protobuf\""),
+		},
+		{
+			name: "simple/otlp-http-invalid-compression",
+			processor: config.LogRecordProcessor{
+				Simple: &config.SimpleLogRecordProcessor{
+					Exporter: config.LogRecordExporter{
+						OTLP: &config.OTLP{
+							Protocol:    "http/protobuf",
+							Endpoint:    "localhost:4318",
+							Compression: strPtr("invalid"),
+						},
+					},
+				},
+			},
+			err: errors.New("unsupported compression \"invalid\""),
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, err := InitLogRecordProcessor(context.Background(), tt.processor)
+			defer func() {
+				if processor != nil {
+					assert.NoError(t, processor.Shutdown(context.Background()))
+				}
+			}()
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package proctelemetry // import "go.opentelemetry.io/collector/service/internal/proctelemetry"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/contrib/config"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// defaultHistogramBoundaries are the default boundaries of the explicit bucket histograms, see
+// https://opentelemetry.io/docs/specs/otel/metrics/sdk/#explicit-bucket-histogram-aggregation.
+var defaultHistogramBoundaries = []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}
+
+// cardinalityLimitEnv is the environment variable of the cardinality limit of the SDK, read when an
+// instrument is created.
+const cardinalityLimitEnv = "OTEL_GO_X_CARDINALITY_LIMIT"
+
+// envCardinalityLimit is the cardinality limit of the environment of the collector, if set.
+var envCardinalityLimit, envCardinalityLimitSet = os.LookupEnv(cardinalityLimitEnv)
+
+var instrumentKinds = map[config.ViewSelectorInstrumentType]sdkmetric.InstrumentKind{
+	config.ViewSelectorInstrumentTypeCounter:                 sdkmetric.InstrumentKindCounter,
+	config.ViewSelectorInstrumentTypeHistogram:               sdkmetric.InstrumentKindHistogram,
+	config.ViewSelectorInstrumentTypeObservableCounter:       sdkmetric.InstrumentKindObservableCounter,
+	config.ViewSelectorInstrumentTypeObservableGauge:         sdkmetric.InstrumentKindObservableGauge,
+	config.ViewSelectorInstrumentTypeObservableUpDownCounter: sdkmetric.InstrumentKindObservableUpDownCounter,
+	config.ViewSelectorInstrumentTypeUpDownCounter:           sdkmetric.InstrumentKindUpDownCounter,
+}
+
+// ViewsFromConfig returns the views configured for the metrics of the collector.
+func ViewsFromConfig(views []config.View) ([]sdkmetric.View, error) {
+	sdkViews := make([]sdkmetric.View, 0, len(views))
+	for i, v := range views {
+		view, err := viewFromConfig(v)
+		if err != nil {
+			return nil, fmt.Errorf("views[%d]: %w", i, err)
+		}
+		sdkViews = append(sdkViews, view)
+	}
+	return sdkViews, nil
+}
+
+func viewFromConfig(v config.View) (sdkmetric.View, error) {
+	if v.Selector == nil {
+		return nil, errors.New("selector must be set")
+	}
+	if v.Stream == nil {
+		return nil, errors.New("stream must be set")
+	}
+
+	var inst sdkmetric.Instrument
+	if v.Selector.InstrumentName != nil {
+		inst.Name = *v.Selector.InstrumentName
+	}
+	if v.Selector.InstrumentType != nil {
+		kind, ok := instrumentKinds[*v.Selector.InstrumentType]
+		if !ok {
+			return nil, fmt.Errorf("unsupported instrument_type %q", *v.Selector.InstrumentType)
+		}
+		inst.Kind = kind
+	}
+	if v.Selector.Unit != nil {
+		inst.Unit = *v.Selector.Unit
+	}
+	if v.Selector.MeterName != nil {
+		inst.Scope.Name = *v.Selector.MeterName
+	}
+	if v.Selector.MeterVersion != nil {
+		inst.Scope.Version = *v.Selector.MeterVersion
+	}
+	if v.Selector.MeterSchemaUrl != nil {
+		inst.Scope.SchemaURL = *v.Selector.MeterSchemaUrl
+	}
+	if inst.Name == "" && inst.Kind == 0 && inst.Unit == "" && inst.Scope == (instrumentation.Scope{}) {
+		return nil, errors.New("selector must match at least one instrument property")
+	}
+
+	var stream sdkmetric.Stream
+	if v.Stream.Name != nil {
+		if strings.ContainsAny(inst.Name, "*?") {
+			return nil, errors.New("stream name cannot be set for an instrument_name with wildcards")
+		}
+		stream.Name = *v.Stream.Name
+	}
+	if v.Stream.Description != nil {
+		stream.Description = *v.Stream.Description
+	}
+	if v.Stream.AttributeKeys != nil {
+		keys := make([]attribute.Key, 0, len(v.Stream.AttributeKeys))
+		for _, k := range v.Stream.AttributeKeys {
+			keys = append(keys, attribute.Key(k))
+		}
+		stream.AttributeFilter = attribute.NewAllowKeysFilter(keys...)
+	}
+	if v.Stream.Aggregation != nil {
+		aggr, err := aggregationFromConfig(v.Stream.Aggregation)
+		if err != nil {
+			return nil, err
+		}
+		stream.Aggregation = aggr
+	}
+	return sdkmetric.NewView(inst, stream), nil
+}
+
+func aggregationFromConfig(aggr *config.ViewStreamAggregation) (sdkmetric.Aggregation, error) {
+	switch {
+	case aggr.Drop != nil:
+		return sdkmetric.AggregationDrop{}, nil
+	case aggr.Sum != nil:
+		return sdkmetric.AggregationSum{}, nil
+	case aggr.LastValue != nil:
+		return sdkmetric.AggregationLastValue{}, nil
+	case aggr.ExplicitBucketHistogram != nil:
+		cfg := aggr.ExplicitBucketHistogram
+		h := sdkmetric.AggregationExplicitBucketHistogram{
+			Boundaries: cfg.Boundaries,
+			NoMinMax:   cfg.RecordMinMax != nil && !*cfg.RecordMinMax,
+		}
+		if h.Boundaries == nil {
+			h.Boundaries = defaultHistogramBoundaries
+		}
+		return h, nil
+	case aggr.Base2ExponentialBucketHistogram != nil:
+		cfg := aggr.Base2ExponentialBucketHistogram
+		h := sdkmetric.AggregationBase2ExponentialHistogram{
+			MaxSize:  160,
+			MaxScale: 20,
+			NoMinMax: cfg.RecordMinMax != nil && !*cfg.RecordMinMax,
+		}
+		if cfg.MaxSize != nil {
+			h.MaxSize = int32(*cfg.MaxSize)
+		}
+		if cfg.MaxScale != nil {
+			h.MaxScale = int32(*cfg.MaxScale)
+		}
+		return h, nil
+	case aggr.Default != nil:
+		return sdkmetric.AggregationDefault{}, nil
+	}
+	return nil, errors.New("aggregation must be set")
+}
+
+// firstMatchView returns a view applying the first of the views matching an instrument.
+func firstMatchView(views []sdkmetric.View) sdkmetric.View {
+	return func(inst sdkmetric.Instrument) (sdkmetric.Stream, bool) {
+		for _, v := range views {
+			if s, ok := v(inst); ok {
+				return s, true
+			}
+		}
+		return sdkmetric.Stream{}, false
+	}
+}
+
+// setCardinalityLimit limits the number of attribute sets of each metric stream of the instruments
+// created afterwards, including the otel.metric.overflow=true set the measurements above the limit are
+// recorded with, if the limit is positive. Otherwise, the limit of the environment of the collector,
+// if any, applies. The limit is an experimental feature of the SDK, only enabled by cardinalityLimitEnv.
+func setCardinalityLimit(limit int) error {
+	switch {
+	case limit > 0:
+		return os.Setenv(cardinalityLimitEnv, strconv.Itoa(limit))
+	case envCardinalityLimitSet:
+		return os.Setenv(cardinalityLimitEnv, envCardinalityLimit)
+	default:
+		return os.Unsetenv(cardinalityLimitEnv)
+	}
+}
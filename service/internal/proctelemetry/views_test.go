@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package proctelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/config"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// collectCounters records the measurements on the counters of a meter provider configured with the
+// views, and returns the data points of the collected sums by name.
+func collectCounters(t *testing.T, views []config.View, cardinalityLimit int, record func(metric.Meter)) map[string][]metricdata.DataPoint[int64] {
+	reader := sdkmetric.NewManualReader()
+	mp, err := InitOpenTelemetry(resource.Empty(), []sdkmetric.Option{sdkmetric.WithReader(reader)}, false, views, cardinalityLimit)
+	require.NoError(t, err)
+	record(mp.Meter("test"))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	points := map[string][]metricdata.DataPoint[int64]{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			points[m.Name] = m.Data.(metricdata.Sum[int64]).DataPoints
+		}
+	}
+	return points
+}
+
+func TestViews(t *testing.T) {
+	views := []config.View{
+		{
+			Selector: &config.ViewSelector{InstrumentName: strPtr("renamed")},
+			Stream:   &config.ViewStream{Name: strPtr("new_name")},
+		},
+		{
+			Selector: &config.ViewSelector{InstrumentName: strPtr("dropped*")},
+			Stream:   &config.ViewStream{Aggregation: &config.ViewStreamAggregation{Drop: config.ViewStreamAggregationDrop{}}},
+		},
+		{
+			Selector: &config.ViewSelector{InstrumentName: strPtr("filtered"), MeterName: strPtr("test")},
+			Stream:   &config.ViewStream{AttributeKeys: []string{"kept"}},
+		},
+	}
+	points := collectCounters(t, views, 0, func(meter metric.Meter) {
+		for _, name := range []string{"renamed", "dropped", "dropped_too", "filtered", "other"} {
+			counter, err := meter.Int64Counter(name)
+			require.NoError(t, err)
+			counter.Add(context.Background(), 1, metric.WithAttributes(attribute.String("kept", "a"), attribute.String("removed", "b")))
+		}
+	})
+
+	assert.Len(t, points, 3)
+	assert.Contains(t, points, "new_name")
+	require.Len(t, points["filtered"], 1)
+	assert.Equal(t, attribute.NewSet(attribute.String("kept", "a")), points["filtered"][0].Attributes)
+	require.Len(t, points["other"], 1)
+	assert.Equal(t, 2, points["other"][0].Attributes.Len())
+}
+
+func TestViewsCardinalityLimit(t *testing.T) {
+	views := []config.View{{
+		Selector: &config.ViewSelector{InstrumentName: strPtr("renamed")},
+		Stream:   &config.ViewStream{Name: strPtr("new_name"), AttributeKeys: []string{"id"}},
+	}}
+	t.Cleanup(func() { require.NoError(t, setCardinalityLimit(0)) })
+	points := collectCounters(t, views, 3, func(meter metric.Meter) {
+		for _, name := range []string{"renamed", "other"} {
+			counter, err := meter.Int64Counter(name)
+			require.NoError(t, err)
+			for _, id := range []string{"1", "2", "3", "4", "1"} {
+				// The sets of the other instrument differ by their second attribute only.
+				counter.Add(context.Background(), 1, metric.WithAttributes(attribute.String("id", "1"), attribute.String("removed", id)))
+				counter.Add(context.Background(), 1, metric.WithAttributes(attribute.String("id", id)))
+			}
+		}
+	})
+
+	// The measurements with the attribute sets above the limit are recorded in the overflow stream.
+	sets := func(points []metricdata.DataPoint[int64]) map[string]int64 {
+		ret := map[string]int64{}
+		for _, dp := range points {
+			ret[dp.Attributes.Encoded(attribute.DefaultEncoder())] = dp.Value
+		}
+		return ret
+	}
+	assert.Equal(t, map[string]int64{"id=1": 7, "id=2": 1, "otel.metric.overflow=true": 2}, sets(points["new_name"]))
+	// The limit applies to the attribute sets, not to the values of each attribute.
+	assert.Equal(t, map[string]int64{"id=1,removed=1": 2, "id=1": 2, "otel.metric.overflow=true": 6}, sets(points["other"]))
+
+	// The limit is not applied to the instruments created without it.
+	points = collectCounters(t, views, 0, func(meter metric.Meter) {
+		counter, err := meter.Int64Counter("other")
+		require.NoError(t, err)
+		for _, id := range []string{"1", "2", "3", "4"} {
+			counter.Add(context.Background(), 1, metric.WithAttributes(attribute.String("id", id)))
+		}
+	})
+	assert.Len(t, points["other"], 4)
+}
+
+func TestViewsFromConfigErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		view config.View
+		err  string
+	}{
+		{
+			name: "no selector",
+			view: config.View{Stream: &config.ViewStream{}},
+			err:  "views[0]: selector must be set",
+		},
+		{
+			name: "no stream",
+			view: config.View{Selector: &config.ViewSelector{InstrumentName: strPtr("counter")}},
+			err:  "views[0]: stream must be set",
+		},
+		{
+			name: "empty selector",
+			view: config.View{Selector: &config.ViewSelector{}, Stream: &config.ViewStream{}},
+			err:  "views[0]: selector must match at least one instrument property",
+		},
+		{
+			name: "invalid instrument type",
+			view: config.View{
+				Selector: &config.ViewSelector{InstrumentType: (*config.ViewSelectorInstrumentType)(strPtr("gauge"))},
+				Stream:   &config.ViewStream{},
+			},
+			err: `views[0]: unsupported instrument_type "gauge"`,
+		},
+		{
+			name: "rename wildcard",
+			view: config.View{
+				Selector: &config.ViewSelector{InstrumentName: strPtr("otelcol_*")},
+				Stream:   &config.ViewStream{Name: strPtr("renamed")},
+			},
+			err: "views[0]: stream name cannot be set for an instrument_name with wildcards",
+		},
+		{
+			name: "empty aggregation",
+			view: config.View{
+				Selector: &config.ViewSelector{InstrumentName: strPtr("counter")},
+				Stream:   &config.ViewStream{Aggregation: &config.ViewStreamAggregation{}},
+			},
+			err: "views[0]: aggregation must be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ViewsFromConfig([]config.View{tt.view})
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -6,9 +6,11 @@ package status // import "go.opentelemetry.io/collector/service/internal/status"
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"sync"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 // onTransitionFunc receives a component.StatusEvent on a successful state transition
@@ -151,6 +153,25 @@ func (r *Reporter) ReportOKIfStarting(id *component.InstanceID) {
 	}
 }
 
+// ReportOKAttributes reports a StatusOK event with the given attributes for the given InstanceID,
+// if its current status is StatusOK with different attributes, so that the attributes never hide
+// an error status.
+func (r *Reporter) ReportOKAttributes(id *component.InstanceID, attributes pcommon.Map) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.ready {
+		r.onInvalidTransition(ErrStatusNotReady)
+		return
+	}
+	fsm := r.componentFSM(id)
+	if fsm.current.Status() != component.StatusOK ||
+		reflect.DeepEqual(fsm.current.Attributes().AsRaw(), attributes.AsRaw()) {
+		return
+	}
+	fsm.current = component.NewStatusEventWithAttributes(component.StatusOK, attributes)
+	fsm.onTransition(fsm.current)
+}
+
 // Note: a lock must be acquired before calling this method.
 func (r *Reporter) componentFSM(id *component.InstanceID) *fsm {
 	fsm, ok := r.fsmMap[id]
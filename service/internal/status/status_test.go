@@ -8,9 +8,11 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 func TestStatusFSM(t *testing.T) {
@@ -351,3 +353,42 @@ func TestReportComponentOKIfStarting(t *testing.T) {
 		})
 	}
 }
+
+func TestReportOKAttributes(t *testing.T) {
+	var received []*component.StatusEvent
+	rep := NewReporter(
+		func(_ *component.InstanceID, ev *component.StatusEvent) {
+			received = append(received, ev)
+		},
+		func(err error) {
+			require.NoError(t, err)
+		},
+	)
+	rep.Ready()
+
+	id := &component.InstanceID{}
+	rep.ReportStatus(id, component.NewStatusEvent(component.StatusStarting))
+	paused := pcommon.NewMap()
+	paused.PutBool("pipeline.paused", true)
+
+	// The attributes are not reported while starting.
+	rep.ReportOKAttributes(id, paused)
+	require.Len(t, received, 1)
+
+	rep.ReportStatus(id, component.NewStatusEvent(component.StatusOK))
+	rep.ReportOKAttributes(id, paused)
+	rep.ReportOKAttributes(id, paused)
+	require.Len(t, received, 3)
+	assert.Equal(t, component.StatusOK, received[2].Status())
+	assert.Equal(t, map[string]any{"pipeline.paused": true}, received[2].Attributes().AsRaw())
+
+	rep.ReportOKAttributes(id, pcommon.NewMap())
+	require.Len(t, received, 4)
+	assert.Equal(t, 0, received[3].Attributes().Len())
+
+	// The attributes never hide an error.
+	rep.ReportStatus(id, component.NewRecoverableErrorEvent(assert.AnError))
+	rep.ReportOKAttributes(id, paused)
+	require.Len(t, received, 5)
+	assert.Equal(t, component.StatusRecoverableError, received[4].Status())
+}
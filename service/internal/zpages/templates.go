@@ -41,6 +41,10 @@ var (
 	propertiesTableBytes    []byte
 	propertiesTableTemplate = parseTemplate("properties_table", propertiesTableBytes)
 
+	//go:embed templates/status_table.html
+	statusTableBytes    []byte
+	statusTableTemplate = parseTemplate("status_table", statusTableBytes)
+
 	//go:embed templates/features_table.html
 	featuresTableBytes    []byte
 	featuresTableTemplate = parseTemplate("features_table", featuresTableBytes)
@@ -91,6 +95,7 @@ type SummaryPipelinesTableRowData struct {
 	FullName    string
 	InputType   string
 	MutatesData bool
+	Paused      bool
 	Receivers   []string
 	Processors  []string
 	Exporters   []string
@@ -172,3 +177,27 @@ func WriteHTMLFeaturesTable(w io.Writer, ftd FeatureGateTableData) {
 		log.Printf("zpages: executing template: %v", err)
 	}
 }
+
+// StatusTableData contains data for the status table template.
+type StatusTableData struct {
+	Rows []StatusTableRowData
+}
+
+// StatusTableRowData contains data for one row in the status table template: the collector,
+// a pipeline or a component instance.
+type StatusTableRowData struct {
+	Name string
+	// Depth is 0 for the collector, 1 for pipelines and 2 for component instances.
+	Depth     int
+	Status     string
+	Error      string
+	Attributes string
+	Timestamp  string
+}
+
+// WriteHTMLStatusTable writes a table of the status tree of the collector.
+func WriteHTMLStatusTable(w io.Writer, std StatusTableData) {
+	if err := statusTableTemplate.Execute(w, std); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
@@ -69,6 +69,14 @@ func TestNoCrash(t *testing.T) {
 	assert.NotPanics(t, func() {
 		WriteHTMLPropertiesTable(buf, PropertiesTableData{Name: "Bar", Properties: [][2]string{{"key", "value"}}})
 	})
+	assert.NotPanics(t, func() {
+		WriteHTMLStatusTable(buf, StatusTableData{Rows: []StatusTableRowData{
+			{Name: "collector", Status: "StatusOK"},
+			{Name: "traces", Depth: 1, Status: "StatusOK"},
+			{Name: "receiver:otlp", Depth: 2, Status: "StatusRecoverableError", Error: "connection refused"},
+			{Name: "processor:batch", Depth: 2, Status: "StatusOK", Attributes: "pipeline.paused=true"},
+		}})
+	})
 	assert.NotPanics(t, func() {
 		WriteHTMLFeaturesTable(buf, FeatureGateTableData{Rows: []FeatureGateTableRowData{
 			{
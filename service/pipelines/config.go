@@ -46,6 +46,42 @@ type PipelineConfig struct {
 	Receivers  []component.ID `mapstructure:"receivers"`
 	Processors []component.ID `mapstructure:"processors"`
 	Exporters  []component.ID `mapstructure:"exporters"`
+
+	// Limits are the resource limits of the pipeline, enforced when the data enters it.
+	Limits LimitsConfig `mapstructure:"limits"`
+}
+
+// LimitsConfig defines the resource limits of a pipeline. The data entering the pipeline is refused
+// while a limit is exceeded, without affecting the other pipelines. Zero values mean no limit.
+//
+// The data is counted from the time it enters the pipeline until the call consuming it returns.
+// The data that a component keeps after the call returns, e.g. buffered by a batch processor or
+// by the sending queue of an exporter, is not counted: the limits bound the synchronous part of
+// the pipeline only, and the buffers are bounded by the settings of these components.
+type LimitsConfig struct {
+	// MaxInFlightItems is the maximum number of spans, data points, log records or profiles
+	// being consumed by the pipeline.
+	MaxInFlightItems int64 `mapstructure:"max_in_flight_items"`
+
+	// MaxInFlightBytes is the maximum size, in bytes of the OTLP protobuf encoding, of the data
+	// being consumed by the pipeline.
+	MaxInFlightBytes int64 `mapstructure:"max_in_flight_bytes"`
+
+	// MaxConcurrentCalls is the maximum number of concurrent calls consuming data in the pipeline.
+	MaxConcurrentCalls int64 `mapstructure:"max_concurrent_calls"`
+}
+
+func (cfg *LimitsConfig) Validate() error {
+	if cfg.MaxInFlightItems < 0 {
+		return errors.New("limits::max_in_flight_items must not be negative")
+	}
+	if cfg.MaxInFlightBytes < 0 {
+		return errors.New("limits::max_in_flight_bytes must not be negative")
+	}
+	if cfg.MaxConcurrentCalls < 0 {
+		return errors.New("limits::max_concurrent_calls must not be negative")
+	}
+	return nil
 }
 
 func (cfg *PipelineConfig) Validate() error {
@@ -69,5 +105,5 @@ func (cfg *PipelineConfig) Validate() error {
 		procSet[ref] = struct{}{}
 	}
 
-	return nil
+	return cfg.Limits.Validate()
 }
@@ -72,6 +72,24 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: errors.New(`pipeline "wrongtype": unknown datatype "wrongtype"`),
 		},
+		{
+			name: "valid-pipeline-limits",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.MustNewID("traces")].Limits = LimitsConfig{MaxInFlightItems: 1000, MaxInFlightBytes: 1 << 20, MaxConcurrentCalls: 10}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "negative-pipeline-limit",
+			cfgFn: func() Config {
+				cfg := generateConfig()
+				cfg[component.MustNewID("traces")].Limits.MaxConcurrentCalls = -1
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces": %w`, errors.New(`limits::max_concurrent_calls must not be negative`)),
+		},
 	}
 
 	for _, test := range testCases {
@@ -7,14 +7,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"runtime"
 
+	"go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/metric"
 	sdkresource "go.opentelemetry.io/otel/sdk/resource"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/connector"
@@ -68,8 +71,11 @@ type Settings struct {
 type Service struct {
 	buildInfo         component.BuildInfo
 	telemetrySettings servicetelemetry.TelemetrySettings
+	loggerProvider    log.LoggerProvider
+	pipelineSource    *telemetry.PipelineSource
 	host              *serviceHost
 	collectorConf     *confmap.Conf
+	shutdownConfig    ShutdownConfig
 }
 
 // New creates a new Service, its telemetry, and Components.
@@ -86,8 +92,10 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 			extensions:        set.Extensions,
 			buildInfo:         set.BuildInfo,
 			asyncErrorChannel: set.AsyncErrorChannel,
+			statusAggregator:  componentstatus.NewAggregator(),
 		},
-		collectorConf: set.CollectorConf,
+		collectorConf:  set.CollectorConf,
+		shutdownConfig: cfg.Shutdown,
 	}
 	tel, err := telemetry.New(ctx, telemetry.Settings{BuildInfo: set.BuildInfo, ZapOptions: set.LoggingOptions}, cfg.Telemetry)
 	if err != nil {
@@ -98,6 +106,8 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 	res := resource.New(set.BuildInfo, cfg.Telemetry.Resource)
 	pcommonRes := pdataFromSdk(res)
 
+	srv.loggerProvider = tel.LoggerProvider()
+	srv.pipelineSource = tel.PipelineSource()
 	logger := tel.Logger()
 	logger.Info("Setting up own telemetry...")
 	mp, err := newMeterProvider(
@@ -105,6 +115,7 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 			res:               res,
 			cfg:               cfg.Telemetry.Metrics,
 			asyncErrorChannel: set.AsyncErrorChannel,
+			pipelineReader:    srv.pipelineSource.MetricReader(),
 		},
 		disableHighCard,
 	)
@@ -188,6 +199,10 @@ func (srv *Service) Start(ctx context.Context) error {
 		return fmt.Errorf("cannot start pipelines: %w", err)
 	}
 
+	if err := srv.pipelineSource.Start(srv.host.pipelines); err != nil {
+		return fmt.Errorf("cannot route own telemetry into pipelines: %w", err)
+	}
+
 	if err := srv.host.serviceExtensions.NotifyPipelineReady(); err != nil {
 		return err
 	}
@@ -198,7 +213,7 @@ func (srv *Service) Start(ctx context.Context) error {
 }
 
 func (srv *Service) shutdownTelemetry(ctx context.Context) error {
-	// The metric.MeterProvider and trace.TracerProvider interfaces do not have a Shutdown method.
+	// The metric.MeterProvider, trace.TracerProvider and log.LoggerProvider interfaces do not have a Shutdown method.
 	// To shutdown the providers we try to cast to this interface, which matches the type signature used in the SDK.
 	type shutdownable interface {
 		Shutdown(context.Context) error
@@ -216,6 +231,12 @@ func (srv *Service) shutdownTelemetry(ctx context.Context) error {
 			err = multierr.Append(err, fmt.Errorf("failed to shutdown tracer provider: %w", shutdownErr))
 		}
 	}
+
+	if prov, ok := srv.loggerProvider.(shutdownable); ok {
+		if shutdownErr := prov.Shutdown(ctx); shutdownErr != nil {
+			err = multierr.Append(err, fmt.Errorf("failed to shutdown logger provider: %w", shutdownErr))
+		}
+	}
 	return err
 }
 
@@ -235,6 +256,13 @@ func (srv *Service) Shutdown(ctx context.Context) error {
 		errs = multierr.Append(errs, fmt.Errorf("failed to notify that pipeline is not ready: %w", err))
 	}
 
+	// Stop routing the telemetry of the collector before its pipelines shut down.
+	srv.pipelineSource.Shutdown()
+
+	if srv.shutdownConfig.DrainTimeout > 0 {
+		errs = multierr.Append(errs, srv.drainPipelines(ctx))
+	}
+
 	if err := srv.host.pipelines.ShutdownAll(ctx); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown pipelines: %w", err))
 	}
@@ -243,6 +271,8 @@ func (srv *Service) Shutdown(ctx context.Context) error {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown extensions: %w", err))
 	}
 
+	srv.host.statusAggregator.Close()
+
 	srv.telemetrySettings.Logger.Info("Shutdown complete.")
 
 	errs = multierr.Append(errs, srv.shutdownTelemetry(ctx))
@@ -250,6 +280,24 @@ func (srv *Service) Shutdown(ctx context.Context) error {
 	return errs
 }
 
+// drainPipelines stops the receivers and waits up to the drain timeout for the data buffered by the
+// pipelines to be sent, before the pipelines are shut down.
+func (srv *Service) drainPipelines(ctx context.Context) error {
+	srv.telemetrySettings.Logger.Info("Draining pipelines...", zap.Duration("drain_timeout", srv.shutdownConfig.DrainTimeout))
+	drainCtx, cancel := context.WithTimeout(ctx, srv.shutdownConfig.DrainTimeout)
+	defer cancel()
+	lost, err := srv.host.pipelines.DrainAll(drainCtx)
+	if err != nil {
+		err = fmt.Errorf("failed to drain pipelines: %w", err)
+	}
+	if lost > 0 {
+		srv.telemetrySettings.Logger.Warn("Pipelines not drained before the drain timeout.", zap.Int("lost_items", lost))
+		return err
+	}
+	srv.telemetrySettings.Logger.Info("Pipelines drained.")
+	return err
+}
+
 // Creates extensions and then builds the pipeline graph.
 func (srv *Service) initExtensionsAndPipeline(ctx context.Context, set Settings, cfg Config) error {
 	var err error
@@ -292,6 +340,25 @@ func (srv *Service) Logger() *zap.Logger {
 	return srv.telemetrySettings.Logger
 }
 
+// WriteTopology writes the graph of the pipelines of the service, annotated with the capabilities of
+// its nodes, in the given format: "dot", "mermaid" or "json".
+func (srv *Service) WriteTopology(w io.Writer, format string) error {
+	return srv.host.pipelines.Topology().Write(w, format)
+}
+
+// RecordPipeline calls record with the context and the data of each batch entering the pipeline, a
+// ptrace.Traces, pmetric.Metrics or plog.Logs, until the returned stop function is called. record is
+// called concurrently, and must not modify nor retain the data. Profiles pipelines cannot be recorded.
+func (srv *Service) RecordPipeline(pipelineID component.ID, record func(ctx context.Context, data any)) (stop func(), err error) {
+	return srv.host.pipelines.RecordPipeline(pipelineID, record)
+}
+
+// ConsumePipeline passes the data, a ptrace.Traces, pmetric.Metrics or plog.Logs, to the pipeline as
+// if it was received by the receivers of the pipeline.
+func (srv *Service) ConsumePipeline(ctx context.Context, pipelineID component.ID, data any) error {
+	return srv.host.pipelines.ConsumePipeline(ctx, pipelineID, data)
+}
+
 func getBallastSize(host component.Host) uint64 {
 	for _, ext := range host.GetExtensions() {
 		if bExt, ok := ext.(interface{ GetBallastSize() uint64 }); ok {
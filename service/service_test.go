@@ -30,6 +30,7 @@ import (
 	"go.opentelemetry.io/collector/extension/zpagesextension"
 	"go.opentelemetry.io/collector/internal/testutil"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/testdata"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 	"go.opentelemetry.io/collector/service/extensions"
@@ -238,6 +239,96 @@ func TestServiceGetExporters(t *testing.T) {
 	assert.Contains(t, expMap[component.DataTypeProfiles], component.NewID(nopType))
 }
 
+func TestServicePipelineControls(t *testing.T) {
+	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
+	require.NoError(t, err)
+
+	assert.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+
+	tracesID := component.MustNewID("traces")
+	require.NoError(t, srv.host.SetPipelinePaused(tracesID, true))
+	require.NoError(t, srv.host.SetProcessorBypassed(tracesID, component.NewID(nopType), true))
+	assert.Equal(t, []component.ID{tracesID}, srv.host.PausedPipelines())
+	assert.Equal(t, map[component.ID][]component.ID{tracesID: {component.NewID(nopType)}}, srv.host.BypassedProcessors())
+
+	require.NoError(t, srv.host.SetPipelinePaused(tracesID, false))
+	require.NoError(t, srv.host.SetProcessorBypassed(tracesID, component.NewID(nopType), false))
+	assert.Empty(t, srv.host.PausedPipelines())
+	assert.Empty(t, srv.host.BypassedProcessors())
+
+	assert.Error(t, srv.host.SetPipelinePaused(component.MustNewID("unknown"), true))
+	assert.Error(t, srv.host.SetProcessorBypassed(tracesID, component.MustNewID("unknown"), true))
+}
+
+func TestServiceTapNode(t *testing.T) {
+	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+
+	emit := func([]byte) error { return nil }
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, srv.host.TapNode(ctx, component.MustNewID("traces"), component.KindProcessor, component.NewID(nopType), 1, 1, nil, emit))
+	assert.Error(t, srv.host.TapNode(ctx, component.MustNewID("unknown"), component.KindProcessor, component.NewID(nopType), 1, 1, nil, emit))
+
+	assert.NoError(t, srv.host.ValidateTapNode(component.MustNewID("traces"), component.KindProcessor, component.NewID(nopType), 1, 1))
+	assert.EqualError(t, srv.host.ValidateTapNode(component.MustNewID("unknown"), component.KindProcessor, component.NewID(nopType), 1, 1), `pipeline "unknown" not found`)
+	assert.Error(t, srv.host.ValidateTapNode(component.MustNewID("traces"), component.KindProcessor, component.NewID(nopType), 0, 1))
+}
+
+func TestServiceRecordPipeline(t *testing.T) {
+	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+
+	var recorded []any
+	stop, err := srv.RecordPipeline(component.MustNewID("logs"), func(_ context.Context, data any) {
+		recorded = append(recorded, data)
+	})
+	require.NoError(t, err)
+	require.NoError(t, srv.ConsumePipeline(context.Background(), component.MustNewID("logs"), testdata.GenerateLogs(1)))
+	stop()
+	assert.Equal(t, []any{testdata.GenerateLogs(1)}, recorded)
+
+	_, err = srv.RecordPipeline(component.MustNewID("profiles"), func(context.Context, any) {})
+	assert.Error(t, err)
+	assert.Error(t, srv.ConsumePipeline(context.Background(), component.MustNewID("unknown"), testdata.GenerateLogs(1)))
+}
+
+func TestServiceShutdownDrain(t *testing.T) {
+	cfg := newNopConfig()
+	cfg.Shutdown.DrainTimeout = time.Second
+	srv, err := New(context.Background(), newNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	assert.NoError(t, srv.Shutdown(context.Background()))
+}
+
+func TestServiceTelemetryPipelines(t *testing.T) {
+	cfg := newNopConfig()
+	cfg.Telemetry.Traces.Pipeline = component.MustNewID("traces")
+	cfg.Telemetry.Metrics.Pipeline = component.MustNewID("metrics")
+	cfg.Telemetry.Logs.Pipeline = component.MustNewID("logs")
+	require.NoError(t, cfg.Validate())
+
+	srv, err := New(context.Background(), newNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, srv.pipelineSource.MetricReader())
+
+	require.NoError(t, srv.Start(context.Background()))
+	srv.telemetrySettings.Logger.Info("Routed into the logs pipeline.")
+	assert.NoError(t, srv.Shutdown(context.Background()))
+}
+
 // TestServiceTelemetryCleanupOnError tests that if newService errors due to an invalid config telemetry is cleaned up
 // and another service with a valid config can be started right after.
 func TestServiceTelemetryCleanupOnError(t *testing.T) {
@@ -503,6 +594,9 @@ func assertZPages(t *testing.T, zpagesAddr string) {
 		"/debug/pipelinez",
 		"/debug/servicez",
 		"/debug/extensionz",
+		"/debug/statusz",
+		"/debug/topologyz",
+		"/debug/topologyz?format=mermaid",
 	}
 
 	testZPagePathFn := func(t *testing.T, path string) {
@@ -39,10 +39,12 @@ type meterProviderSettings struct {
 	res               *resource.Resource
 	cfg               telemetry.MetricsConfig
 	asyncErrorChannel chan error
+	// pipelineReader reads the metrics routed into the metrics pipeline, if any.
+	pipelineReader sdkmetric.Reader
 }
 
 func newMeterProvider(set meterProviderSettings, disableHighCardinality bool) (metric.MeterProvider, error) {
-	if set.cfg.Level == configtelemetry.LevelNone || (set.cfg.Address == "" && len(set.cfg.Readers) == 0) {
+	if set.cfg.Level == configtelemetry.LevelNone || (set.cfg.Address == "" && len(set.cfg.Readers) == 0 && set.pipelineReader == nil) {
 		return noopmetric.NewMeterProvider(), nil
 	}
 
@@ -88,9 +90,12 @@ func newMeterProvider(set meterProviderSettings, disableHighCardinality bool) (m
 		}
 		opts = append(opts, sdkmetric.WithReader(r))
 	}
+	if set.pipelineReader != nil {
+		opts = append(opts, sdkmetric.WithReader(set.pipelineReader))
+	}
 
 	var err error
-	mp.MeterProvider, err = proctelemetry.InitOpenTelemetry(set.res, opts, disableHighCardinality)
+	mp.MeterProvider, err = proctelemetry.InitOpenTelemetry(set.res, opts, disableHighCardinality, set.cfg.Views, set.cfg.CardinalityLimit)
 	if err != nil {
 		return nil, err
 	}
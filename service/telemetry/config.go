@@ -10,7 +10,9 @@ import (
 	"go.opentelemetry.io/contrib/config"
 	"go.uber.org/zap/zapcore"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/service/internal/proctelemetry"
 )
 
 // Config defines the configurable settings for service telemetry.
@@ -92,6 +94,15 @@ type LogsConfig struct {
 	//
 	// By default, there is no initial field.
 	InitialFields map[string]any `mapstructure:"initial_fields"`
+
+	// Processors allow configuration of log record processors to emit the logs of the collector,
+	// in addition to the zap outputs, to any number of supported backends. The log records of the
+	// exporters are correlated with the span of the failed export, if any.
+	Processors []config.LogRecordProcessor `mapstructure:"processors"`
+
+	// Pipeline is the ID of a logs pipeline to route the logs of the collector into, in addition
+	// to the zap outputs and the processors. See PipelineSource.
+	Pipeline component.ID `mapstructure:"pipeline"`
 }
 
 // LogsSamplingConfig sets a sampling strategy for the logger. Sampling caps the
@@ -125,6 +136,21 @@ type MetricsConfig struct {
 	// Readers allow configuration of metric readers to emit metrics to
 	// any number of supported backends.
 	Readers []config.MetricReader `mapstructure:"readers"`
+
+	// Pipeline is the ID of a metrics pipeline to route the metrics of the collector into, in
+	// addition to the readers. See PipelineSource.
+	Pipeline component.ID `mapstructure:"pipeline"`
+
+	// Views configure the streams of the instruments, e.g. to rename an instrument, drop it or
+	// keep only some of its attributes. The first view matching an instrument applies, the views
+	// take precedence over the default views of the collector.
+	Views []config.View `mapstructure:"views"`
+
+	// CardinalityLimit is the maximum number of attribute sets of each metric stream. Once the
+	// limit is reached, the measurements with other attribute sets are aggregated into a single
+	// stream with the otel.metric.overflow=true attribute, which counts toward the limit.
+	// There is no limit if zero, the default.
+	CardinalityLimit int `mapstructure:"cardinality_limit"`
 }
 
 // TracesConfig exposes the common Telemetry configuration for collector's internal spans.
@@ -137,13 +163,25 @@ type TracesConfig struct {
 	// Processors allow configuration of span processors to emit spans to
 	// any number of suported backends.
 	Processors []config.SpanProcessor `mapstructure:"processors"`
+
+	// Pipeline is the ID of a traces pipeline to route the spans of the collector into, in
+	// addition to the processors. See PipelineSource.
+	Pipeline component.ID `mapstructure:"pipeline"`
 }
 
 // Validate checks whether the current configuration is valid
 func (c *Config) Validate() error {
 	// Check when service telemetry metric level is not none, the metrics address should not be empty
-	if c.Metrics.Level != configtelemetry.LevelNone && c.Metrics.Address == "" && len(c.Metrics.Readers) == 0 {
-		return fmt.Errorf("collector telemetry metric address or reader should exist when metric level is not none")
+	if c.Metrics.Level != configtelemetry.LevelNone && c.Metrics.Address == "" && len(c.Metrics.Readers) == 0 && c.Metrics.Pipeline == (component.ID{}) {
+		return fmt.Errorf("collector telemetry metric address, reader or pipeline should exist when metric level is not none")
+	}
+
+	if c.Metrics.CardinalityLimit < 0 {
+		return fmt.Errorf("collector telemetry metrics cardinality_limit must not be negative")
+	}
+
+	if _, err := proctelemetry.ViewsFromConfig(c.Metrics.Views); err != nil {
+		return fmt.Errorf("collector telemetry metrics %w", err)
 	}
 
 	return nil
@@ -50,6 +50,43 @@ func TestLoadConfig(t *testing.T) {
 			},
 			success: true,
 		},
+		{
+			name: "valid metric telemetry with views and cardinality limit",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+					Views: []config.View{{
+						Selector: &config.ViewSelector{InstrumentName: ptr("otelcol_exporter_sent_spans")},
+						Stream:   &config.ViewStream{Name: ptr("sent_spans")},
+					}},
+					CardinalityLimit: 100,
+				},
+			},
+			success: true,
+		},
+		{
+			name: "invalid metric telemetry view",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:   configtelemetry.LevelBasic,
+					Address: "127.0.0.1:3333",
+					Views:   []config.View{{Stream: &config.ViewStream{Name: ptr("sent_spans")}}},
+				},
+			},
+			success: false,
+		},
+		{
+			name: "negative metric telemetry cardinality limit",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level:            configtelemetry.LevelBasic,
+					Address:          "127.0.0.1:3333",
+					CardinalityLimit: -1,
+				},
+			},
+			success: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -63,3 +100,7 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func ptr[T any](v T) *T {
+	return &v
+}
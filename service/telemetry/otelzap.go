@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	otelCoreScopeName = "go.opentelemetry.io/collector/service/telemetry"
+
+	// Attributes of the code location of the log entry, see
+	// https://opentelemetry.io/docs/specs/semconv/attributes-registry/code/.
+	codeFilepathKey = "code.filepath"
+	codeLinenoKey   = "code.lineno"
+	codeFunctionKey = "code.function"
+)
+
+// otelCore is a zapcore.Core emitting the log entries to an OpenTelemetry log.Logger.
+// Any field holding a context.Context, e.g. created with logctx.Field, sets the context of the
+// emitted log records instead of being added to their attributes.
+type otelCore struct {
+	zapcore.LevelEnabler
+	logger log.Logger
+	ctx    context.Context
+	fields []zapcore.Field
+}
+
+var _ zapcore.Core = (*otelCore)(nil)
+
+func newOTelCore(provider log.LoggerProvider, enabler zapcore.LevelEnabler) *otelCore {
+	return &otelCore{
+		LevelEnabler: enabler,
+		logger:       provider.Logger(otelCoreScopeName),
+		ctx:          context.Background(),
+	}
+}
+
+func (c *otelCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := &otelCore{
+		LevelEnabler: c.LevelEnabler,
+		logger:       c.logger,
+		ctx:          c.ctx,
+		fields:       make([]zapcore.Field, 0, len(c.fields)+len(fields)),
+	}
+	clone.fields = append(clone.fields, c.fields...)
+	for _, field := range fields {
+		if ctx, ok := field.Interface.(context.Context); ok {
+			clone.ctx = ctx
+			continue
+		}
+		clone.fields = append(clone.fields, field)
+	}
+	return clone
+}
+
+func (c *otelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *otelCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	ctx := c.ctx
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range c.fields {
+		field.AddTo(enc)
+	}
+	for _, field := range fields {
+		if fieldCtx, ok := field.Interface.(context.Context); ok {
+			ctx = fieldCtx
+			continue
+		}
+		field.AddTo(enc)
+	}
+
+	var record log.Record
+	record.SetTimestamp(entry.Time)
+	record.SetBody(log.StringValue(entry.Message))
+	record.SetSeverity(otelSeverity(entry.Level))
+	record.SetSeverityText(entry.Level.CapitalString())
+	record.AddAttributes(otelKeyValues(enc.Fields)...)
+	if entry.Caller.Defined {
+		record.AddAttributes(
+			log.String(codeFilepathKey, entry.Caller.File),
+			log.Int(codeLinenoKey, entry.Caller.Line),
+			log.String(codeFunctionKey, entry.Caller.Function),
+		)
+	}
+	c.logger.Emit(ctx, record)
+	return nil
+}
+
+func (c *otelCore) Sync() error {
+	return nil
+}
+
+func otelSeverity(level zapcore.Level) log.Severity {
+	switch level {
+	case zapcore.DebugLevel:
+		return log.SeverityDebug
+	case zapcore.InfoLevel:
+		return log.SeverityInfo
+	case zapcore.WarnLevel:
+		return log.SeverityWarn
+	case zapcore.ErrorLevel:
+		return log.SeverityError
+	case zapcore.DPanicLevel:
+		return log.SeverityFatal1
+	case zapcore.PanicLevel:
+		return log.SeverityFatal2
+	case zapcore.FatalLevel:
+		return log.SeverityFatal3
+	}
+	return log.SeverityUndefined
+}
+
+// otelKeyValues converts the fields encoded by a zapcore.MapObjectEncoder, sorted by key.
+func otelKeyValues(fields map[string]any) []log.KeyValue {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	kvs := make([]log.KeyValue, 0, len(keys))
+	for _, key := range keys {
+		kvs = append(kvs, log.KeyValue{Key: key, Value: otelValue(fields[key])})
+	}
+	return kvs
+}
+
+func otelValue(v any) log.Value {
+	switch v := v.(type) {
+	case string:
+		return log.StringValue(v)
+	case bool:
+		return log.BoolValue(v)
+	case int:
+		return log.IntValue(v)
+	case int8:
+		return log.Int64Value(int64(v))
+	case int16:
+		return log.Int64Value(int64(v))
+	case int32:
+		return log.Int64Value(int64(v))
+	case int64:
+		return log.Int64Value(v)
+	case uint8:
+		return log.Int64Value(int64(v))
+	case uint16:
+		return log.Int64Value(int64(v))
+	case uint32:
+		return log.Int64Value(int64(v))
+	case float32:
+		return log.Float64Value(float64(v))
+	case float64:
+		return log.Float64Value(v)
+	case []byte:
+		return log.BytesValue(v)
+	case time.Time:
+		return log.Int64Value(v.UnixNano())
+	case time.Duration:
+		return log.Int64Value(int64(v))
+	case []any:
+		values := make([]log.Value, 0, len(v))
+		for _, elem := range v {
+			values = append(values, otelValue(elem))
+		}
+		return log.SliceValue(values...)
+	case map[string]any:
+		return log.MapValue(otelKeyValues(v)...)
+	}
+	// Unsigned integers which may overflow an int64, complex numbers, and reflected values.
+	return log.StringValue(fmt.Sprintf("%+v", v))
+}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/internal/logctx"
+)
+
+type recordingExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *recordingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func (e *recordingExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func newTestOTelLogger(t *testing.T) (*zap.Logger, *recordingExporter) {
+	exp := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exp)))
+	t.Cleanup(func() { assert.NoError(t, lp.Shutdown(context.Background())) })
+	return zap.New(newOTelCore(lp, zapcore.InfoLevel), zap.AddCaller()), exp
+}
+
+func recordAttributes(r sdklog.Record) map[string]log.Value {
+	attrs := map[string]log.Value{}
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	return attrs
+}
+
+func TestOTelCore(t *testing.T) {
+	logger, exp := newTestOTelLogger(t)
+
+	logger.Debug("filtered")
+	logger.With(zap.String("kind", "receiver")).Warn("message",
+		zap.Int("count", 3),
+		zap.Bool("ok", false),
+		zap.Float64("ratio", 0.5),
+		zap.Duration("delay", time.Second),
+		zap.Strings("ids", []string{"a", "b"}),
+		zap.Error(errors.New("failed")),
+	)
+
+	require.Len(t, exp.records, 1)
+	r := exp.records[0]
+	assert.Equal(t, "message", r.Body().AsString())
+	assert.Equal(t, log.SeverityWarn, r.Severity())
+	assert.Equal(t, "WARN", r.SeverityText())
+	assert.False(t, r.Timestamp().IsZero())
+	assert.False(t, r.TraceID().IsValid())
+
+	attrs := recordAttributes(r)
+	assert.Equal(t, "receiver", attrs["kind"].AsString())
+	assert.Equal(t, int64(3), attrs["count"].AsInt64())
+	assert.False(t, attrs["ok"].AsBool())
+	assert.Equal(t, 0.5, attrs["ratio"].AsFloat64())
+	assert.Equal(t, int64(time.Second), attrs["delay"].AsInt64())
+	assert.Equal(t, []log.Value{log.StringValue("a"), log.StringValue("b")}, attrs["ids"].AsSlice())
+	assert.Equal(t, "failed", attrs["error"].AsString())
+	assert.Contains(t, attrs[codeFilepathKey].AsString(), "otelzap_test.go")
+	assert.Positive(t, attrs[codeLinenoKey].AsInt64())
+	assert.Contains(t, attrs[codeFunctionKey].AsString(), "TestOTelCore")
+}
+
+func TestOTelCoreTraceCorrelation(t *testing.T) {
+	logger, exp := newTestOTelLogger(t)
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	logger.Info("in span", logctx.Field(ctx))
+	logger.With(logctx.Field(ctx)).Error("with span")
+	logger.Info("without span")
+
+	require.Len(t, exp.records, 3)
+	for _, r := range exp.records[:2] {
+		assert.Equal(t, sc.TraceID(), r.TraceID())
+		assert.Equal(t, sc.SpanID(), r.SpanID())
+		assert.Equal(t, trace.FlagsSampled, r.TraceFlags())
+		assert.NotContains(t, recordAttributes(r), logctx.Key)
+	}
+	assert.False(t, exp.records[2].TraceID().IsValid())
+}
@@ -0,0 +1,363 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// tracesFromSpans converts the spans of the SDK, grouped by resource and instrumentation scope.
+func tracesFromSpans(spans []sdktrace.ReadOnlySpan) ptrace.Traces {
+	td := ptrace.NewTraces()
+	resourceSpans := map[attribute.Distinct]ptrace.ResourceSpans{}
+	scopeSpans := map[attribute.Distinct]map[instrumentation.Scope]ptrace.ScopeSpans{}
+	for _, span := range spans {
+		key := span.Resource().Equivalent()
+		rs, ok := resourceSpans[key]
+		if !ok {
+			rs = td.ResourceSpans().AppendEmpty()
+			putResource(rs.Resource(), span.Resource())
+			rs.SetSchemaUrl(span.Resource().SchemaURL())
+			resourceSpans[key] = rs
+			scopeSpans[key] = map[instrumentation.Scope]ptrace.ScopeSpans{}
+		}
+		scope := span.InstrumentationScope()
+		ss, ok := scopeSpans[key][scope]
+		if !ok {
+			ss = rs.ScopeSpans().AppendEmpty()
+			putScope(ss.Scope(), scope)
+			ss.SetSchemaUrl(scope.SchemaURL)
+			scopeSpans[key][scope] = ss
+		}
+		putSpan(ss.Spans().AppendEmpty(), span)
+	}
+	return td
+}
+
+func putSpan(dest ptrace.Span, span sdktrace.ReadOnlySpan) {
+	sc := span.SpanContext()
+	dest.SetTraceID(pcommon.TraceID(sc.TraceID()))
+	dest.SetSpanID(pcommon.SpanID(sc.SpanID()))
+	dest.TraceState().FromRaw(sc.TraceState().String())
+	dest.SetFlags(uint32(sc.TraceFlags()))
+	if parent := span.Parent(); parent.IsValid() {
+		dest.SetParentSpanID(pcommon.SpanID(parent.SpanID()))
+	}
+	dest.SetName(span.Name())
+	// The values of the span kinds are the same in the SDK and in OTLP.
+	dest.SetKind(ptrace.SpanKind(span.SpanKind()))
+	dest.SetStartTimestamp(pcommon.NewTimestampFromTime(span.StartTime()))
+	dest.SetEndTimestamp(pcommon.NewTimestampFromTime(span.EndTime()))
+	putAttributes(dest.Attributes(), span.Attributes())
+	dest.SetDroppedAttributesCount(uint32(span.DroppedAttributes()))
+
+	for _, event := range span.Events() {
+		de := dest.Events().AppendEmpty()
+		de.SetName(event.Name)
+		de.SetTimestamp(pcommon.NewTimestampFromTime(event.Time))
+		putAttributes(de.Attributes(), event.Attributes)
+		de.SetDroppedAttributesCount(uint32(event.DroppedAttributeCount))
+	}
+	dest.SetDroppedEventsCount(uint32(span.DroppedEvents()))
+
+	for _, link := range span.Links() {
+		dl := dest.Links().AppendEmpty()
+		dl.SetTraceID(pcommon.TraceID(link.SpanContext.TraceID()))
+		dl.SetSpanID(pcommon.SpanID(link.SpanContext.SpanID()))
+		dl.TraceState().FromRaw(link.SpanContext.TraceState().String())
+		putAttributes(dl.Attributes(), link.Attributes)
+		dl.SetDroppedAttributesCount(uint32(link.DroppedAttributeCount))
+	}
+	dest.SetDroppedLinksCount(uint32(span.DroppedLinks()))
+
+	status := span.Status()
+	switch status.Code {
+	case codes.Ok:
+		dest.Status().SetCode(ptrace.StatusCodeOk)
+	case codes.Error:
+		dest.Status().SetCode(ptrace.StatusCodeError)
+	default:
+		dest.Status().SetCode(ptrace.StatusCodeUnset)
+	}
+	dest.Status().SetMessage(status.Description)
+}
+
+// metricsFromResourceMetrics converts the metrics collected by a reader of the SDK.
+func metricsFromResourceMetrics(rm *metricdata.ResourceMetrics) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rms := md.ResourceMetrics().AppendEmpty()
+	if rm.Resource != nil {
+		putResource(rms.Resource(), rm.Resource)
+		rms.SetSchemaUrl(rm.Resource.SchemaURL())
+	}
+	for _, sm := range rm.ScopeMetrics {
+		sms := rms.ScopeMetrics().AppendEmpty()
+		putScope(sms.Scope(), sm.Scope)
+		sms.SetSchemaUrl(sm.Scope.SchemaURL)
+		for _, m := range sm.Metrics {
+			putMetric(sms.Metrics().AppendEmpty(), m)
+		}
+	}
+	return md
+}
+
+func putMetric(dest pmetric.Metric, m metricdata.Metrics) {
+	dest.SetName(m.Name)
+	dest.SetDescription(m.Description)
+	dest.SetUnit(m.Unit)
+	switch data := m.Data.(type) {
+	case metricdata.Gauge[int64]:
+		putNumberDataPoints(dest.SetEmptyGauge().DataPoints(), data.DataPoints)
+	case metricdata.Gauge[float64]:
+		putNumberDataPoints(dest.SetEmptyGauge().DataPoints(), data.DataPoints)
+	case metricdata.Sum[int64]:
+		sum := dest.SetEmptySum()
+		sum.SetIsMonotonic(data.IsMonotonic)
+		sum.SetAggregationTemporality(aggregationTemporality(data.Temporality))
+		putNumberDataPoints(sum.DataPoints(), data.DataPoints)
+	case metricdata.Sum[float64]:
+		sum := dest.SetEmptySum()
+		sum.SetIsMonotonic(data.IsMonotonic)
+		sum.SetAggregationTemporality(aggregationTemporality(data.Temporality))
+		putNumberDataPoints(sum.DataPoints(), data.DataPoints)
+	case metricdata.Histogram[int64]:
+		histogram := dest.SetEmptyHistogram()
+		histogram.SetAggregationTemporality(aggregationTemporality(data.Temporality))
+		putHistogramDataPoints(histogram.DataPoints(), data.DataPoints)
+	case metricdata.Histogram[float64]:
+		histogram := dest.SetEmptyHistogram()
+		histogram.SetAggregationTemporality(aggregationTemporality(data.Temporality))
+		putHistogramDataPoints(histogram.DataPoints(), data.DataPoints)
+	case metricdata.ExponentialHistogram[int64]:
+		histogram := dest.SetEmptyExponentialHistogram()
+		histogram.SetAggregationTemporality(aggregationTemporality(data.Temporality))
+		putExponentialHistogramDataPoints(histogram.DataPoints(), data.DataPoints)
+	case metricdata.ExponentialHistogram[float64]:
+		histogram := dest.SetEmptyExponentialHistogram()
+		histogram.SetAggregationTemporality(aggregationTemporality(data.Temporality))
+		putExponentialHistogramDataPoints(histogram.DataPoints(), data.DataPoints)
+	case metricdata.Summary:
+		putSummaryDataPoints(dest.SetEmptySummary().DataPoints(), data.DataPoints)
+	}
+}
+
+func putNumberDataPoints[N int64 | float64](dest pmetric.NumberDataPointSlice, points []metricdata.DataPoint[N]) {
+	dest.EnsureCapacity(len(points))
+	for _, p := range points {
+		dp := dest.AppendEmpty()
+		putAttributes(dp.Attributes(), p.Attributes.ToSlice())
+		dp.SetStartTimestamp(pcommon.NewTimestampFromTime(p.StartTime))
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(p.Time))
+		switch v := any(p.Value).(type) {
+		case int64:
+			dp.SetIntValue(v)
+		case float64:
+			dp.SetDoubleValue(v)
+		}
+	}
+}
+
+func putHistogramDataPoints[N int64 | float64](dest pmetric.HistogramDataPointSlice, points []metricdata.HistogramDataPoint[N]) {
+	dest.EnsureCapacity(len(points))
+	for _, p := range points {
+		dp := dest.AppendEmpty()
+		putAttributes(dp.Attributes(), p.Attributes.ToSlice())
+		dp.SetStartTimestamp(pcommon.NewTimestampFromTime(p.StartTime))
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(p.Time))
+		dp.SetCount(p.Count)
+		dp.SetSum(float64(p.Sum))
+		dp.ExplicitBounds().FromRaw(p.Bounds)
+		dp.BucketCounts().FromRaw(p.BucketCounts)
+		if v, ok := p.Min.Value(); ok {
+			dp.SetMin(float64(v))
+		}
+		if v, ok := p.Max.Value(); ok {
+			dp.SetMax(float64(v))
+		}
+	}
+}
+
+func putExponentialHistogramDataPoints[N int64 | float64](dest pmetric.ExponentialHistogramDataPointSlice, points []metricdata.ExponentialHistogramDataPoint[N]) {
+	dest.EnsureCapacity(len(points))
+	for _, p := range points {
+		dp := dest.AppendEmpty()
+		putAttributes(dp.Attributes(), p.Attributes.ToSlice())
+		dp.SetStartTimestamp(pcommon.NewTimestampFromTime(p.StartTime))
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(p.Time))
+		dp.SetCount(p.Count)
+		dp.SetSum(float64(p.Sum))
+		dp.SetScale(p.Scale)
+		dp.SetZeroCount(p.ZeroCount)
+		dp.SetZeroThreshold(p.ZeroThreshold)
+		dp.Positive().SetOffset(p.PositiveBucket.Offset)
+		dp.Positive().BucketCounts().FromRaw(p.PositiveBucket.Counts)
+		dp.Negative().SetOffset(p.NegativeBucket.Offset)
+		dp.Negative().BucketCounts().FromRaw(p.NegativeBucket.Counts)
+		if v, ok := p.Min.Value(); ok {
+			dp.SetMin(float64(v))
+		}
+		if v, ok := p.Max.Value(); ok {
+			dp.SetMax(float64(v))
+		}
+	}
+}
+
+func putSummaryDataPoints(dest pmetric.SummaryDataPointSlice, points []metricdata.SummaryDataPoint) {
+	dest.EnsureCapacity(len(points))
+	for _, p := range points {
+		dp := dest.AppendEmpty()
+		putAttributes(dp.Attributes(), p.Attributes.ToSlice())
+		dp.SetStartTimestamp(pcommon.NewTimestampFromTime(p.StartTime))
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(p.Time))
+		dp.SetCount(p.Count)
+		dp.SetSum(p.Sum)
+		for _, q := range p.QuantileValues {
+			dq := dp.QuantileValues().AppendEmpty()
+			dq.SetQuantile(q.Quantile)
+			dq.SetValue(q.Value)
+		}
+	}
+}
+
+func aggregationTemporality(temporality metricdata.Temporality) pmetric.AggregationTemporality {
+	switch temporality {
+	case metricdata.CumulativeTemporality:
+		return pmetric.AggregationTemporalityCumulative
+	case metricdata.DeltaTemporality:
+		return pmetric.AggregationTemporalityDelta
+	}
+	return pmetric.AggregationTemporalityUnspecified
+}
+
+// logsFromRecords converts the log records of the SDK, grouped by resource and instrumentation scope.
+func logsFromRecords(records []sdklog.Record) plog.Logs {
+	ld := plog.NewLogs()
+	resourceLogs := map[attribute.Distinct]plog.ResourceLogs{}
+	scopeLogs := map[attribute.Distinct]map[instrumentation.Scope]plog.ScopeLogs{}
+	for _, record := range records {
+		res := record.Resource()
+		key := res.Equivalent()
+		rl, ok := resourceLogs[key]
+		if !ok {
+			rl = ld.ResourceLogs().AppendEmpty()
+			putResource(rl.Resource(), &res)
+			rl.SetSchemaUrl(res.SchemaURL())
+			resourceLogs[key] = rl
+			scopeLogs[key] = map[instrumentation.Scope]plog.ScopeLogs{}
+		}
+		scope := record.InstrumentationScope()
+		sl, ok := scopeLogs[key][scope]
+		if !ok {
+			sl = rl.ScopeLogs().AppendEmpty()
+			putScope(sl.Scope(), scope)
+			sl.SetSchemaUrl(scope.SchemaURL)
+			scopeLogs[key][scope] = sl
+		}
+		putLogRecord(sl.LogRecords().AppendEmpty(), record)
+	}
+	return ld
+}
+
+func putLogRecord(dest plog.LogRecord, record sdklog.Record) {
+	dest.SetTimestamp(pcommon.NewTimestampFromTime(record.Timestamp()))
+	dest.SetObservedTimestamp(pcommon.NewTimestampFromTime(record.ObservedTimestamp()))
+	// The values of the severities are the same in the SDK and in OTLP.
+	dest.SetSeverityNumber(plog.SeverityNumber(record.Severity()))
+	dest.SetSeverityText(record.SeverityText())
+	putLogValue(dest.Body(), record.Body())
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		putLogValue(dest.Attributes().PutEmpty(kv.Key), kv.Value)
+		return true
+	})
+	dest.SetDroppedAttributesCount(uint32(record.DroppedAttributes()))
+	dest.SetTraceID(pcommon.TraceID(record.TraceID()))
+	dest.SetSpanID(pcommon.SpanID(record.SpanID()))
+	dest.SetFlags(plog.LogRecordFlags(record.TraceFlags()))
+}
+
+func putLogValue(dest pcommon.Value, v log.Value) {
+	switch v.Kind() {
+	case log.KindBool:
+		dest.SetBool(v.AsBool())
+	case log.KindInt64:
+		dest.SetInt(v.AsInt64())
+	case log.KindFloat64:
+		dest.SetDouble(v.AsFloat64())
+	case log.KindString:
+		dest.SetStr(v.AsString())
+	case log.KindBytes:
+		dest.SetEmptyBytes().FromRaw(v.AsBytes())
+	case log.KindSlice:
+		slice := dest.SetEmptySlice()
+		for _, elem := range v.AsSlice() {
+			putLogValue(slice.AppendEmpty(), elem)
+		}
+	case log.KindMap:
+		m := dest.SetEmptyMap()
+		for _, kv := range v.AsMap() {
+			putLogValue(m.PutEmpty(kv.Key), kv.Value)
+		}
+	}
+}
+
+func putResource(dest pcommon.Resource, res *resource.Resource) {
+	putAttributes(dest.Attributes(), res.Attributes())
+}
+
+func putScope(dest pcommon.InstrumentationScope, scope instrumentation.Scope) {
+	dest.SetName(scope.Name)
+	dest.SetVersion(scope.Version)
+}
+
+func putAttributes(dest pcommon.Map, attrs []attribute.KeyValue) {
+	dest.EnsureCapacity(len(attrs))
+	for _, kv := range attrs {
+		putAttributeValue(dest.PutEmpty(string(kv.Key)), kv.Value)
+	}
+}
+
+func putAttributeValue(dest pcommon.Value, v attribute.Value) {
+	switch v.Type() {
+	case attribute.BOOL:
+		dest.SetBool(v.AsBool())
+	case attribute.INT64:
+		dest.SetInt(v.AsInt64())
+	case attribute.FLOAT64:
+		dest.SetDouble(v.AsFloat64())
+	case attribute.STRING:
+		dest.SetStr(v.AsString())
+	case attribute.BOOLSLICE:
+		slice := dest.SetEmptySlice()
+		for _, elem := range v.AsBoolSlice() {
+			slice.AppendEmpty().SetBool(elem)
+		}
+	case attribute.INT64SLICE:
+		slice := dest.SetEmptySlice()
+		for _, elem := range v.AsInt64Slice() {
+			slice.AppendEmpty().SetInt(elem)
+		}
+	case attribute.FLOAT64SLICE:
+		slice := dest.SetEmptySlice()
+		for _, elem := range v.AsFloat64Slice() {
+			slice.AppendEmpty().SetDouble(elem)
+		}
+	case attribute.STRINGSLICE:
+		slice := dest.SetEmptySlice()
+		for _, elem := range v.AsStringSlice() {
+			slice.AppendEmpty().SetStr(elem)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+// Fields set by the collector on the loggers of the components.
+const (
+	componentKindKey     = "kind"
+	componentNameKey     = "name"
+	componentPipelineKey = "pipeline"
+)
+
+// PipelineConsumers provides the consumers of the pipelines of the collector, see PipelineSource.
+type PipelineConsumers interface {
+	// TracesConsumer returns the consumer feeding the traces pipeline.
+	TracesConsumer(pipelineID component.ID) (consumer.Traces, error)
+	// MetricsConsumer returns the consumer feeding the metrics pipeline.
+	MetricsConsumer(pipelineID component.ID) (consumer.Metrics, error)
+	// LogsConsumer returns the consumer feeding the logs pipeline.
+	LogsConsumer(pipelineID component.ID) (consumer.Logs, error)
+	// PipelineComponents returns the instance IDs of the components of the pipeline.
+	PipelineComponents(pipelineID component.ID) ([]*component.InstanceID, error)
+}
+
+// PipelineSource feeds the telemetry of the collector into the pipelines configured with the
+// "pipeline" setting of the logs, metrics and traces, acting as an additional receiver of these
+// pipelines. The telemetry is batched, and dropped while the source is not started.
+//
+// To protect against the telemetry of a pipeline recursing through itself:
+//   - The spans started, and the log entries emitted with logctx.Field (e.g. by the exporter
+//     helper on a failed export), while the pipeline consumes the telemetry of the collector are
+//     not routed. The context is not kept by the sending queues of the exporters.
+//   - The log entries of the components of the pipeline, and the spans of its receivers and
+//     exporters, are not routed into the pipeline.
+//
+// The metrics are aggregated and read periodically, so they cannot recurse.
+type PipelineSource struct {
+	cfg Config
+
+	traces  atomic.Pointer[route[consumer.Traces]]
+	metrics atomic.Pointer[route[consumer.Metrics]]
+	logs    atomic.Pointer[route[consumer.Logs]]
+
+	// internalSpans are the spans started while consuming the telemetry of the collector.
+	internalSpans sync.Map
+
+	metricReader sdkmetric.Reader
+}
+
+// route is the pipeline a signal is routed into.
+type route[C any] struct {
+	consumer C
+	// components are the keys of the components of the pipeline whose telemetry is not routed
+	// into it: the componentstatus.InstanceKey of the components for the logs, and the
+	// spanComponentKey of the receivers and exporters for the spans.
+	components map[string]struct{}
+}
+
+func newPipelineSource(cfg Config) *PipelineSource {
+	ps := &PipelineSource{cfg: cfg}
+	if cfg.Metrics.Pipeline != (component.ID{}) {
+		ps.metricReader = sdkmetric.NewPeriodicReader(&pipelineMetricExporter{source: ps})
+	}
+	return ps
+}
+
+// MetricReader returns the reader feeding the metrics of the collector into the metrics
+// pipeline, or nil if the metrics are not routed into a pipeline.
+func (ps *PipelineSource) MetricReader() sdkmetric.Reader {
+	return ps.metricReader
+}
+
+// Start starts feeding the telemetry of the collector into the configured pipelines.
+func (ps *PipelineSource) Start(pipelines PipelineConsumers) error {
+	if id := ps.cfg.Traces.Pipeline; id != (component.ID{}) {
+		tc, err := pipelines.TracesConsumer(id)
+		if err != nil {
+			return err
+		}
+		components, err := pipelineComponents(pipelines, id, func(instanceID *component.InstanceID) string {
+			if instanceID.Kind != component.KindReceiver && instanceID.Kind != component.KindExporter {
+				return ""
+			}
+			return strings.ToLower(instanceID.Kind.String()) + "/" + instanceID.ID.String()
+		})
+		if err != nil {
+			return err
+		}
+		ps.traces.Store(&route[consumer.Traces]{consumer: tc, components: components})
+	}
+	if id := ps.cfg.Metrics.Pipeline; id != (component.ID{}) {
+		mc, err := pipelines.MetricsConsumer(id)
+		if err != nil {
+			return err
+		}
+		ps.metrics.Store(&route[consumer.Metrics]{consumer: mc})
+	}
+	if id := ps.cfg.Logs.Pipeline; id != (component.ID{}) {
+		lc, err := pipelines.LogsConsumer(id)
+		if err != nil {
+			return err
+		}
+		components, err := pipelineComponents(pipelines, id, componentstatus.InstanceKey)
+		if err != nil {
+			return err
+		}
+		ps.logs.Store(&route[consumer.Logs]{consumer: lc, components: components})
+	}
+	return nil
+}
+
+// Shutdown stops feeding the telemetry of the collector into the pipelines.
+func (ps *PipelineSource) Shutdown() {
+	ps.traces.Store(nil)
+	ps.metrics.Store(nil)
+	ps.logs.Store(nil)
+}
+
+func pipelineComponents(pipelines PipelineConsumers, pipelineID component.ID, key func(*component.InstanceID) string) (map[string]struct{}, error) {
+	instanceIDs, err := pipelines.PipelineComponents(pipelineID)
+	if err != nil {
+		return nil, err
+	}
+	components := make(map[string]struct{}, len(instanceIDs))
+	for _, instanceID := range instanceIDs {
+		if k := key(instanceID); k != "" {
+			components[k] = struct{}{}
+		}
+	}
+	return components, nil
+}
+
+type internalTelemetryKey struct{}
+
+// internalTelemetryContext marks the context of the telemetry of the collector consumed by a pipeline.
+func internalTelemetryContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, internalTelemetryKey{}, true)
+}
+
+func isInternalTelemetry(ctx context.Context) bool {
+	return ctx.Value(internalTelemetryKey{}) != nil
+}
+
+// pipelineSpanProcessor filters the spans routed into the traces pipeline, before batching them.
+type pipelineSpanProcessor struct {
+	source *PipelineSource
+	next   sdktrace.SpanProcessor
+}
+
+func newPipelineSpanProcessor(source *PipelineSource) *pipelineSpanProcessor {
+	return &pipelineSpanProcessor{
+		source: source,
+		next:   sdktrace.NewBatchSpanProcessor(&pipelineSpanExporter{source: source}),
+	}
+}
+
+func (p *pipelineSpanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	if isInternalTelemetry(parent) {
+		p.source.internalSpans.Store(s.SpanContext().SpanID(), struct{}{})
+		return
+	}
+	p.next.OnStart(parent, s)
+}
+
+func (p *pipelineSpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if _, internal := p.source.internalSpans.LoadAndDelete(s.SpanContext().SpanID()); internal {
+		return
+	}
+	if r := p.source.traces.Load(); r != nil {
+		if _, ok := r.components[spanComponentKey(s)]; ok {
+			return
+		}
+	}
+	p.next.OnEnd(s)
+}
+
+// spanComponentKey returns the kind and ID of the receiver or exporter which started the span,
+// e.g. "receiver/otlp". The spans of the receivers and exporters are started by a tracer named
+// after their ID, and their names are prefixed with their kind and ID, e.g.
+// "receiver/otlp/TraceDataReceived".
+func spanComponentKey(s sdktrace.ReadOnlySpan) string {
+	id := s.InstrumentationScope().Name
+	kind, rest, ok := strings.Cut(s.Name(), "/")
+	if !ok || !strings.HasPrefix(rest, id+"/") {
+		return ""
+	}
+	return kind + "/" + id
+}
+
+func (p *pipelineSpanProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+func (p *pipelineSpanProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+type pipelineSpanExporter struct {
+	source *PipelineSource
+}
+
+func (e *pipelineSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	r := e.source.traces.Load()
+	if r == nil {
+		return nil
+	}
+	return r.consumer.ConsumeTraces(internalTelemetryContext(ctx), tracesFromSpans(spans))
+}
+
+func (e *pipelineSpanExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+type pipelineMetricExporter struct {
+	source *PipelineSource
+}
+
+func (e *pipelineMetricExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	return sdkmetric.DefaultTemporalitySelector(kind)
+}
+
+func (e *pipelineMetricExporter) Aggregation(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
+
+func (e *pipelineMetricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	r := e.source.metrics.Load()
+	if r == nil {
+		return nil
+	}
+	return r.consumer.ConsumeMetrics(internalTelemetryContext(ctx), metricsFromResourceMetrics(rm))
+}
+
+func (e *pipelineMetricExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (e *pipelineMetricExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+// pipelineLogProcessor filters the log records routed into the logs pipeline, before batching them.
+type pipelineLogProcessor struct {
+	source *PipelineSource
+	next   sdklog.Processor
+}
+
+func newPipelineLogProcessor(source *PipelineSource) *pipelineLogProcessor {
+	return &pipelineLogProcessor{
+		source: source,
+		next:   sdklog.NewBatchProcessor(&pipelineLogExporter{source: source}),
+	}
+}
+
+func (p *pipelineLogProcessor) OnEmit(ctx context.Context, record sdklog.Record) error {
+	if !p.routed(ctx, record) {
+		return nil
+	}
+	return p.next.OnEmit(ctx, record)
+}
+
+func (p *pipelineLogProcessor) Enabled(ctx context.Context, record sdklog.Record) bool {
+	return p.routed(ctx, record) && p.next.Enabled(ctx, record)
+}
+
+func (p *pipelineLogProcessor) routed(ctx context.Context, record sdklog.Record) bool {
+	if isInternalTelemetry(ctx) {
+		return false
+	}
+	r := p.source.logs.Load()
+	if r == nil {
+		return true
+	}
+	var kind, name, pipeline string
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		switch kv.Key {
+		case componentKindKey:
+			kind = kv.Value.AsString()
+		case componentNameKey:
+			name = kv.Value.AsString()
+		case componentPipelineKey:
+			pipeline = kv.Value.AsString()
+		}
+		return true
+	})
+	if kind == "" {
+		return true
+	}
+	// Processors are instantiated per pipeline.
+	if kind == strings.ToLower(component.KindProcessor.String()) && pipeline != p.source.cfg.Logs.Pipeline.String() {
+		return true
+	}
+	_, excluded := r.components[kind+":"+name]
+	return !excluded
+}
+
+func (p *pipelineLogProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+func (p *pipelineLogProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+type pipelineLogExporter struct {
+	source *PipelineSource
+}
+
+func (e *pipelineLogExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	r := e.source.logs.Load()
+	if r == nil {
+		return nil
+	}
+	return r.consumer.ConsumeLogs(internalTelemetryContext(ctx), logsFromRecords(records))
+}
+
+func (e *pipelineLogExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func (e *pipelineLogExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+var (
+	_ sdktrace.SpanProcessor = (*pipelineSpanProcessor)(nil)
+	_ sdkmetric.Exporter     = (*pipelineMetricExporter)(nil)
+	_ sdklog.Processor       = (*pipelineLogProcessor)(nil)
+)
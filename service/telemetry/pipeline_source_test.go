@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/logctx"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+type testPipelines struct {
+	traces     *consumertest.TracesSink
+	metrics    *consumertest.MetricsSink
+	logs       *consumertest.LogsSink
+	components []*component.InstanceID
+}
+
+func newTestPipelines() *testPipelines {
+	return &testPipelines{
+		traces:  new(consumertest.TracesSink),
+		metrics: new(consumertest.MetricsSink),
+		logs:    new(consumertest.LogsSink),
+		components: []*component.InstanceID{
+			{ID: component.MustNewID("nop"), Kind: component.KindReceiver},
+			{ID: component.MustNewID("batch"), Kind: component.KindProcessor},
+			{ID: component.MustNewID("otlp"), Kind: component.KindExporter},
+		},
+	}
+}
+
+func (p *testPipelines) TracesConsumer(pipelineID component.ID) (consumer.Traces, error) {
+	if pipelineID.Type() != component.DataTypeTraces {
+		return nil, fmt.Errorf("pipeline %q not found", pipelineID)
+	}
+	return p.traces, nil
+}
+
+func (p *testPipelines) MetricsConsumer(pipelineID component.ID) (consumer.Metrics, error) {
+	if pipelineID.Type() != component.DataTypeMetrics {
+		return nil, fmt.Errorf("pipeline %q not found", pipelineID)
+	}
+	return p.metrics, nil
+}
+
+func (p *testPipelines) LogsConsumer(pipelineID component.ID) (consumer.Logs, error) {
+	if pipelineID.Type() != component.DataTypeLogs {
+		return nil, fmt.Errorf("pipeline %q not found", pipelineID)
+	}
+	return p.logs, nil
+}
+
+func (p *testPipelines) PipelineComponents(component.ID) ([]*component.InstanceID, error) {
+	return p.components, nil
+}
+
+func newPipelineTelemetry(t *testing.T, cfg Config) (*Telemetry, *testPipelines) {
+	cfg.Logs.Level = zapcore.InfoLevel
+	cfg.Logs.Encoding = "console"
+	tel, err := New(context.Background(), Settings{BuildInfo: component.NewDefaultBuildInfo()}, cfg)
+	require.NoError(t, err)
+	pipelines := newTestPipelines()
+	require.NoError(t, tel.PipelineSource().Start(pipelines))
+	return tel, pipelines
+}
+
+func TestPipelineSourceLogs(t *testing.T) {
+	tel, pipelines := newPipelineTelemetry(t, Config{Logs: LogsConfig{Pipeline: component.MustNewID("logs")}})
+	logger := tel.Logger()
+
+	logger.Info("Everything is ready.")
+	// The logs of the components of the pipeline are not routed into it.
+	logger.Info("Receiver log.", zap.String("kind", "receiver"), zap.String("name", "nop"))
+	logger.Info("Processor log.", zap.String("kind", "processor"), zap.String("name", "batch"), zap.String("pipeline", "logs"))
+	logger.Info("Other processor log.", zap.String("kind", "processor"), zap.String("name", "batch"), zap.String("pipeline", "logs/other"))
+	logger.Info("Other exporter log.", zap.String("kind", "exporter"), zap.String("name", "debug"))
+	// Nor are the logs emitted while consuming the telemetry of the collector.
+	logger.Info("Internal log.", logctx.Field(internalTelemetryContext(context.Background())))
+	require.NoError(t, tel.Shutdown(context.Background()))
+
+	var bodies []string
+	for _, ld := range pipelines.logs.AllLogs() {
+		assert.Equal(t, "INFO", ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SeverityText())
+		forEachLogRecord(ld, func(lr plog.LogRecord) {
+			bodies = append(bodies, lr.Body().Str())
+		})
+	}
+	assert.Equal(t, []string{"Everything is ready.", "Other processor log.", "Other exporter log."}, bodies)
+}
+
+func TestPipelineSourceNotStarted(t *testing.T) {
+	tel, err := New(context.Background(), Settings{}, Config{Logs: LogsConfig{Level: zapcore.InfoLevel, Encoding: "console", Pipeline: component.MustNewID("logs")}})
+	require.NoError(t, err)
+	pipelines := newTestPipelines()
+	require.NoError(t, tel.PipelineSource().Start(pipelines))
+	tel.PipelineSource().Shutdown()
+
+	tel.Logger().Info("Dropped.")
+	require.NoError(t, tel.Shutdown(context.Background()))
+	assert.Zero(t, pipelines.logs.LogRecordCount())
+}
+
+func TestPipelineSourceTraces(t *testing.T) {
+	tel, pipelines := newPipelineTelemetry(t, Config{Traces: TracesConfig{Pipeline: component.MustNewID("traces")}})
+
+	ctx, span := tel.TracerProvider().Tracer("receiver/test").Start(context.Background(), "parent")
+	_, child := tel.TracerProvider().Tracer("receiver/test").Start(ctx, "child", trace.WithAttributes(attribute.String("key", "value")))
+	child.End()
+	span.End()
+	// The spans of the receivers and exporters of the pipeline are not routed into it.
+	_, exporterSpan := tel.TracerProvider().Tracer("otlp").Start(context.Background(), "exporter/otlp/traces")
+	exporterSpan.End()
+	_, receiverSpan := tel.TracerProvider().Tracer("nop").Start(context.Background(), "receiver/nop/TraceDataReceived")
+	receiverSpan.End()
+	// Unlike the spans of the other components with the same ID.
+	_, otherSpan := tel.TracerProvider().Tracer("otlp").Start(context.Background(), "receiver/otlp/TraceDataReceived")
+	otherSpan.End()
+	// Nor are the spans started while consuming the telemetry of the collector.
+	_, internalSpan := tel.TracerProvider().Tracer("receiver/test").Start(internalTelemetryContext(context.Background()), "internal")
+	internalSpan.End()
+	require.NoError(t, tel.Shutdown(context.Background()))
+
+	require.Equal(t, 3, pipelines.traces.SpanCount())
+	var names []string
+	for _, td := range pipelines.traces.AllTraces() {
+		for i := 0; i < td.ResourceSpans().At(0).ScopeSpans().Len(); i++ {
+			spans := td.ResourceSpans().At(0).ScopeSpans().At(i).Spans()
+			for j := 0; j < spans.Len(); j++ {
+				names = append(names, spans.At(j).Name())
+			}
+		}
+	}
+	assert.ElementsMatch(t, []string{"parent", "child", "receiver/otlp/TraceDataReceived"}, names)
+}
+
+func TestPipelineSourceMetrics(t *testing.T) {
+	ps := newPipelineSource(Config{Metrics: MetricsConfig{Pipeline: component.MustNewID("metrics")}})
+	require.NotNil(t, ps.MetricReader())
+	pipelines := newTestPipelines()
+	require.NoError(t, ps.Start(pipelines))
+
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(ps.MetricReader()))
+	counter, err := mp.Meter("test").Int64Counter("requests")
+	require.NoError(t, err)
+	counter.Add(context.Background(), 3)
+	require.NoError(t, mp.Shutdown(context.Background()))
+
+	require.Len(t, pipelines.metrics.AllMetrics(), 1)
+	m := pipelines.metrics.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "requests", m.Name())
+	require.Equal(t, pmetric.MetricTypeSum, m.Type())
+	assert.Equal(t, int64(3), m.Sum().DataPoints().At(0).IntValue())
+	assert.True(t, m.Sum().IsMonotonic())
+}
+
+func TestPipelineSourceStartError(t *testing.T) {
+	ps := newPipelineSource(Config{Logs: LogsConfig{Pipeline: component.MustNewID("traces")}})
+	assert.EqualError(t, ps.Start(newTestPipelines()), `pipeline "traces" not found`)
+	assert.Nil(t, ps.MetricReader())
+}
+
+func forEachLogRecord(ld plog.Logs, f func(plog.LogRecord)) {
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		for j := 0; j < ld.ResourceLogs().At(i).ScopeLogs().Len(); j++ {
+			lrs := ld.ResourceLogs().At(i).ScopeLogs().At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				f(lrs.At(k))
+			}
+		}
+	}
+}
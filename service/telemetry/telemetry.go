@@ -6,11 +6,15 @@ package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/contrib/config"
 	"go.opentelemetry.io/contrib/propagators/b3"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/noop"
 	"go.opentelemetry.io/otel/propagation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/multierr"
@@ -18,6 +22,8 @@ import (
 	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service/internal/proctelemetry"
+	"go.opentelemetry.io/collector/service/internal/resource"
 )
 
 const (
@@ -32,7 +38,9 @@ var (
 
 type Telemetry struct {
 	logger         *zap.Logger
+	loggerProvider log.LoggerProvider
 	tracerProvider trace.TracerProvider
+	pipelineSource *PipelineSource
 }
 
 func (t *Telemetry) TracerProvider() trace.TracerProvider {
@@ -43,15 +51,27 @@ func (t *Telemetry) Logger() *zap.Logger {
 	return t.logger
 }
 
+// LoggerProvider returns the provider emitting the logs of the collector with the configured
+// LogsConfig processors.
+func (t *Telemetry) LoggerProvider() log.LoggerProvider {
+	return t.loggerProvider
+}
+
+// PipelineSource returns the source feeding the telemetry of the collector into its pipelines.
+func (t *Telemetry) PipelineSource() *PipelineSource {
+	return t.pipelineSource
+}
+
 func (t *Telemetry) Shutdown(ctx context.Context) error {
 	// TODO: Sync logger.
+	var err error
+	if lp, ok := t.loggerProvider.(*sdklog.LoggerProvider); ok {
+		err = multierr.Append(err, lp.Shutdown(ctx))
+	}
 	if tp, ok := t.tracerProvider.(*sdktrace.TracerProvider); ok {
-		return multierr.Combine(
-			tp.Shutdown(ctx),
-		)
+		err = multierr.Append(err, tp.Shutdown(ctx))
 	}
-	// should this return an error?
-	return nil
+	return err
 }
 
 // Settings holds configuration for building Telemetry.
@@ -62,11 +82,18 @@ type Settings struct {
 
 // New creates a new Telemetry from Config.
 func New(ctx context.Context, set Settings, cfg Config) (*Telemetry, error) {
-	logger, err := newLogger(cfg.Logs, set.ZapOptions)
+	ps := newPipelineSource(cfg)
+
+	lp, err := newLoggerProvider(ctx, set, cfg, ps)
 	if err != nil {
 		return nil, err
 	}
 
+	logger, err := newLogger(cfg.Logs, set.ZapOptions, lp)
+	if err != nil {
+		return nil, multierr.Append(err, shutdownLoggerProvider(ctx, lp))
+	}
+
 	sdk, err := config.NewSDK(
 		config.WithContext(ctx),
 		config.WithOpenTelemetryConfiguration(
@@ -98,21 +125,70 @@ func New(ctx context.Context, set Settings, cfg Config) (*Telemetry, error) {
 	)
 
 	if err != nil {
-		return nil, err
+		return nil, multierr.Append(err, shutdownLoggerProvider(ctx, lp))
+	}
+
+	if cfg.Traces.Pipeline != (component.ID{}) {
+		if tp, ok := sdk.TracerProvider().(*sdktrace.TracerProvider); ok {
+			tp.RegisterSpanProcessor(newPipelineSpanProcessor(ps))
+		}
 	}
 
 	if tp, err := textMapPropagatorFromConfig(cfg.Traces.Propagators); err == nil {
 		otel.SetTextMapPropagator(tp)
 	} else {
-		return nil, err
+		return nil, multierr.Combine(err, shutdownLoggerProvider(ctx, lp), sdk.Shutdown(ctx))
 	}
 
 	return &Telemetry{
 		logger:         logger,
+		loggerProvider: lp,
 		tracerProvider: sdk.TracerProvider(),
+		pipelineSource: ps,
 	}, nil
 }
 
+// newLoggerProvider creates the provider emitting the logs of the collector with the configured
+// processors and into the configured pipeline, or a no-op provider if there is none.
+func newLoggerProvider(ctx context.Context, set Settings, cfg Config, ps *PipelineSource) (log.LoggerProvider, error) {
+	if !emitsOTelLogs(cfg.Logs) {
+		return noop.NewLoggerProvider(), nil
+	}
+
+	opts := []sdklog.LoggerProviderOption{
+		sdklog.WithResource(resource.New(set.BuildInfo, cfg.Resource)),
+	}
+	var processors []sdklog.Processor
+	for _, processorCfg := range cfg.Logs.Processors {
+		processor, err := proctelemetry.InitLogRecordProcessor(ctx, processorCfg)
+		if err != nil {
+			err = fmt.Errorf("failed to create log record processor: %w", err)
+			for _, created := range processors {
+				err = multierr.Append(err, created.Shutdown(ctx))
+			}
+			return nil, err
+		}
+		processors = append(processors, processor)
+		opts = append(opts, sdklog.WithProcessor(processor))
+	}
+	if cfg.Logs.Pipeline != (component.ID{}) {
+		opts = append(opts, sdklog.WithProcessor(newPipelineLogProcessor(ps)))
+	}
+	return sdklog.NewLoggerProvider(opts...), nil
+}
+
+// emitsOTelLogs returns true if the logs of the collector are emitted with the OpenTelemetry logs SDK.
+func emitsOTelLogs(cfg LogsConfig) bool {
+	return len(cfg.Processors) > 0 || cfg.Pipeline != (component.ID{})
+}
+
+func shutdownLoggerProvider(ctx context.Context, lp log.LoggerProvider) error {
+	if sdkLP, ok := lp.(*sdklog.LoggerProvider); ok {
+		return sdkLP.Shutdown(ctx)
+	}
+	return nil
+}
+
 func textMapPropagatorFromConfig(props []string) (propagation.TextMapPropagator, error) {
 	var textMapPropagators []propagation.TextMapPropagator
 	for _, prop := range props {
@@ -128,7 +204,7 @@ func textMapPropagatorFromConfig(props []string) (propagation.TextMapPropagator,
 	return propagation.NewCompositeTextMapPropagator(textMapPropagators...), nil
 }
 
-func newLogger(cfg LogsConfig, options []zap.Option) (*zap.Logger, error) {
+func newLogger(cfg LogsConfig, options []zap.Option, lp log.LoggerProvider) (*zap.Logger, error) {
 	// Copied from NewProductionConfig.
 	zapCfg := &zap.Config{
 		Level:             zap.NewAtomicLevelAt(cfg.Level),
@@ -151,6 +227,12 @@ func newLogger(cfg LogsConfig, options []zap.Option) (*zap.Logger, error) {
 	if err != nil {
 		return nil, err
 	}
+	if emitsOTelLogs(cfg) {
+		// Emit the logs to the processors and the pipeline in addition to the outputs.
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(core, newOTelCore(lp, zapCfg.Level))
+		}))
+	}
 	if cfg.Sampling != nil && cfg.Sampling.Enabled {
 		logger = newSampledLogger(logger, cfg.Sampling)
 	}
@@ -5,17 +5,23 @@ package telemetry
 
 import (
 	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/config"
 	"go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/noop"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 )
 
 func TestTelemetryConfiguration(t *testing.T) {
@@ -141,3 +147,66 @@ func TestTelemetryShutdown(t *testing.T) {
 		})
 	}
 }
+
+func TestTelemetryLogsProcessors(t *testing.T) {
+	requests := make(chan plogotlp.ExportRequest, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		req := plogotlp.NewExportRequest()
+		assert.NoError(t, req.UnmarshalProto(body))
+		requests <- req
+	}))
+	defer srv.Close()
+
+	cfg := Config{
+		Logs: LogsConfig{
+			Level:       zapcore.InfoLevel,
+			Encoding:    "console",
+			OutputPaths: []string{"stderr"},
+			Processors: []config.LogRecordProcessor{
+				{
+					Simple: &config.SimpleLogRecordProcessor{
+						Exporter: config.LogRecordExporter{
+							OTLP: &config.OTLP{
+								Protocol: "http/protobuf",
+								Endpoint: srv.URL + "/v1/logs",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	tel, err := New(context.Background(), Settings{BuildInfo: component.NewDefaultBuildInfo()}, cfg)
+	require.NoError(t, err)
+
+	tel.Logger().Info("Everything is ready.", zap.String("kind", "service"))
+	require.NoError(t, tel.Shutdown(context.Background()))
+
+	require.Len(t, requests, 1)
+	req := <-requests
+	require.Equal(t, 1, req.Logs().LogRecordCount())
+	rl := req.Logs().ResourceLogs().At(0)
+	serviceName, ok := rl.Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, component.NewDefaultBuildInfo().Command, serviceName.Str())
+	lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "Everything is ready.", lr.Body().Str())
+	assert.Equal(t, "INFO", lr.SeverityText())
+	kind, ok := lr.Attributes().Get("kind")
+	require.True(t, ok)
+	assert.Equal(t, "service", kind.Str())
+}
+
+func TestTelemetryLogsProcessorsInvalid(t *testing.T) {
+	cfg := Config{
+		Logs: LogsConfig{
+			Encoding:    "console",
+			OutputPaths: []string{"stderr"},
+			Processors:  []config.LogRecordProcessor{{}},
+		},
+	}
+	_, err := New(context.Background(), Settings{}, cfg)
+	assert.ErrorContains(t, err, "failed to create log record processor")
+}
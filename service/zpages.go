@@ -7,10 +7,14 @@ import (
 	"net/http"
 	"path"
 	"runtime"
+	"sort"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/service/internal/zpages"
 )
 
@@ -20,6 +24,8 @@ const (
 	zPipelinePath  = "pipelinez"
 	zExtensionPath = "extensionz"
 	zFeaturePath   = "featurez"
+	zStatusPath    = "statusz"
+	zTopologyPath  = "topologyz"
 )
 
 var (
@@ -42,6 +48,8 @@ func (host *serviceHost) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 	mux.HandleFunc(path.Join(pathPrefix, zPipelinePath), host.pipelines.HandleZPages)
 	mux.HandleFunc(path.Join(pathPrefix, zExtensionPath), host.serviceExtensions.HandleZPages)
 	mux.HandleFunc(path.Join(pathPrefix, zFeaturePath), handleFeaturezRequest)
+	mux.HandleFunc(path.Join(pathPrefix, zStatusPath), host.handleStatuszRequest)
+	mux.HandleFunc(path.Join(pathPrefix, zTopologyPath), host.pipelines.HandleTopologyZPages)
 }
 
 func (host *serviceHost) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
@@ -54,11 +62,21 @@ func (host *serviceHost) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
 		ComponentEndpoint: zPipelinePath,
 		Link:              true,
 	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Topology",
+		ComponentEndpoint: zTopologyPath,
+		Link:              true,
+	})
 	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
 		Name:              "Extensions",
 		ComponentEndpoint: zExtensionPath,
 		Link:              true,
 	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Status",
+		ComponentEndpoint: zStatusPath,
+		Link:              true,
+	})
 	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
 		Name:              "Features",
 		ComponentEndpoint: zFeaturePath,
@@ -74,6 +92,47 @@ func handleFeaturezRequest(w http.ResponseWriter, _ *http.Request) {
 	zpages.WriteHTMLPageFooter(w)
 }
 
+func (host *serviceHost) handleStatuszRequest(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Status"})
+	zpages.WriteHTMLStatusTable(w, getStatusTableData(host.statusAggregator.Status()))
+	zpages.WriteHTMLPageFooter(w)
+}
+
+func getStatusTableData(st *componentstatus.AggregateStatus) zpages.StatusTableData {
+	data := zpages.StatusTableData{}
+	var appendRows func(name string, depth int, st *componentstatus.AggregateStatus)
+	appendRows = func(name string, depth int, st *componentstatus.AggregateStatus) {
+		row := zpages.StatusTableRowData{Name: name, Depth: depth, Status: component.StatusNone.String()}
+		if st.Event != nil {
+			row.Status = st.Event.Status().String()
+			row.Timestamp = st.Event.Timestamp().String()
+			if st.Event.Err() != nil {
+				row.Error = st.Event.Err().Error()
+			}
+			var attrs []string
+			st.Event.Attributes().Range(func(k string, v pcommon.Value) bool {
+				attrs = append(attrs, k+"="+v.AsString())
+				return true
+			})
+			sort.Strings(attrs)
+			row.Attributes = strings.Join(attrs, ", ")
+		}
+		data.Rows = append(data.Rows, row)
+
+		names := make([]string, 0, len(st.Children))
+		for child := range st.Children {
+			names = append(names, child)
+		}
+		sort.Strings(names)
+		for _, child := range names {
+			appendRows(child, depth+1, st.Children[child])
+		}
+	}
+	appendRows("collector", 0, st)
+	return data
+}
+
 func getFeaturesTableData() zpages.FeatureGateTableData {
 	data := zpages.FeatureGateTableData{}
 	featuregate.GlobalRegistry().VisitAll(func(gate *featuregate.Gate) {